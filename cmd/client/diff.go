@@ -0,0 +1,238 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+)
+
+var diffFileCmdOpts struct {
+	Shallow bool
+	NoColor bool
+	Context int
+}
+
+// diffCmd groups the commands that compare data
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compares data stored in Bhojpur Data",
+}
+
+// diffFileCmd represents the diff file command
+var diffFileCmd = &cobra.Command{
+	Use:   "file <repo>@<branch-or-commit>[:<path>] [<repo>@<branch-or-commit>[:<path>]]",
+	Short: "Shows the differences between two files or directories",
+	Long: "Shows the differences between two files or directories as a unified diff. " +
+		"The old file defaults to the same path in the parent of the new file's commit.",
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		req := &pfs.DiffFileRequest{Shallow: diffFileCmdOpts.Shallow}
+		var err error
+		if req.NewFile, err = pfs.ParseFile(args[0]); err != nil {
+			return err
+		}
+		if len(args) > 1 {
+			if req.OldFile, err = pfs.ParseFile(args[1]); err != nil {
+				return err
+			}
+		}
+
+		conn := dial()
+		defer conn.Close()
+		client := pfs.NewAPIClient(conn)
+		ctx := context.Background()
+		stream, err := client.DiffFile(ctx, req)
+		if err != nil {
+			return err
+		}
+		d := &differ{
+			client:  client,
+			context: diffFileCmdOpts.Context,
+			color:   !diffFileCmdOpts.NoColor && term.IsTerminal(int(os.Stdout.Fd())),
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			if err := d.write(ctx, os.Stdout, resp); err != nil {
+				return err
+			}
+		}
+	},
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiCyan  = "\x1b[36m"
+)
+
+// differ renders DiffFileResponses as unified diffs.
+type differ struct {
+	client  pfs.APIClient
+	context int
+	color   bool
+}
+
+// write renders a single DiffFileResponse to w.
+func (d *differ) write(ctx context.Context, w io.Writer, resp *pfs.DiffFileResponse) error {
+	oldName, newName := "/dev/null", "/dev/null"
+	if resp.OldFile != nil {
+		oldName = fileName(resp.OldFile)
+	}
+	if resp.NewFile != nil {
+		newName = fileName(resp.NewFile)
+	}
+	if isDir(resp.OldFile) || isDir(resp.NewFile) {
+		var line string
+		switch {
+		case resp.OldFile == nil:
+			line = d.paint(ansiGreen, "added "+newName)
+		case resp.NewFile == nil:
+			line = d.paint(ansiRed, "removed "+oldName)
+		default:
+			line = d.paint(ansiCyan, "modified "+newName)
+		}
+		_, err := fmt.Fprintln(w, line)
+		return err
+	}
+
+	oldData, err := d.getFile(ctx, resp.OldFile)
+	if err != nil {
+		return err
+	}
+	newData, err := d.getFile(ctx, resp.NewFile)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, d.paint(ansiBold, "diff "+oldName+" "+newName)); err != nil {
+		return err
+	}
+	if bytes.IndexByte(oldData, 0) >= 0 || bytes.IndexByte(newData, 0) >= 0 {
+		_, err := fmt.Fprintf(w, "Binary files %s and %s differ\n", oldName, newName)
+		return err
+	}
+	text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(oldData),
+		B:        splitLines(newData),
+		FromFile: oldName,
+		ToFile:   newName,
+		Context:  d.context,
+	})
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			line = d.paint(ansiBold, line)
+		case strings.HasPrefix(line, "@@"):
+			line = d.paint(ansiCyan, line)
+		case strings.HasPrefix(line, "-"):
+			line = d.paint(ansiRed, line)
+		case strings.HasPrefix(line, "+"):
+			line = d.paint(ansiGreen, line)
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// paint colours s, leaving any trailing newline uncoloured.
+func (d *differ) paint(color, s string) string {
+	if !d.color || s == "" {
+		return s
+	}
+	trimmed := strings.TrimSuffix(s, "\n")
+	return color + trimmed + ansiReset + s[len(trimmed):]
+}
+
+// getFile returns the content of a file, or nothing if info is nil.
+func (d *differ) getFile(ctx context.Context, info *pfs.FileInfo) ([]byte, error) {
+	if info == nil {
+		return nil, nil
+	}
+	stream, err := d.client.GetFile(ctx, &pfs.GetFileRequest{File: info.File})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot get %s: %w", fileName(info), err)
+		}
+		buf.Write(chunk.Value)
+	}
+}
+
+// splitLines splits data into newline terminated lines, terminating the last
+// line if necessary.
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if last := lines[len(lines)-1]; last == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] = last + "\n"
+	}
+	return lines
+}
+
+func fileName(info *pfs.FileInfo) string {
+	return info.File.Commit.Key() + ":" + info.File.Path
+}
+
+func isDir(info *pfs.FileInfo) bool {
+	return info != nil && info.FileType == pfs.FileType_DIR
+}
+
+func init() {
+	diffFileCmd.Flags().BoolVar(&diffFileCmdOpts.Shallow, "shallow", false, "only compare the entries directly beneath a directory instead of every file beneath it")
+	diffFileCmd.Flags().BoolVar(&diffFileCmdOpts.NoColor, "no-color", false, "disable coloured output, which is otherwise used when writing to a terminal")
+	diffFileCmd.Flags().IntVar(&diffFileCmdOpts.Context, "context", 3, "number of unchanged lines to show around each change")
+	diffCmd.AddCommand(diffFileCmd)
+	rootCmd.AddCommand(diffCmd)
+}
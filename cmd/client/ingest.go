@@ -0,0 +1,130 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	ppsserver "github.com/bhojpur/data/pkg/pps/server"
+)
+
+var ingestSQLCmdOpts struct {
+	URL              string
+	Query            string
+	Secret           string
+	SecretKey        string
+	Delimiter        string
+	TargetFileDatums int64
+	TargetFileBytes  int64
+	Cron             string
+	Overwrite        bool
+}
+
+// ingestCmd groups the commands that bring external data into Bhojpur Data
+var ingestCmd = &cobra.Command{
+	Use:   "ingest",
+	Short: "Ingests data from external systems into Bhojpur Data",
+}
+
+// ingestSQLCmd represents the ingest sql command
+var ingestSQLCmd = &cobra.Command{
+	Use:   "sql <repo>@<branch>:<path>",
+	Short: "Commits a snapshot of the result of a SQL query",
+	Long: "Runs a query against a Postgres database and commits the result to the files beneath a path, " +
+		"replacing what was there. The result is written as CSV with a header or as JSON lines, split into " +
+		"numbered files on row boundaries. With --cron the snapshot is taken on every tick of a schedule " +
+		"until the command is interrupted; scheduled snapshots always commit to master.",
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := pfs.ParseFile(args[0])
+		if err != nil {
+			return err
+		}
+		delimiter, ok := pfs.Delimiter_value[strings.ToUpper(ingestSQLCmdOpts.Delimiter)]
+		if !ok {
+			return fmt.Errorf("invalid delimiter %q: must be csv or json", ingestSQLCmdOpts.Delimiter)
+		}
+		ingest := &pfs.SQLIngest{
+			Url:              ingestSQLCmdOpts.URL,
+			Query:            ingestSQLCmdOpts.Query,
+			Delimiter:        pfs.Delimiter(delimiter),
+			Path:             file.Path,
+			TargetFileDatums: ingestSQLCmdOpts.TargetFileDatums,
+			TargetFileBytes:  ingestSQLCmdOpts.TargetFileBytes,
+		}
+		if ingestSQLCmdOpts.Secret != "" {
+			ingest.Secret = &pfs.SQLDatabaseEgress_Secret{Name: ingestSQLCmdOpts.Secret, Key: ingestSQLCmdOpts.SecretKey}
+		}
+
+		conn := dial()
+		defer conn.Close()
+		client := pfs.NewAPIClient(conn)
+		if ingestSQLCmdOpts.Cron == "" {
+			resp, err := client.IngestSQL(context.Background(), &pfs.IngestSQLRequest{Branch: file.Commit.Branch, Sql: ingest})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s: %d rows\n", resp.Commit, resp.RowsRead)
+			return nil
+		}
+		if file.Commit.Branch.Name != "master" || file.Commit.Id != "" {
+			return fmt.Errorf("scheduled snapshots always commit to master, not %s", file.Commit)
+		}
+		ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer cancel()
+		err = ppsserver.RunCron(ctx, client, &pps.CronInput{
+			Name:      path.Base(file.Path),
+			Repo:      file.Commit.Branch.Repo.Name,
+			Spec:      ingestSQLCmdOpts.Cron,
+			Overwrite: ingestSQLCmdOpts.Overwrite,
+			Sql:       ingest,
+		})
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	},
+}
+
+func init() {
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.URL, "url", "", "Postgres connection URL of the database to query")
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.Query, "query", "", "query whose result is committed")
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.Secret, "secret", "", "name of the secret holding the database password, which is otherwise taken from the URL")
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.SecretKey, "secret-key", "password", "key of the database password in the secret")
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.Delimiter, "delimiter", "csv", "format of the files, csv or json")
+	ingestSQLCmd.Flags().Int64Var(&ingestSQLCmdOpts.TargetFileDatums, "target-file-datums", 0, "number of rows in each file")
+	ingestSQLCmd.Flags().Int64Var(&ingestSQLCmdOpts.TargetFileBytes, "target-file-bytes", 0, "size in bytes each file is cut at; with neither this nor --target-file-datums each row gets its own file")
+	ingestSQLCmd.Flags().StringVar(&ingestSQLCmdOpts.Cron, "cron", "", "cron spec on whose ticks to take snapshots, such as \"@daily\" or \"0 2 * * *\"")
+	ingestSQLCmd.Flags().BoolVar(&ingestSQLCmdOpts.Overwrite, "overwrite", true, "with --cron, replace the previous snapshot on each tick rather than adding one beneath path named after the tick time")
+	ingestSQLCmd.MarkFlagRequired("url")
+	ingestSQLCmd.MarkFlagRequired("query")
+	ingestCmd.AddCommand(ingestSQLCmd)
+	rootCmd.AddCommand(ingestCmd)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	pfsserver "github.com/bhojpur/data/pkg/pfs/server"
+)
+
+var loadTestCmdOpts struct {
+	Branch string
+	Seed   int64
+}
+
+// loadTestCmd represents the load-test command
+var loadTestCmd = &cobra.Command{
+	Use:   "load-test [<spec-file>]",
+	Short: "Runs a PFS load test and reports its throughput and latencies",
+	Long: "Runs a load test against PFS, which makes commits of generated files as described by a YAML spec, " +
+		"and reports the latency percentiles of each kind of operation. The same spec and seed always make " +
+		"the same commits. Without a spec file the default load test runs, with a random seed unless --seed is given.",
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		conn := dial()
+		defer conn.Close()
+		client := pfs.NewAPIClient(conn)
+		ctx := context.Background()
+		var resp *pfs.RunLoadTestResponse
+		if len(args) == 0 && !cmd.Flags().Changed("branch") && !cmd.Flags().Changed("seed") {
+			var err error
+			if resp, err = client.RunLoadTestDefault(ctx, &emptypb.Empty{}); err != nil {
+				return err
+			}
+		} else {
+			spec := []byte(pfsserver.DefaultLoadTestSpec)
+			if len(args) > 0 {
+				var err error
+				if spec, err = os.ReadFile(args[0]); err != nil {
+					return err
+				}
+			}
+			req := &pfs.RunLoadTestRequest{Spec: string(spec), Seed: loadTestCmdOpts.Seed}
+			if loadTestCmdOpts.Branch != "" {
+				commit, err := pfs.ParseCommit(loadTestCmdOpts.Branch)
+				if err != nil {
+					return err
+				}
+				req.Branch = commit.Branch
+			}
+			var err error
+			if resp, err = client.RunLoadTest(ctx, req); err != nil {
+				return err
+			}
+		}
+
+		fmt.Printf("branch %s, seed %d: %s, %s/s\n", resp.Branch, resp.Seed,
+			resp.Duration.AsDuration().Round(time.Millisecond), units.HumanSize(resp.BytesPerSecond))
+		w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+		fmt.Fprintln(w, "OPERATION\tCOUNT\tBYTES\tP50\tP90\tP99\tMAX")
+		for _, s := range resp.Stats {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\n", s.Operation, s.Count, units.HumanSize(float64(s.Bytes)),
+				s.P50.AsDuration(), s.P90.AsDuration(), s.P99.AsDuration(), s.Max.AsDuration())
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		if resp.Error != "" {
+			return fmt.Errorf("load test failed: %s", resp.Error)
+		}
+		return nil
+	},
+}
+
+func init() {
+	loadTestCmd.Flags().StringVar(&loadTestCmdOpts.Branch, "branch", "", "<repo>@<branch> to commit to, which is created if necessary (defaults to load_test@master)")
+	loadTestCmd.Flags().Int64Var(&loadTestCmdOpts.Seed, "seed", 0, "seed the file content and operations are generated from")
+	rootCmd.AddCommand(loadTestCmd)
+}
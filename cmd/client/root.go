@@ -36,6 +36,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -135,7 +136,7 @@ func dial() (res closableGrpcClientConnInterface) {
 	var err error
 	switch rootCmdOpts.DialMode {
 	case dialModeHost:
-		res, err = grpc.Dial(rootCmdOpts.Host, grpc.WithInsecure())
+		res, err = grpc.Dial(rootCmdOpts.Host, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	case dialModeKubernetes:
 		res, err = dialKubernetes()
 	default:
@@ -178,7 +179,7 @@ func dialKubernetes() (closableGrpcClientConnInterface, error) {
 	case <-readychan:
 	}
 
-	res, err := grpc.Dial(fmt.Sprintf("localhost:%d", localPort), grpc.WithInsecure())
+	res, err := grpc.Dial(fmt.Sprintf("localhost:%d", localPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("cannot dial forwarded connection: %w", err)
@@ -0,0 +1,290 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/bhojpur/data/pkg/api/v1/admin"
+	"github.com/bhojpur/data/pkg/api/v1/auth"
+	"github.com/bhojpur/data/pkg/api/v1/debug"
+	"github.com/bhojpur/data/pkg/api/v1/enterprise"
+	"github.com/bhojpur/data/pkg/api/v1/identity"
+	"github.com/bhojpur/data/pkg/api/v1/license"
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	"github.com/bhojpur/data/pkg/api/v1/proxy"
+	"github.com/bhojpur/data/pkg/api/v1/transaction"
+	versionpb "github.com/bhojpur/data/pkg/api/v1/version"
+	pfsserver "github.com/bhojpur/data/pkg/pfs/server"
+	ppsserver "github.com/bhojpur/data/pkg/pps/server"
+	"github.com/bhojpur/data/pkg/version"
+)
+
+var serveCmdOpts struct {
+	Port            int
+	HTTPPort        int
+	S3Port          int
+	ShutdownTimeout time.Duration
+	StorageRoot     string
+	PostgresURL     string
+	CacheSizeBytes  int64
+	FileURLRoot     string
+	Enabled         map[string]*bool
+}
+
+// ppsAPIServer is shared with PFS, which resolves secrets through it.
+var ppsAPIServer = ppsserver.NewAPIServer()
+
+// service is a v1 gRPC service the server knows how to host.
+type service struct {
+	name string
+	// register registers the service with s. Background work the service
+	// starts stops when ctx is done.
+	register func(ctx context.Context, s *grpc.Server) error
+}
+
+// services lists every v1 gRPC service in the order it is registered. Services
+// without a real implementation yet are registered with their generated
+// Unimplemented server, so that clients get a clean codes.Unimplemented
+// instead of an unknown service error.
+var services = []service{
+	{"pfs", func(ctx context.Context, s *grpc.Server) error {
+		env := pfsserver.Env{
+			StorageRoot:    serveCmdOpts.StorageRoot,
+			PostgresURL:    serveCmdOpts.PostgresURL,
+			CacheSizeBytes: serveCmdOpts.CacheSizeBytes,
+			FileURLRoot:    serveCmdOpts.FileURLRoot,
+		}
+		// egress targets and SQL ingests read their credentials from PPS
+		// secrets
+		if *serveCmdOpts.Enabled["pps"] {
+			env.Secrets = ppsAPIServer.GetSecret
+		}
+		apiServer, err := pfsserver.NewAPIServer(ctx, env)
+		if err != nil {
+			return err
+		}
+		pfs.RegisterAPIServer(s, apiServer)
+		return nil
+	}},
+	{"pps", func(ctx context.Context, s *grpc.Server) error {
+		pps.RegisterAPIServer(s, ppsAPIServer)
+		return nil
+	}},
+	{"auth", func(ctx context.Context, s *grpc.Server) error {
+		auth.RegisterAPIServer(s, &auth.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"identity", func(ctx context.Context, s *grpc.Server) error {
+		identity.RegisterAPIServer(s, &identity.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"license", func(ctx context.Context, s *grpc.Server) error {
+		license.RegisterAPIServer(s, &license.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"enterprise", func(ctx context.Context, s *grpc.Server) error {
+		enterprise.RegisterAPIServer(s, &enterprise.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"transaction", func(ctx context.Context, s *grpc.Server) error {
+		transaction.RegisterAPIServer(s, &transaction.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"debug", func(ctx context.Context, s *grpc.Server) error {
+		debug.RegisterDebugServer(s, &debug.UnimplementedDebugServer{})
+		return nil
+	}},
+	{"proxy", func(ctx context.Context, s *grpc.Server) error {
+		proxy.RegisterAPIServer(s, &proxy.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"admin", func(ctx context.Context, s *grpc.Server) error {
+		admin.RegisterAPIServer(s, &admin.UnimplementedAPIServer{})
+		return nil
+	}},
+	{"version", func(ctx context.Context, s *grpc.Server) error {
+		versionpb.RegisterAPIServer(s, version.NewAPIServer(version.Version, version.APIServerOptions{}))
+		return nil
+	}},
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Starts the Bhojpur Data gRPC server and hosts the enabled v1 services",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// serveCtx is done once the server has shut down
+		serveCtx, stopServing := context.WithCancel(context.Background())
+		defer stopServing()
+		server := grpc.NewServer()
+		for _, svc := range services {
+			if !*serveCmdOpts.Enabled[svc.name] {
+				log.WithField("service", svc.name).Debug("service disabled")
+				continue
+			}
+			if err := svc.register(serveCtx, server); err != nil {
+				return fmt.Errorf("cannot register %s service: %w", svc.name, err)
+			}
+			log.WithField("service", svc.name).Info("service registered")
+		}
+
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", serveCmdOpts.Port))
+		if err != nil {
+			return fmt.Errorf("cannot listen on port %d: %w", serveCmdOpts.Port, err)
+		}
+
+		errchan := make(chan error, 3)
+		go func() {
+			log.WithField("port", serveCmdOpts.Port).Info("serving gRPC")
+			errchan <- server.Serve(l)
+		}()
+
+		// the gateways are served over a loopback connection to the gRPC
+		// server
+		var gateways []*http.Server
+		serveGateway := func(name string, port int, handler http.Handler) {
+			gateway := &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: handler}
+			gateways = append(gateways, gateway)
+			go func() {
+				log.WithField("port", port).Infof("serving %s gateway", name)
+				if err := gateway.ListenAndServe(); err != http.ErrServerClosed {
+					errchan <- err
+				}
+			}()
+		}
+		if (serveCmdOpts.HTTPPort != 0 || serveCmdOpts.S3Port != 0) && *serveCmdOpts.Enabled["pfs"] {
+			conn, err := grpc.Dial(fmt.Sprintf("localhost:%d", serveCmdOpts.Port), grpc.WithTransportCredentials(insecure.NewCredentials()))
+			if err != nil {
+				return fmt.Errorf("cannot connect the gateways to PFS: %w", err)
+			}
+			defer conn.Close()
+			if serveCmdOpts.HTTPPort != 0 {
+				serveGateway("HTTP", serveCmdOpts.HTTPPort, pfsserver.NewHTTPHandler(pfs.NewAPIClient(conn)))
+			}
+			if serveCmdOpts.S3Port != 0 {
+				var authC auth.APIClient
+				if *serveCmdOpts.Enabled["auth"] {
+					authC = auth.NewAPIClient(conn)
+				}
+				serveGateway("S3", serveCmdOpts.S3Port, pfsserver.NewS3Handler(pfs.NewAPIClient(conn), authC))
+			}
+		}
+
+		sigchan := make(chan os.Signal, 1)
+		signal.Notify(sigchan, syscall.SIGTERM, os.Interrupt)
+		defer signal.Stop(sigchan)
+
+		select {
+		case err := <-errchan:
+			return err
+		case sig := <-sigchan:
+			log.WithField("signal", sig).Info("shutting down")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), serveCmdOpts.ShutdownTimeout)
+		defer cancel()
+		for _, gateway := range gateways {
+			if err := gateway.Shutdown(ctx); err != nil {
+				log.WithError(err).WithField("addr", gateway.Addr).Warn("gateway shutdown timed out, forcing stop")
+				gateway.Close()
+			}
+		}
+		gracefulStop(server, serveCmdOpts.ShutdownTimeout)
+		return nil
+	},
+}
+
+// gracefulStop lets in-flight RPCs finish, but forcibly stops the server if
+// they take longer than timeout.
+func gracefulStop(server *grpc.Server, timeout time.Duration) {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		log.WithField("timeout", timeout).Warn("graceful shutdown timed out, forcing stop")
+		server.Stop()
+	}
+}
+
+func init() {
+	port := 7777
+	if p := os.Getenv("DATA_PORT"); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil {
+			log.WithError(err).Warnf("ignoring invalid DATA_PORT %q", p)
+			port = 7777
+		}
+	}
+
+	serveCmd.Flags().IntVar(&serveCmdOpts.Port, "port", port, "port to serve gRPC on (defaults to DATA_PORT env var)")
+	httpPort := 0
+	if p := os.Getenv("DATA_HTTP_PORT"); p != "" {
+		var err error
+		if httpPort, err = strconv.Atoi(p); err != nil {
+			log.WithError(err).Warnf("ignoring invalid DATA_HTTP_PORT %q", p)
+			httpPort = 0
+		}
+	}
+	serveCmd.Flags().IntVar(&serveCmdOpts.HTTPPort, "http-port", httpPort, "port to serve the PFS HTTP gateway on, or 0 to disable it (defaults to DATA_HTTP_PORT env var)")
+	s3Port := 0
+	if p := os.Getenv("DATA_S3_PORT"); p != "" {
+		var err error
+		if s3Port, err = strconv.Atoi(p); err != nil {
+			log.WithError(err).Warnf("ignoring invalid DATA_S3_PORT %q", p)
+			s3Port = 0
+		}
+	}
+	serveCmd.Flags().IntVar(&serveCmdOpts.S3Port, "s3-port", s3Port, "port to serve the S3-compatible gateway over PFS branches on, or 0 to disable it (defaults to DATA_S3_PORT env var)")
+	serveCmd.Flags().DurationVar(&serveCmdOpts.ShutdownTimeout, "shutdown-timeout", 30*time.Second, "how long to wait for in-flight requests on SIGTERM before forcing shutdown")
+	storageRoot := os.Getenv("DATA_STORAGE_ROOT")
+	if storageRoot == "" {
+		storageRoot = filepath.Join(os.TempDir(), "bhojpur-data", "storage")
+	}
+	serveCmd.Flags().StringVar(&serveCmdOpts.StorageRoot, "storage-root", storageRoot, "directory to store file content in (defaults to DATA_STORAGE_ROOT env var)")
+	serveCmd.Flags().StringVar(&serveCmdOpts.PostgresURL, "postgres-url", os.Getenv("DATA_POSTGRES_URL"), "Postgres connection URL to store metadata in; metadata is kept in memory if unset (defaults to DATA_POSTGRES_URL env var)")
+	serveCmd.Flags().Int64Var(&serveCmdOpts.CacheSizeBytes, "cache-size-bytes", 64<<20, "size budget of the PFS cache, including the file sets its entries pin, beyond which the least recently used entries are evicted")
+	serveCmd.Flags().StringVar(&serveCmdOpts.FileURLRoot, "file-url-root", os.Getenv("DATA_FILE_URL_ROOT"), "directory that file:// URLs in PFS requests read from and write to; file URLs are refused if unset (defaults to DATA_FILE_URL_ROOT env var)")
+	serveCmdOpts.Enabled = make(map[string]*bool, len(services))
+	for _, svc := range services {
+		serveCmdOpts.Enabled[svc.name] = serveCmd.Flags().Bool("enable-"+svc.name, true, fmt.Sprintf("en/disable the %s service", svc.name))
+	}
+	rootCmd.AddCommand(serveCmd)
+}
@@ -22,9 +22,12 @@ package pfs
 
 import (
 	"encoding/hex"
+	"fmt"
 	"hash"
+	"io"
+	"strings"
 
-	"github.com/gogo/protobuf/proto"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/bhojpur/data/pkg/internal/datahash"
 	"github.com/bhojpur/data/pkg/internal/errors"
@@ -56,7 +59,9 @@ func DecodeHash(hash string) ([]byte, error) {
 	return res, errors.EnsureStack(err)
 }
 
-func (r *Repo) String() string {
+// Key returns the repo's string form, "name" or "name.type", which
+// identifies it.
+func (r *Repo) Key() string {
 	if r.Type == UserRepoType {
 		return r.Name
 	}
@@ -72,7 +77,7 @@ func (r *Repo) NewBranch(name string) *Branch {
 
 func (r *Repo) NewCommit(branch, id string) *Commit {
 	return &Commit{
-		ID:     id,
+		Id:     id,
 		Branch: r.NewBranch(branch),
 	}
 }
@@ -84,17 +89,90 @@ func (c *Commit) NewFile(path string) *File {
 	}
 }
 
-func (c *Commit) String() string {
-	return c.Branch.String() + "=" + c.ID
+// Key returns the commit's string form, "repo@branch=id", which identifies
+// it.
+func (c *Commit) Key() string {
+	return c.Branch.Key() + "=" + c.Id
 }
 
 func (b *Branch) NewCommit(id string) *Commit {
 	return &Commit{
 		Branch: proto.Clone(b).(*Branch),
-		ID:     id,
+		Id:     id,
 	}
 }
 
-func (b *Branch) String() string {
-	return b.Repo.String() + "@" + b.Name
+// Key returns the branch's string form, "repo@branch", which identifies it.
+func (b *Branch) Key() string {
+	return b.Repo.Key() + "@" + b.Name
+}
+
+// Repos, branches and commits are formatted by their keys, rather than their
+// generated String methods, so that they read naturally in errors and logs.
+
+func (r *Repo) Format(f fmt.State, verb rune)   { formatKey(f, verb, r.Key()) }
+func (b *Branch) Format(f fmt.State, verb rune) { formatKey(f, verb, b.Key()) }
+func (c *Commit) Format(f fmt.State, verb rune) { formatKey(f, verb, c.Key()) }
+
+// formatKey writes key to f, quoted for the %q verb.
+func formatKey(f fmt.State, verb rune, key string) {
+	if verb == 'q' {
+		fmt.Fprintf(f, "%q", key)
+		return
+	}
+	io.WriteString(f, key)
+}
+
+// ParseRepo parses a repo from its string form, "name" or "name.type".
+func ParseRepo(s string) (*Repo, error) {
+	name, repoType := s, UserRepoType
+	if i := strings.LastIndexByte(s, '.'); i >= 0 {
+		name, repoType = s[:i], s[i+1:]
+	}
+	if name == "" {
+		return nil, errors.Errorf("invalid repo %q: the name cannot be empty", s)
+	}
+	return &Repo{Name: name, Type: repoType}, nil
+}
+
+// ParseCommit parses a commit from its string form, "repo@branch",
+// "repo@branch=id" or "repo=id". The branch defaults to master if neither a
+// branch nor an ID is given. PFS resolves a branch that does not exist as a
+// commit ID, so "repo@id" refers to a commit as well.
+func ParseCommit(s string) (*Commit, error) {
+	repoPart, commitPart := s, ""
+	if i := strings.IndexAny(s, "@="); i >= 0 {
+		repoPart, commitPart = s[:i], s[i:]
+	}
+	repo, err := ParseRepo(repoPart)
+	if err != nil {
+		return nil, err
+	}
+	branch, id := "master", ""
+	if strings.HasPrefix(commitPart, "@") {
+		branch = commitPart[1:]
+		if i := strings.IndexByte(branch, '='); i >= 0 {
+			branch, id = branch[:i], branch[i+1:]
+		}
+	} else if strings.HasPrefix(commitPart, "=") {
+		branch, id = "", commitPart[1:]
+	}
+	if branch == "" && id == "" {
+		return nil, errors.Errorf("invalid commit %q: a branch or an ID is required", s)
+	}
+	return repo.NewCommit(branch, id), nil
+}
+
+// ParseFile parses a file from its string form, "<commit>:path", where
+// commit is parsed by ParseCommit. The path defaults to the root directory.
+func ParseFile(s string) (*File, error) {
+	commitPart, path := s, "/"
+	if i := strings.IndexByte(s, ':'); i >= 0 {
+		commitPart, path = s[:i], s[i+1:]
+	}
+	commit, err := ParseCommit(commitPart)
+	if err != nil {
+		return nil, err
+	}
+	return commit.NewFile(path), nil
 }
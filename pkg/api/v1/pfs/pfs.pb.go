@@ -314,7 +314,7 @@ func (x SQLDatabaseEgress_FileFormat_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SQLDatabaseEgress_FileFormat_Type.Descriptor instead.
 func (SQLDatabaseEgress_FileFormat_Type) EnumDescriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{60, 0, 0}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{69, 0, 0}
 }
 
 type Repo struct {
@@ -664,6 +664,10 @@ type BranchInfo struct {
 	Subvenance       []*Branch `protobuf:"bytes,4,rep,name=subvenance,proto3" json:"subvenance,omitempty"`
 	DirectProvenance []*Branch `protobuf:"bytes,5,rep,name=direct_provenance,json=directProvenance,proto3" json:"direct_provenance,omitempty"`
 	Trigger          *Trigger  `protobuf:"bytes,6,opt,name=trigger,proto3" json:"trigger,omitempty"`
+	// The sizes of the head commit, as in its CommitInfo. They are set by
+	// InspectBranch and ListBranch, but not stored.
+	SizeBytesUpperBound int64               `protobuf:"varint,7,opt,name=size_bytes_upper_bound,json=sizeBytesUpperBound,proto3" json:"size_bytes_upper_bound,omitempty"`
+	Details             *BranchInfo_Details `protobuf:"bytes,8,opt,name=details,proto3" json:"details,omitempty"`
 }
 
 func (x *BranchInfo) Reset() {
@@ -740,6 +744,20 @@ func (x *BranchInfo) GetTrigger() *Trigger {
 	return nil
 }
 
+func (x *BranchInfo) GetSizeBytesUpperBound() int64 {
+	if x != nil {
+		return x.SizeBytesUpperBound
+	}
+	return 0
+}
+
+func (x *BranchInfo) GetDetails() *BranchInfo_Details {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
 // Trigger defines the conditions under which a head is moved, and to which
 // branch it is moved.
 type Trigger struct {
@@ -2644,20 +2662,89 @@ func (*ModifyFileRequest_DeleteFile) isModifyFileRequest_Body() {}
 
 func (*ModifyFileRequest_CopyFile) isModifyFileRequest_Body() {}
 
+// ByteRange is a range of bytes in a file.
+type ByteRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"`
+	// SizeBytes is the length of the range. If it is 0, the range extends to
+	// the end of the file.
+	SizeBytes int64 `protobuf:"varint,2,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+}
+
+func (x *ByteRange) Reset() {
+	*x = ByteRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ByteRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ByteRange) ProtoMessage() {}
+
+func (x *ByteRange) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ByteRange.ProtoReflect.Descriptor instead.
+func (*ByteRange) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ByteRange) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ByteRange) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
 type GetFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	File   *File  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
-	URL    string `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
-	Offset int64  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	File *File `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// URL, if set, is where the content is written to instead of being
+	// streamed back, e.g. "file:///tmp/out" or "s3://bucket/key". If File is a
+	// directory, every file beneath it is written to the corresponding object
+	// beneath the URL. File URLs name paths beneath the server's file URL
+	// root, and are refused if it has none.
+	URL string `protobuf:"bytes,2,opt,name=URL,proto3" json:"URL,omitempty"`
+	// Offset and SizeBytes select the range of the file to get. A SizeBytes
+	// of 0 gets everything after Offset.
+	Offset    int64 `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	SizeBytes int64 `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	// Ranges, if set, are read in order and concatenated, instead of the
+	// single range given by Offset and SizeBytes.
+	Ranges []*ByteRange `protobuf:"bytes,5,rep,name=ranges,proto3" json:"ranges,omitempty"`
 }
 
 func (x *GetFileRequest) Reset() {
 	*x = GetFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[35]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2670,7 +2757,7 @@ func (x *GetFileRequest) String() string {
 func (*GetFileRequest) ProtoMessage() {}
 
 func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[35]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2683,7 +2770,7 @@ func (x *GetFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFileRequest.ProtoReflect.Descriptor instead.
 func (*GetFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{35}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *GetFileRequest) GetFile() *File {
@@ -2707,6 +2794,20 @@ func (x *GetFileRequest) GetOffset() int64 {
 	return 0
 }
 
+func (x *GetFileRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *GetFileRequest) GetRanges() []*ByteRange {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
 type InspectFileRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -2718,7 +2819,7 @@ type InspectFileRequest struct {
 func (x *InspectFileRequest) Reset() {
 	*x = InspectFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[36]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2731,7 +2832,7 @@ func (x *InspectFileRequest) String() string {
 func (*InspectFileRequest) ProtoMessage() {}
 
 func (x *InspectFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[36]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2744,7 +2845,7 @@ func (x *InspectFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InspectFileRequest.ProtoReflect.Descriptor instead.
 func (*InspectFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{36}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *InspectFileRequest) GetFile() *File {
@@ -2769,7 +2870,7 @@ type ListFileRequest struct {
 func (x *ListFileRequest) Reset() {
 	*x = ListFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[37]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2782,7 +2883,7 @@ func (x *ListFileRequest) String() string {
 func (*ListFileRequest) ProtoMessage() {}
 
 func (x *ListFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[37]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2795,7 +2896,7 @@ func (x *ListFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFileRequest.ProtoReflect.Descriptor instead.
 func (*ListFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{37}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *ListFileRequest) GetFile() *File {
@@ -2816,7 +2917,7 @@ type WalkFileRequest struct {
 func (x *WalkFileRequest) Reset() {
 	*x = WalkFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[38]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2829,7 +2930,7 @@ func (x *WalkFileRequest) String() string {
 func (*WalkFileRequest) ProtoMessage() {}
 
 func (x *WalkFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[38]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2842,7 +2943,7 @@ func (x *WalkFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WalkFileRequest.ProtoReflect.Descriptor instead.
 func (*WalkFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{38}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *WalkFileRequest) GetFile() *File {
@@ -2864,7 +2965,7 @@ type GlobFileRequest struct {
 func (x *GlobFileRequest) Reset() {
 	*x = GlobFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[39]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2877,7 +2978,7 @@ func (x *GlobFileRequest) String() string {
 func (*GlobFileRequest) ProtoMessage() {}
 
 func (x *GlobFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[39]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2890,7 +2991,7 @@ func (x *GlobFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GlobFileRequest.ProtoReflect.Descriptor instead.
 func (*GlobFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{39}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *GlobFileRequest) GetCommit() *Commit {
@@ -2922,7 +3023,7 @@ type DiffFileRequest struct {
 func (x *DiffFileRequest) Reset() {
 	*x = DiffFileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[40]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[41]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2935,7 +3036,7 @@ func (x *DiffFileRequest) String() string {
 func (*DiffFileRequest) ProtoMessage() {}
 
 func (x *DiffFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[40]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[41]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2948,7 +3049,7 @@ func (x *DiffFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiffFileRequest.ProtoReflect.Descriptor instead.
 func (*DiffFileRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{40}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{41}
 }
 
 func (x *DiffFileRequest) GetNewFile() *File {
@@ -2984,7 +3085,7 @@ type DiffFileResponse struct {
 func (x *DiffFileResponse) Reset() {
 	*x = DiffFileResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[41]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[42]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2997,7 +3098,7 @@ func (x *DiffFileResponse) String() string {
 func (*DiffFileResponse) ProtoMessage() {}
 
 func (x *DiffFileResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[41]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[42]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3010,7 +3111,7 @@ func (x *DiffFileResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DiffFileResponse.ProtoReflect.Descriptor instead.
 func (*DiffFileResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{41}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *DiffFileResponse) GetNewFile() *FileInfo {
@@ -3027,31 +3128,40 @@ func (x *DiffFileResponse) GetOldFile() *FileInfo {
 	return nil
 }
 
-type FsckRequest struct {
+// An upload session assembles a single file from fixed-size parts, which may
+// be sent over several connections. Every part except the last is
+// part_size_bytes long, and parts must be sent in order. When all
+// size_bytes have been received, FinishUpload replaces the file in its
+// commit with the assembled content.
+type StartUploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fix bool `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"`
+	// File is the file to write, in an open commit.
+	File  *File  `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Datum string `protobuf:"bytes,2,opt,name=datum,proto3" json:"datum,omitempty"`
+	// SizeBytes is the total size of the file.
+	SizeBytes int64 `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 }
 
-func (x *FsckRequest) Reset() {
-	*x = FsckRequest{}
+func (x *StartUploadRequest) Reset() {
+	*x = StartUploadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[42]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[43]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FsckRequest) String() string {
+func (x *StartUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FsckRequest) ProtoMessage() {}
+func (*StartUploadRequest) ProtoMessage() {}
 
-func (x *FsckRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[42]
+func (x *StartUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[43]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3062,44 +3172,65 @@ func (x *FsckRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FsckRequest.ProtoReflect.Descriptor instead.
-func (*FsckRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use StartUploadRequest.ProtoReflect.Descriptor instead.
+func (*StartUploadRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *FsckRequest) GetFix() bool {
+func (x *StartUploadRequest) GetFile() *File {
 	if x != nil {
-		return x.Fix
+		return x.File
 	}
-	return false
+	return nil
 }
 
-type FsckResponse struct {
+func (x *StartUploadRequest) GetDatum() string {
+	if x != nil {
+		return x.Datum
+	}
+	return ""
+}
+
+func (x *StartUploadRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type UploadInfo struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Fix   string `protobuf:"bytes,1,opt,name=fix,proto3" json:"fix,omitempty"`
-	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	File          *File  `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Datum         string `protobuf:"bytes,3,opt,name=datum,proto3" json:"datum,omitempty"`
+	SizeBytes     int64  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	PartSizeBytes int64  `protobuf:"varint,5,opt,name=part_size_bytes,json=partSizeBytes,proto3" json:"part_size_bytes,omitempty"`
+	// CommittedBytes is how much of the file has been received and
+	// acknowledged. An interrupted upload resumes at this offset.
+	CommittedBytes int64                  `protobuf:"varint,6,opt,name=committed_bytes,json=committedBytes,proto3" json:"committed_bytes,omitempty"`
+	Started        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=started,proto3" json:"started,omitempty"`
 }
 
-func (x *FsckResponse) Reset() {
-	*x = FsckResponse{}
+func (x *UploadInfo) Reset() {
+	*x = UploadInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[43]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[44]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *FsckResponse) String() string {
+func (x *UploadInfo) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FsckResponse) ProtoMessage() {}
+func (*UploadInfo) ProtoMessage() {}
 
-func (x *FsckResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[43]
+func (x *UploadInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[44]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3110,82 +3241,75 @@ func (x *FsckResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FsckResponse.ProtoReflect.Descriptor instead.
-func (*FsckResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use UploadInfo.ProtoReflect.Descriptor instead.
+func (*UploadInfo) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *FsckResponse) GetFix() string {
+func (x *UploadInfo) GetId() string {
 	if x != nil {
-		return x.Fix
+		return x.Id
 	}
 	return ""
 }
 
-func (x *FsckResponse) GetError() string {
+func (x *UploadInfo) GetFile() *File {
 	if x != nil {
-		return x.Error
+		return x.File
 	}
-	return ""
-}
-
-type CreateFileSetResponse struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	FileSetId string `protobuf:"bytes,1,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
+	return nil
 }
 
-func (x *CreateFileSetResponse) Reset() {
-	*x = CreateFileSetResponse{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[44]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *UploadInfo) GetDatum() string {
+	if x != nil {
+		return x.Datum
 	}
+	return ""
 }
 
-func (x *CreateFileSetResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *UploadInfo) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
 }
 
-func (*CreateFileSetResponse) ProtoMessage() {}
-
-func (x *CreateFileSetResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[44]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *UploadInfo) GetPartSizeBytes() int64 {
+	if x != nil {
+		return x.PartSizeBytes
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CreateFileSetResponse.ProtoReflect.Descriptor instead.
-func (*CreateFileSetResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{44}
+func (x *UploadInfo) GetCommittedBytes() int64 {
+	if x != nil {
+		return x.CommittedBytes
+	}
+	return 0
 }
 
-func (x *CreateFileSetResponse) GetFileSetId() string {
+func (x *UploadInfo) GetStarted() *timestamppb.Timestamp {
 	if x != nil {
-		return x.FileSetId
+		return x.Started
 	}
-	return ""
+	return nil
 }
 
-type GetFileSetRequest struct {
+type UploadPartHeader struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	// Offset is where the part starts in the file. It must be a multiple of the
+	// upload's part size.
+	Offset int64 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// Hash is the pfs.NewHash checksum of the part's content.
+	Hash []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
 }
 
-func (x *GetFileSetRequest) Reset() {
-	*x = GetFileSetRequest{}
+func (x *UploadPartHeader) Reset() {
+	*x = UploadPartHeader{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[45]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3193,13 +3317,13 @@ func (x *GetFileSetRequest) Reset() {
 	}
 }
 
-func (x *GetFileSetRequest) String() string {
+func (x *UploadPartHeader) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetFileSetRequest) ProtoMessage() {}
+func (*UploadPartHeader) ProtoMessage() {}
 
-func (x *GetFileSetRequest) ProtoReflect() protoreflect.Message {
+func (x *UploadPartHeader) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[45]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3211,29 +3335,48 @@ func (x *GetFileSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetFileSetRequest.ProtoReflect.Descriptor instead.
-func (*GetFileSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UploadPartHeader.ProtoReflect.Descriptor instead.
+func (*UploadPartHeader) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetFileSetRequest) GetCommit() *Commit {
+func (x *UploadPartHeader) GetUploadId() string {
 	if x != nil {
-		return x.Commit
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadPartHeader) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadPartHeader) GetHash() []byte {
+	if x != nil {
+		return x.Hash
 	}
 	return nil
 }
 
-type AddFileSetRequest struct {
+type PutUploadPartRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Commit    *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
-	FileSetId string  `protobuf:"bytes,2,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
+	// The first message of a PutUploadPart stream is a header, and the
+	// remaining messages are the part's content.
+	//
+	// Types that are assignable to Body:
+	//	*PutUploadPartRequest_Header
+	//	*PutUploadPartRequest_Data
+	Body isPutUploadPartRequest_Body `protobuf_oneof:"body"`
 }
 
-func (x *AddFileSetRequest) Reset() {
-	*x = AddFileSetRequest{}
+func (x *PutUploadPartRequest) Reset() {
+	*x = PutUploadPartRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[46]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3241,13 +3384,13 @@ func (x *AddFileSetRequest) Reset() {
 	}
 }
 
-func (x *AddFileSetRequest) String() string {
+func (x *PutUploadPartRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddFileSetRequest) ProtoMessage() {}
+func (*PutUploadPartRequest) ProtoMessage() {}
 
-func (x *AddFileSetRequest) ProtoReflect() protoreflect.Message {
+func (x *PutUploadPartRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[46]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3259,36 +3402,58 @@ func (x *AddFileSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddFileSetRequest.ProtoReflect.Descriptor instead.
-func (*AddFileSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use PutUploadPartRequest.ProtoReflect.Descriptor instead.
+func (*PutUploadPartRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *AddFileSetRequest) GetCommit() *Commit {
-	if x != nil {
-		return x.Commit
+func (m *PutUploadPartRequest) GetBody() isPutUploadPartRequest_Body {
+	if m != nil {
+		return m.Body
 	}
 	return nil
 }
 
-func (x *AddFileSetRequest) GetFileSetId() string {
-	if x != nil {
-		return x.FileSetId
+func (x *PutUploadPartRequest) GetHeader() *UploadPartHeader {
+	if x, ok := x.GetBody().(*PutUploadPartRequest_Header); ok {
+		return x.Header
 	}
-	return ""
+	return nil
 }
 
-type RenewFileSetRequest struct {
+func (x *PutUploadPartRequest) GetData() *wrapperspb.BytesValue {
+	if x, ok := x.GetBody().(*PutUploadPartRequest_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+type isPutUploadPartRequest_Body interface {
+	isPutUploadPartRequest_Body()
+}
+
+type PutUploadPartRequest_Header struct {
+	Header *UploadPartHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type PutUploadPartRequest_Data struct {
+	Data *wrapperspb.BytesValue `protobuf:"bytes,2,opt,name=data,proto3,oneof"`
+}
+
+func (*PutUploadPartRequest_Header) isPutUploadPartRequest_Body() {}
+
+func (*PutUploadPartRequest_Data) isPutUploadPartRequest_Body() {}
+
+type InspectUploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileSetId  string `protobuf:"bytes,1,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
-	TtlSeconds int64  `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 }
 
-func (x *RenewFileSetRequest) Reset() {
-	*x = RenewFileSetRequest{}
+func (x *InspectUploadRequest) Reset() {
+	*x = InspectUploadRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3296,13 +3461,13 @@ func (x *RenewFileSetRequest) Reset() {
 	}
 }
 
-func (x *RenewFileSetRequest) String() string {
+func (x *InspectUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RenewFileSetRequest) ProtoMessage() {}
+func (*InspectUploadRequest) ProtoMessage() {}
 
-func (x *RenewFileSetRequest) ProtoReflect() protoreflect.Message {
+func (x *InspectUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3314,36 +3479,28 @@ func (x *RenewFileSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RenewFileSetRequest.ProtoReflect.Descriptor instead.
-func (*RenewFileSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use InspectUploadRequest.ProtoReflect.Descriptor instead.
+func (*InspectUploadRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *RenewFileSetRequest) GetFileSetId() string {
+func (x *InspectUploadRequest) GetUploadId() string {
 	if x != nil {
-		return x.FileSetId
+		return x.UploadId
 	}
 	return ""
 }
 
-func (x *RenewFileSetRequest) GetTtlSeconds() int64 {
-	if x != nil {
-		return x.TtlSeconds
-	}
-	return 0
-}
-
-type ComposeFileSetRequest struct {
+type FinishUploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	FileSetIds []string `protobuf:"bytes,1,rep,name=file_set_ids,json=fileSetIds,proto3" json:"file_set_ids,omitempty"`
-	TtlSeconds int64    `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 }
 
-func (x *ComposeFileSetRequest) Reset() {
-	*x = ComposeFileSetRequest{}
+func (x *FinishUploadRequest) Reset() {
+	*x = FinishUploadRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3351,13 +3508,13 @@ func (x *ComposeFileSetRequest) Reset() {
 	}
 }
 
-func (x *ComposeFileSetRequest) String() string {
+func (x *FinishUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ComposeFileSetRequest) ProtoMessage() {}
+func (*FinishUploadRequest) ProtoMessage() {}
 
-func (x *ComposeFileSetRequest) ProtoReflect() protoreflect.Message {
+func (x *FinishUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3369,37 +3526,28 @@ func (x *ComposeFileSetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ComposeFileSetRequest.ProtoReflect.Descriptor instead.
-func (*ComposeFileSetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FinishUploadRequest.ProtoReflect.Descriptor instead.
+func (*FinishUploadRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *ComposeFileSetRequest) GetFileSetIds() []string {
-	if x != nil {
-		return x.FileSetIds
-	}
-	return nil
-}
-
-func (x *ComposeFileSetRequest) GetTtlSeconds() int64 {
+func (x *FinishUploadRequest) GetUploadId() string {
 	if x != nil {
-		return x.TtlSeconds
+		return x.UploadId
 	}
-	return 0
+	return ""
 }
 
-type CheckStorageRequest struct {
+type AbortUploadRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ReadChunkData bool   `protobuf:"varint,1,opt,name=read_chunk_data,json=readChunkData,proto3" json:"read_chunk_data,omitempty"`
-	ChunkBegin    []byte `protobuf:"bytes,2,opt,name=chunk_begin,json=chunkBegin,proto3" json:"chunk_begin,omitempty"`
-	ChunkEnd      []byte `protobuf:"bytes,3,opt,name=chunk_end,json=chunkEnd,proto3" json:"chunk_end,omitempty"`
+	UploadId string `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
 }
 
-func (x *CheckStorageRequest) Reset() {
-	*x = CheckStorageRequest{}
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3407,13 +3555,13 @@ func (x *CheckStorageRequest) Reset() {
 	}
 }
 
-func (x *CheckStorageRequest) String() string {
+func (x *AbortUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckStorageRequest) ProtoMessage() {}
+func (*AbortUploadRequest) ProtoMessage() {}
 
-func (x *CheckStorageRequest) ProtoReflect() protoreflect.Message {
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3425,42 +3573,28 @@ func (x *CheckStorageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckStorageRequest.ProtoReflect.Descriptor instead.
-func (*CheckStorageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *CheckStorageRequest) GetReadChunkData() bool {
-	if x != nil {
-		return x.ReadChunkData
-	}
-	return false
-}
-
-func (x *CheckStorageRequest) GetChunkBegin() []byte {
+func (x *AbortUploadRequest) GetUploadId() string {
 	if x != nil {
-		return x.ChunkBegin
-	}
-	return nil
-}
-
-func (x *CheckStorageRequest) GetChunkEnd() []byte {
-	if x != nil {
-		return x.ChunkEnd
+		return x.UploadId
 	}
-	return nil
+	return ""
 }
 
-type CheckStorageResponse struct {
+type FsckRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ChunkObjectCount int64 `protobuf:"varint,1,opt,name=chunk_object_count,json=chunkObjectCount,proto3" json:"chunk_object_count,omitempty"`
+	Fix bool `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"`
 }
 
-func (x *CheckStorageResponse) Reset() {
-	*x = CheckStorageResponse{}
+func (x *FsckRequest) Reset() {
+	*x = FsckRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3468,13 +3602,13 @@ func (x *CheckStorageResponse) Reset() {
 	}
 }
 
-func (x *CheckStorageResponse) String() string {
+func (x *FsckRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckStorageResponse) ProtoMessage() {}
+func (*FsckRequest) ProtoMessage() {}
 
-func (x *CheckStorageResponse) ProtoReflect() protoreflect.Message {
+func (x *FsckRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3486,31 +3620,29 @@ func (x *CheckStorageResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckStorageResponse.ProtoReflect.Descriptor instead.
-func (*CheckStorageResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use FsckRequest.ProtoReflect.Descriptor instead.
+func (*FsckRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *CheckStorageResponse) GetChunkObjectCount() int64 {
+func (x *FsckRequest) GetFix() bool {
 	if x != nil {
-		return x.ChunkObjectCount
+		return x.Fix
 	}
-	return 0
+	return false
 }
 
-type PutCacheRequest struct {
+type FsckResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key        string     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value      *anypb.Any `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	FileSetIds []string   `protobuf:"bytes,3,rep,name=file_set_ids,json=fileSetIds,proto3" json:"file_set_ids,omitempty"`
-	Tag        string     `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+	Fix   string `protobuf:"bytes,1,opt,name=fix,proto3" json:"fix,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 }
 
-func (x *PutCacheRequest) Reset() {
-	*x = PutCacheRequest{}
+func (x *FsckResponse) Reset() {
+	*x = FsckResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3518,13 +3650,13 @@ func (x *PutCacheRequest) Reset() {
 	}
 }
 
-func (x *PutCacheRequest) String() string {
+func (x *FsckResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PutCacheRequest) ProtoMessage() {}
+func (*FsckResponse) ProtoMessage() {}
 
-func (x *PutCacheRequest) ProtoReflect() protoreflect.Message {
+func (x *FsckResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3536,49 +3668,35 @@ func (x *PutCacheRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PutCacheRequest.ProtoReflect.Descriptor instead.
-func (*PutCacheRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use FsckResponse.ProtoReflect.Descriptor instead.
+func (*FsckResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *PutCacheRequest) GetKey() string {
+func (x *FsckResponse) GetFix() string {
 	if x != nil {
-		return x.Key
+		return x.Fix
 	}
 	return ""
 }
 
-func (x *PutCacheRequest) GetValue() *anypb.Any {
-	if x != nil {
-		return x.Value
-	}
-	return nil
-}
-
-func (x *PutCacheRequest) GetFileSetIds() []string {
-	if x != nil {
-		return x.FileSetIds
-	}
-	return nil
-}
-
-func (x *PutCacheRequest) GetTag() string {
+func (x *FsckResponse) GetError() string {
 	if x != nil {
-		return x.Tag
+		return x.Error
 	}
 	return ""
 }
 
-type GetCacheRequest struct {
+type CreateFileSetResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	FileSetId string `protobuf:"bytes,1,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
 }
 
-func (x *GetCacheRequest) Reset() {
-	*x = GetCacheRequest{}
+func (x *CreateFileSetResponse) Reset() {
+	*x = CreateFileSetResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3586,13 +3704,13 @@ func (x *GetCacheRequest) Reset() {
 	}
 }
 
-func (x *GetCacheRequest) String() string {
+func (x *CreateFileSetResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCacheRequest) ProtoMessage() {}
+func (*CreateFileSetResponse) ProtoMessage() {}
 
-func (x *GetCacheRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateFileSetResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3604,28 +3722,28 @@ func (x *GetCacheRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCacheRequest.ProtoReflect.Descriptor instead.
-func (*GetCacheRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateFileSetResponse.ProtoReflect.Descriptor instead.
+func (*CreateFileSetResponse) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *GetCacheRequest) GetKey() string {
+func (x *CreateFileSetResponse) GetFileSetId() string {
 	if x != nil {
-		return x.Key
+		return x.FileSetId
 	}
 	return ""
 }
 
-type GetCacheResponse struct {
+type GetFileSetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Value *anypb.Any `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 }
 
-func (x *GetCacheResponse) Reset() {
-	*x = GetCacheResponse{}
+func (x *GetFileSetRequest) Reset() {
+	*x = GetFileSetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3633,13 +3751,13 @@ func (x *GetCacheResponse) Reset() {
 	}
 }
 
-func (x *GetCacheResponse) String() string {
+func (x *GetFileSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCacheResponse) ProtoMessage() {}
+func (*GetFileSetRequest) ProtoMessage() {}
 
-func (x *GetCacheResponse) ProtoReflect() protoreflect.Message {
+func (x *GetFileSetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3651,28 +3769,29 @@ func (x *GetCacheResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCacheResponse.ProtoReflect.Descriptor instead.
-func (*GetCacheResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetFileSetRequest.ProtoReflect.Descriptor instead.
+func (*GetFileSetRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetCacheResponse) GetValue() *anypb.Any {
+func (x *GetFileSetRequest) GetCommit() *Commit {
 	if x != nil {
-		return x.Value
+		return x.Commit
 	}
 	return nil
 }
 
-type ClearCacheRequest struct {
+type AddFileSetRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TagPrefix string `protobuf:"bytes,1,opt,name=tag_prefix,json=tagPrefix,proto3" json:"tag_prefix,omitempty"`
+	Commit    *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	FileSetId string  `protobuf:"bytes,2,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
 }
 
-func (x *ClearCacheRequest) Reset() {
-	*x = ClearCacheRequest{}
+func (x *AddFileSetRequest) Reset() {
+	*x = AddFileSetRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3680,13 +3799,13 @@ func (x *ClearCacheRequest) Reset() {
 	}
 }
 
-func (x *ClearCacheRequest) String() string {
+func (x *AddFileSetRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ClearCacheRequest) ProtoMessage() {}
+func (*AddFileSetRequest) ProtoMessage() {}
 
-func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
+func (x *AddFileSetRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -3698,79 +3817,817 @@ func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ClearCacheRequest.ProtoReflect.Descriptor instead.
-func (*ClearCacheRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddFileSetRequest.ProtoReflect.Descriptor instead.
+func (*AddFileSetRequest) Descriptor() ([]byte, []int) {
 	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *ClearCacheRequest) GetTagPrefix() string {
+func (x *AddFileSetRequest) GetCommit() *Commit {
 	if x != nil {
-		return x.TagPrefix
+		return x.Commit
+	}
+	return nil
+}
+
+func (x *AddFileSetRequest) GetFileSetId() string {
+	if x != nil {
+		return x.FileSetId
+	}
+	return ""
+}
+
+type RenewFileSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileSetId  string `protobuf:"bytes,1,opt,name=file_set_id,json=fileSetId,proto3" json:"file_set_id,omitempty"`
+	TtlSeconds int64  `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+}
+
+func (x *RenewFileSetRequest) Reset() {
+	*x = RenewFileSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenewFileSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenewFileSetRequest) ProtoMessage() {}
+
+func (x *RenewFileSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenewFileSetRequest.ProtoReflect.Descriptor instead.
+func (*RenewFileSetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RenewFileSetRequest) GetFileSetId() string {
+	if x != nil {
+		return x.FileSetId
+	}
+	return ""
+}
+
+func (x *RenewFileSetRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type ComposeFileSetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FileSetIds []string `protobuf:"bytes,1,rep,name=file_set_ids,json=fileSetIds,proto3" json:"file_set_ids,omitempty"`
+	TtlSeconds int64    `protobuf:"varint,2,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"`
+}
+
+func (x *ComposeFileSetRequest) Reset() {
+	*x = ComposeFileSetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComposeFileSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComposeFileSetRequest) ProtoMessage() {}
+
+func (x *ComposeFileSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComposeFileSetRequest.ProtoReflect.Descriptor instead.
+func (*ComposeFileSetRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ComposeFileSetRequest) GetFileSetIds() []string {
+	if x != nil {
+		return x.FileSetIds
+	}
+	return nil
+}
+
+func (x *ComposeFileSetRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type CheckStorageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReadChunkData bool   `protobuf:"varint,1,opt,name=read_chunk_data,json=readChunkData,proto3" json:"read_chunk_data,omitempty"`
+	ChunkBegin    []byte `protobuf:"bytes,2,opt,name=chunk_begin,json=chunkBegin,proto3" json:"chunk_begin,omitempty"`
+	ChunkEnd      []byte `protobuf:"bytes,3,opt,name=chunk_end,json=chunkEnd,proto3" json:"chunk_end,omitempty"`
+}
+
+func (x *CheckStorageRequest) Reset() {
+	*x = CheckStorageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStorageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStorageRequest) ProtoMessage() {}
+
+func (x *CheckStorageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStorageRequest.ProtoReflect.Descriptor instead.
+func (*CheckStorageRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *CheckStorageRequest) GetReadChunkData() bool {
+	if x != nil {
+		return x.ReadChunkData
+	}
+	return false
+}
+
+func (x *CheckStorageRequest) GetChunkBegin() []byte {
+	if x != nil {
+		return x.ChunkBegin
+	}
+	return nil
+}
+
+func (x *CheckStorageRequest) GetChunkEnd() []byte {
+	if x != nil {
+		return x.ChunkEnd
+	}
+	return nil
+}
+
+type CheckStorageResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ChunkObjectCount int64 `protobuf:"varint,1,opt,name=chunk_object_count,json=chunkObjectCount,proto3" json:"chunk_object_count,omitempty"`
+}
+
+func (x *CheckStorageResponse) Reset() {
+	*x = CheckStorageResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStorageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStorageResponse) ProtoMessage() {}
+
+func (x *CheckStorageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStorageResponse.ProtoReflect.Descriptor instead.
+func (*CheckStorageResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *CheckStorageResponse) GetChunkObjectCount() int64 {
+	if x != nil {
+		return x.ChunkObjectCount
+	}
+	return 0
+}
+
+type PutCacheRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key        string     `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value      *anypb.Any `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	FileSetIds []string   `protobuf:"bytes,3,rep,name=file_set_ids,json=fileSetIds,proto3" json:"file_set_ids,omitempty"`
+	Tag        string     `protobuf:"bytes,4,opt,name=tag,proto3" json:"tag,omitempty"`
+}
+
+func (x *PutCacheRequest) Reset() {
+	*x = PutCacheRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PutCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PutCacheRequest) ProtoMessage() {}
+
+func (x *PutCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PutCacheRequest.ProtoReflect.Descriptor instead.
+func (*PutCacheRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *PutCacheRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *PutCacheRequest) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *PutCacheRequest) GetFileSetIds() []string {
+	if x != nil {
+		return x.FileSetIds
+	}
+	return nil
+}
+
+func (x *PutCacheRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+type GetCacheRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *GetCacheRequest) Reset() {
+	*x = GetCacheRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheRequest) ProtoMessage() {}
+
+func (x *GetCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheRequest.ProtoReflect.Descriptor instead.
+func (*GetCacheRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *GetCacheRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetCacheResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *anypb.Any `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *GetCacheResponse) Reset() {
+	*x = GetCacheResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCacheResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCacheResponse) ProtoMessage() {}
+
+func (x *GetCacheResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCacheResponse.ProtoReflect.Descriptor instead.
+func (*GetCacheResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GetCacheResponse) GetValue() *anypb.Any {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type ClearCacheRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TagPrefix string `protobuf:"bytes,1,opt,name=tag_prefix,json=tagPrefix,proto3" json:"tag_prefix,omitempty"`
+}
+
+func (x *ClearCacheRequest) Reset() {
+	*x = ClearCacheRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClearCacheRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearCacheRequest) ProtoMessage() {}
+
+func (x *ClearCacheRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearCacheRequest.ProtoReflect.Descriptor instead.
+func (*ClearCacheRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ClearCacheRequest) GetTagPrefix() string {
+	if x != nil {
+		return x.TagPrefix
+	}
+	return ""
+}
+
+type ActivateAuthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ActivateAuthRequest) Reset() {
+	*x = ActivateAuthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateAuthRequest) ProtoMessage() {}
+
+func (x *ActivateAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateAuthRequest.ProtoReflect.Descriptor instead.
+func (*ActivateAuthRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{63}
+}
+
+type ActivateAuthResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ActivateAuthResponse) Reset() {
+	*x = ActivateAuthResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ActivateAuthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivateAuthResponse) ProtoMessage() {}
+
+func (x *ActivateAuthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivateAuthResponse.ProtoReflect.Descriptor instead.
+func (*ActivateAuthResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{64}
+}
+
+type RunLoadTestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec   string  `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Branch *Branch `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	Seed   int64   `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+}
+
+func (x *RunLoadTestRequest) Reset() {
+	*x = RunLoadTestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunLoadTestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunLoadTestRequest) ProtoMessage() {}
+
+func (x *RunLoadTestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunLoadTestRequest.ProtoReflect.Descriptor instead.
+func (*RunLoadTestRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RunLoadTestRequest) GetSpec() string {
+	if x != nil {
+		return x.Spec
+	}
+	return ""
+}
+
+func (x *RunLoadTestRequest) GetBranch() *Branch {
+	if x != nil {
+		return x.Branch
+	}
+	return nil
+}
+
+func (x *RunLoadTestRequest) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+type RunLoadTestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Spec     string               `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
+	Branch   *Branch              `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
+	Seed     int64                `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	Error    string               `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Duration *durationpb.Duration `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	// stats has the latencies of each kind of operation the load test made.
+	Stats []*LoadTestStats `protobuf:"bytes,6,rep,name=stats,proto3" json:"stats,omitempty"`
+	// bytes_per_second is the rate file content was written at over the
+	// whole load test.
+	BytesPerSecond float64 `protobuf:"fixed64,7,opt,name=bytes_per_second,json=bytesPerSecond,proto3" json:"bytes_per_second,omitempty"`
+}
+
+func (x *RunLoadTestResponse) Reset() {
+	*x = RunLoadTestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunLoadTestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunLoadTestResponse) ProtoMessage() {}
+
+func (x *RunLoadTestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunLoadTestResponse.ProtoReflect.Descriptor instead.
+func (*RunLoadTestResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RunLoadTestResponse) GetSpec() string {
+	if x != nil {
+		return x.Spec
+	}
+	return ""
+}
+
+func (x *RunLoadTestResponse) GetBranch() *Branch {
+	if x != nil {
+		return x.Branch
+	}
+	return nil
+}
+
+func (x *RunLoadTestResponse) GetSeed() int64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *RunLoadTestResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *RunLoadTestResponse) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *RunLoadTestResponse) GetStats() []*LoadTestStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+func (x *RunLoadTestResponse) GetBytesPerSecond() float64 {
+	if x != nil {
+		return x.BytesPerSecond
+	}
+	return 0
+}
+
+// LoadTestStats are the latencies of one kind of operation in a load test.
+type LoadTestStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// operation is the PFS API call that was made, such as PutFile.
+	Operation string `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
+	Count     int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// bytes is the amount of file content the operations wrote or read.
+	Bytes int64                `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	P50   *durationpb.Duration `protobuf:"bytes,4,opt,name=p50,proto3" json:"p50,omitempty"`
+	P90   *durationpb.Duration `protobuf:"bytes,5,opt,name=p90,proto3" json:"p90,omitempty"`
+	P99   *durationpb.Duration `protobuf:"bytes,6,opt,name=p99,proto3" json:"p99,omitempty"`
+	Max   *durationpb.Duration `protobuf:"bytes,7,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *LoadTestStats) Reset() {
+	*x = LoadTestStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LoadTestStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadTestStats) ProtoMessage() {}
+
+func (x *LoadTestStats) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadTestStats.ProtoReflect.Descriptor instead.
+func (*LoadTestStats) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *LoadTestStats) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *LoadTestStats) GetCount() int64 {
+	if x != nil {
+		return x.Count
 	}
-	return ""
+	return 0
 }
 
-type ActivateAuthRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *LoadTestStats) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
 }
 
-func (x *ActivateAuthRequest) Reset() {
-	*x = ActivateAuthRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[55]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *LoadTestStats) GetP50() *durationpb.Duration {
+	if x != nil {
+		return x.P50
 	}
+	return nil
 }
 
-func (x *ActivateAuthRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LoadTestStats) GetP90() *durationpb.Duration {
+	if x != nil {
+		return x.P90
+	}
+	return nil
 }
 
-func (*ActivateAuthRequest) ProtoMessage() {}
-
-func (x *ActivateAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[55]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *LoadTestStats) GetP99() *durationpb.Duration {
+	if x != nil {
+		return x.P99
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ActivateAuthRequest.ProtoReflect.Descriptor instead.
-func (*ActivateAuthRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{55}
+func (x *LoadTestStats) GetMax() *durationpb.Duration {
+	if x != nil {
+		return x.Max
+	}
+	return nil
 }
 
-type ActivateAuthResponse struct {
+type ObjectStorageEgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 }
 
-func (x *ActivateAuthResponse) Reset() {
-	*x = ActivateAuthResponse{}
+func (x *ObjectStorageEgress) Reset() {
+	*x = ObjectStorageEgress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[56]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[68]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ActivateAuthResponse) String() string {
+func (x *ObjectStorageEgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ActivateAuthResponse) ProtoMessage() {}
+func (*ObjectStorageEgress) ProtoMessage() {}
 
-func (x *ActivateAuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[56]
+func (x *ObjectStorageEgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[68]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3781,38 +4638,45 @@ func (x *ActivateAuthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ActivateAuthResponse.ProtoReflect.Descriptor instead.
-func (*ActivateAuthResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{56}
+// Deprecated: Use ObjectStorageEgress.ProtoReflect.Descriptor instead.
+func (*ObjectStorageEgress) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{68}
 }
 
-type RunLoadTestRequest struct {
+func (x *ObjectStorageEgress) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type SQLDatabaseEgress struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Spec   string  `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
-	Branch *Branch `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
-	Seed   int64   `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	Url        string                        `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	FileFormat *SQLDatabaseEgress_FileFormat `protobuf:"bytes,2,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
+	Secret     *SQLDatabaseEgress_Secret     `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
 }
 
-func (x *RunLoadTestRequest) Reset() {
-	*x = RunLoadTestRequest{}
+func (x *SQLDatabaseEgress) Reset() {
+	*x = SQLDatabaseEgress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[57]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[69]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RunLoadTestRequest) String() string {
+func (x *SQLDatabaseEgress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunLoadTestRequest) ProtoMessage() {}
+func (*SQLDatabaseEgress) ProtoMessage() {}
 
-func (x *RunLoadTestRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[57]
+func (x *SQLDatabaseEgress) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[69]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3823,61 +4687,66 @@ func (x *RunLoadTestRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunLoadTestRequest.ProtoReflect.Descriptor instead.
-func (*RunLoadTestRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{57}
+// Deprecated: Use SQLDatabaseEgress.ProtoReflect.Descriptor instead.
+func (*SQLDatabaseEgress) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{69}
 }
 
-func (x *RunLoadTestRequest) GetSpec() string {
+func (x *SQLDatabaseEgress) GetUrl() string {
 	if x != nil {
-		return x.Spec
+		return x.Url
 	}
 	return ""
 }
 
-func (x *RunLoadTestRequest) GetBranch() *Branch {
+func (x *SQLDatabaseEgress) GetFileFormat() *SQLDatabaseEgress_FileFormat {
 	if x != nil {
-		return x.Branch
+		return x.FileFormat
 	}
 	return nil
 }
 
-func (x *RunLoadTestRequest) GetSeed() int64 {
+func (x *SQLDatabaseEgress) GetSecret() *SQLDatabaseEgress_Secret {
 	if x != nil {
-		return x.Seed
+		return x.Secret
 	}
-	return 0
+	return nil
 }
 
-type RunLoadTestResponse struct {
+// SQLIngest snapshots the result of a query against a Postgres database into
+// the files beneath path, which are replaced. The files are CSV with a header,
+// or JSON lines, depending on delimiter, and are split as with PutFileSplit.
+type SQLIngest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Spec     string               `protobuf:"bytes,1,opt,name=spec,proto3" json:"spec,omitempty"`
-	Branch   *Branch              `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
-	Seed     int64                `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
-	Error    string               `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
-	Duration *durationpb.Duration `protobuf:"bytes,5,opt,name=duration,proto3" json:"duration,omitempty"`
+	Url              string                    `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Secret           *SQLDatabaseEgress_Secret `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Query            string                    `protobuf:"bytes,3,opt,name=query,proto3" json:"query,omitempty"`
+	Delimiter        Delimiter                 `protobuf:"varint,4,opt,name=delimiter,proto3,enum=v1.pfs.Delimiter" json:"delimiter,omitempty"`
+	Path             string                    `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	TargetFileDatums int64                     `protobuf:"varint,6,opt,name=target_file_datums,json=targetFileDatums,proto3" json:"target_file_datums,omitempty"`
+	TargetFileBytes  int64                     `protobuf:"varint,7,opt,name=target_file_bytes,json=targetFileBytes,proto3" json:"target_file_bytes,omitempty"`
 }
 
-func (x *RunLoadTestResponse) Reset() {
-	*x = RunLoadTestResponse{}
+func (x *SQLIngest) Reset() {
+	*x = SQLIngest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[58]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[70]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RunLoadTestResponse) String() string {
+func (x *SQLIngest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RunLoadTestResponse) ProtoMessage() {}
+func (*SQLIngest) ProtoMessage() {}
 
-func (x *RunLoadTestResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[58]
+func (x *SQLIngest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[70]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3888,71 +4757,86 @@ func (x *RunLoadTestResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RunLoadTestResponse.ProtoReflect.Descriptor instead.
-func (*RunLoadTestResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{58}
+// Deprecated: Use SQLIngest.ProtoReflect.Descriptor instead.
+func (*SQLIngest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *RunLoadTestResponse) GetSpec() string {
+func (x *SQLIngest) GetUrl() string {
 	if x != nil {
-		return x.Spec
+		return x.Url
 	}
 	return ""
 }
 
-func (x *RunLoadTestResponse) GetBranch() *Branch {
+func (x *SQLIngest) GetSecret() *SQLDatabaseEgress_Secret {
 	if x != nil {
-		return x.Branch
+		return x.Secret
 	}
 	return nil
 }
 
-func (x *RunLoadTestResponse) GetSeed() int64 {
+func (x *SQLIngest) GetQuery() string {
 	if x != nil {
-		return x.Seed
+		return x.Query
 	}
-	return 0
+	return ""
 }
 
-func (x *RunLoadTestResponse) GetError() string {
+func (x *SQLIngest) GetDelimiter() Delimiter {
 	if x != nil {
-		return x.Error
+		return x.Delimiter
+	}
+	return Delimiter_NONE
+}
+
+func (x *SQLIngest) GetPath() string {
+	if x != nil {
+		return x.Path
 	}
 	return ""
 }
 
-func (x *RunLoadTestResponse) GetDuration() *durationpb.Duration {
+func (x *SQLIngest) GetTargetFileDatums() int64 {
 	if x != nil {
-		return x.Duration
+		return x.TargetFileDatums
 	}
-	return nil
+	return 0
 }
 
-type ObjectStorageEgress struct {
+func (x *SQLIngest) GetTargetFileBytes() int64 {
+	if x != nil {
+		return x.TargetFileBytes
+	}
+	return 0
+}
+
+type IngestSQLRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Branch *Branch    `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+	Sql    *SQLIngest `protobuf:"bytes,2,opt,name=sql,proto3" json:"sql,omitempty"`
 }
 
-func (x *ObjectStorageEgress) Reset() {
-	*x = ObjectStorageEgress{}
+func (x *IngestSQLRequest) Reset() {
+	*x = IngestSQLRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[59]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[71]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ObjectStorageEgress) String() string {
+func (x *IngestSQLRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ObjectStorageEgress) ProtoMessage() {}
+func (*IngestSQLRequest) ProtoMessage() {}
 
-func (x *ObjectStorageEgress) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[59]
+func (x *IngestSQLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[71]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3963,45 +4847,51 @@ func (x *ObjectStorageEgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ObjectStorageEgress.ProtoReflect.Descriptor instead.
-func (*ObjectStorageEgress) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{59}
+// Deprecated: Use IngestSQLRequest.ProtoReflect.Descriptor instead.
+func (*IngestSQLRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *ObjectStorageEgress) GetUrl() string {
+func (x *IngestSQLRequest) GetBranch() *Branch {
 	if x != nil {
-		return x.Url
+		return x.Branch
 	}
-	return ""
+	return nil
 }
 
-type SQLDatabaseEgress struct {
+func (x *IngestSQLRequest) GetSql() *SQLIngest {
+	if x != nil {
+		return x.Sql
+	}
+	return nil
+}
+
+type IngestSQLResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Url        string                        `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	FileFormat *SQLDatabaseEgress_FileFormat `protobuf:"bytes,2,opt,name=file_format,json=fileFormat,proto3" json:"file_format,omitempty"`
-	Secret     *SQLDatabaseEgress_Secret     `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	Commit   *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
+	RowsRead int64   `protobuf:"varint,2,opt,name=rows_read,json=rowsRead,proto3" json:"rows_read,omitempty"`
 }
 
-func (x *SQLDatabaseEgress) Reset() {
-	*x = SQLDatabaseEgress{}
+func (x *IngestSQLResponse) Reset() {
+	*x = IngestSQLResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[60]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[72]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SQLDatabaseEgress) String() string {
+func (x *IngestSQLResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SQLDatabaseEgress) ProtoMessage() {}
+func (*IngestSQLResponse) ProtoMessage() {}
 
-func (x *SQLDatabaseEgress) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[60]
+func (x *IngestSQLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[72]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4012,30 +4902,23 @@ func (x *SQLDatabaseEgress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SQLDatabaseEgress.ProtoReflect.Descriptor instead.
-func (*SQLDatabaseEgress) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{60}
-}
-
-func (x *SQLDatabaseEgress) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
-	return ""
+// Deprecated: Use IngestSQLResponse.ProtoReflect.Descriptor instead.
+func (*IngestSQLResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *SQLDatabaseEgress) GetFileFormat() *SQLDatabaseEgress_FileFormat {
+func (x *IngestSQLResponse) GetCommit() *Commit {
 	if x != nil {
-		return x.FileFormat
+		return x.Commit
 	}
 	return nil
 }
 
-func (x *SQLDatabaseEgress) GetSecret() *SQLDatabaseEgress_Secret {
+func (x *IngestSQLResponse) GetRowsRead() int64 {
 	if x != nil {
-		return x.Secret
+		return x.RowsRead
 	}
-	return nil
+	return 0
 }
 
 type EgressRequest struct {
@@ -4053,7 +4936,7 @@ type EgressRequest struct {
 func (x *EgressRequest) Reset() {
 	*x = EgressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[61]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[73]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4066,7 +4949,7 @@ func (x *EgressRequest) String() string {
 func (*EgressRequest) ProtoMessage() {}
 
 func (x *EgressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[61]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[73]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4079,7 +4962,7 @@ func (x *EgressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EgressRequest.ProtoReflect.Descriptor instead.
 func (*EgressRequest) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{61}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{73}
 }
 
 func (x *EgressRequest) GetCommit() *Commit {
@@ -4140,7 +5023,7 @@ type EgressResponse struct {
 func (x *EgressResponse) Reset() {
 	*x = EgressResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[62]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[74]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4153,7 +5036,7 @@ func (x *EgressResponse) String() string {
 func (*EgressResponse) ProtoMessage() {}
 
 func (x *EgressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[62]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[74]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4166,7 +5049,7 @@ func (x *EgressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EgressResponse.ProtoReflect.Descriptor instead.
 func (*EgressResponse) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{62}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{74}
 }
 
 func (m *EgressResponse) GetResult() isEgressResponse_Result {
@@ -4206,8 +5089,55 @@ func (*EgressResponse_ObjectStorage) isEgressResponse_Result() {}
 
 func (*EgressResponse_SqlDatabase) isEgressResponse_Result() {}
 
-// Details are only provided when explicitly requested
-type RepoInfo_Details struct {
+// Details are only provided when explicitly requested
+type RepoInfo_Details struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SizeBytes int64 `protobuf:"varint,1,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+}
+
+func (x *RepoInfo_Details) Reset() {
+	*x = RepoInfo_Details{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RepoInfo_Details) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RepoInfo_Details) ProtoMessage() {}
+
+func (x *RepoInfo_Details) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RepoInfo_Details.ProtoReflect.Descriptor instead.
+func (*RepoInfo_Details) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{3, 0}
+}
+
+func (x *RepoInfo_Details) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type BranchInfo_Details struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
@@ -4215,23 +5145,23 @@ type RepoInfo_Details struct {
 	SizeBytes int64 `protobuf:"varint,1,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 }
 
-func (x *RepoInfo_Details) Reset() {
-	*x = RepoInfo_Details{}
+func (x *BranchInfo_Details) Reset() {
+	*x = BranchInfo_Details{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[63]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[76]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RepoInfo_Details) String() string {
+func (x *BranchInfo_Details) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RepoInfo_Details) ProtoMessage() {}
+func (*BranchInfo_Details) ProtoMessage() {}
 
-func (x *RepoInfo_Details) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[63]
+func (x *BranchInfo_Details) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[76]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4242,12 +5172,12 @@ func (x *RepoInfo_Details) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RepoInfo_Details.ProtoReflect.Descriptor instead.
-func (*RepoInfo_Details) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{3, 0}
+// Deprecated: Use BranchInfo_Details.ProtoReflect.Descriptor instead.
+func (*BranchInfo_Details) Descriptor() ([]byte, []int) {
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{5, 0}
 }
 
-func (x *RepoInfo_Details) GetSizeBytes() int64 {
+func (x *BranchInfo_Details) GetSizeBytes() int64 {
 	if x != nil {
 		return x.SizeBytes
 	}
@@ -4268,7 +5198,7 @@ type CommitInfo_Details struct {
 func (x *CommitInfo_Details) Reset() {
 	*x = CommitInfo_Details{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[64]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[77]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4281,7 +5211,7 @@ func (x *CommitInfo_Details) String() string {
 func (*CommitInfo_Details) ProtoMessage() {}
 
 func (x *CommitInfo_Details) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[64]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[77]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4330,7 +5260,7 @@ type AddFile_URLSource struct {
 func (x *AddFile_URLSource) Reset() {
 	*x = AddFile_URLSource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[65]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[78]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4343,7 +5273,7 @@ func (x *AddFile_URLSource) String() string {
 func (*AddFile_URLSource) ProtoMessage() {}
 
 func (x *AddFile_URLSource) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[65]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[78]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4385,7 +5315,7 @@ type SQLDatabaseEgress_FileFormat struct {
 func (x *SQLDatabaseEgress_FileFormat) Reset() {
 	*x = SQLDatabaseEgress_FileFormat{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[66]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[79]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4398,7 +5328,7 @@ func (x *SQLDatabaseEgress_FileFormat) String() string {
 func (*SQLDatabaseEgress_FileFormat) ProtoMessage() {}
 
 func (x *SQLDatabaseEgress_FileFormat) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[66]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[79]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4411,7 +5341,7 @@ func (x *SQLDatabaseEgress_FileFormat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLDatabaseEgress_FileFormat.ProtoReflect.Descriptor instead.
 func (*SQLDatabaseEgress_FileFormat) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{60, 0}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{69, 0}
 }
 
 func (x *SQLDatabaseEgress_FileFormat) GetType() SQLDatabaseEgress_FileFormat_Type {
@@ -4440,7 +5370,7 @@ type SQLDatabaseEgress_Secret struct {
 func (x *SQLDatabaseEgress_Secret) Reset() {
 	*x = SQLDatabaseEgress_Secret{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[67]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[80]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4453,7 +5383,7 @@ func (x *SQLDatabaseEgress_Secret) String() string {
 func (*SQLDatabaseEgress_Secret) ProtoMessage() {}
 
 func (x *SQLDatabaseEgress_Secret) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[67]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[80]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4466,7 +5396,7 @@ func (x *SQLDatabaseEgress_Secret) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SQLDatabaseEgress_Secret.ProtoReflect.Descriptor instead.
 func (*SQLDatabaseEgress_Secret) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{60, 1}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{69, 1}
 }
 
 func (x *SQLDatabaseEgress_Secret) GetName() string {
@@ -4494,7 +5424,7 @@ type EgressResponse_ObjectStorageResult struct {
 func (x *EgressResponse_ObjectStorageResult) Reset() {
 	*x = EgressResponse_ObjectStorageResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[68]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[81]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4507,7 +5437,7 @@ func (x *EgressResponse_ObjectStorageResult) String() string {
 func (*EgressResponse_ObjectStorageResult) ProtoMessage() {}
 
 func (x *EgressResponse_ObjectStorageResult) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[68]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[81]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4520,7 +5450,7 @@ func (x *EgressResponse_ObjectStorageResult) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use EgressResponse_ObjectStorageResult.ProtoReflect.Descriptor instead.
 func (*EgressResponse_ObjectStorageResult) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{62, 0}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{74, 0}
 }
 
 func (x *EgressResponse_ObjectStorageResult) GetBytesWritten() int64 {
@@ -4541,7 +5471,7 @@ type EgressResponse_SQLDatabaseResult struct {
 func (x *EgressResponse_SQLDatabaseResult) Reset() {
 	*x = EgressResponse_SQLDatabaseResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[69]
+		mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[82]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -4554,7 +5484,7 @@ func (x *EgressResponse_SQLDatabaseResult) String() string {
 func (*EgressResponse_SQLDatabaseResult) ProtoMessage() {}
 
 func (x *EgressResponse_SQLDatabaseResult) ProtoReflect() protoreflect.Message {
-	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[69]
+	mi := &file_pkg_api_v1_pfs_pfs_proto_msgTypes[82]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -4567,7 +5497,7 @@ func (x *EgressResponse_SQLDatabaseResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EgressResponse_SQLDatabaseResult.ProtoReflect.Descriptor instead.
 func (*EgressResponse_SQLDatabaseResult) Descriptor() ([]byte, []int) {
-	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{62, 1}
+	return file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP(), []int{74, 1}
 }
 
 func (x *EgressResponse_SQLDatabaseResult) GetRowsWritten() map[string]int64 {
@@ -4639,7 +5569,7 @@ var file_pkg_api_v1_pfs_pfs_proto_rawDesc = []byte{
 	0x76, 0x31, 0x2e, 0x61, 0x75, 0x74, 0x68, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
 	0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12,
 	0x14, 0x0a, 0x05, 0x72, 0x6f, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05,
-	0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0xa0, 0x02, 0x0a, 0x0a, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6c, 0x65, 0x73, 0x22, 0xb5, 0x03, 0x0a, 0x0a, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
 	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72,
 	0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x04,
@@ -4657,397 +5587,513 @@ var file_pkg_api_v1_pfs_pfs_proto_rawDesc = []byte{
 	0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x0a,
 	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f,
 	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52,
-	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x22, 0x7e, 0x0a, 0x07, 0x54, 0x72, 0x69, 0x67,
-	0x67, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x61,
-	0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x1b, 0x0a,
-	0x09, 0x63, 0x72, 0x6f, 0x6e, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x63, 0x72, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x18,
-	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x36, 0x0a, 0x0c, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x26, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64,
-	0x22, 0x4e, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72,
-	0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e,
-	0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e,
-	0x63, 0x68, 0x12, 0x16, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06,
-	0xe2, 0xde, 0x1f, 0x02, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x3a, 0x04, 0x98, 0xa0, 0x1f, 0x00,
-	0x22, 0x87, 0x06, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
-	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x52, 0x06, 0x6f,
-	0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
-	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x0d, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x0c,
-	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x33, 0x0a, 0x0d,
-	0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x05, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x52, 0x0c, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x73, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x07,
-	0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09, 0x66, 0x69, 0x6e, 0x69, 0x73,
-	0x68, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d,
-	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x69, 0x6e,
-	0x67, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x08, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
-	0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x11, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x09,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72,
-	0x61, 0x6e, 0x63, 0x68, 0x52, 0x10, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x50, 0x72, 0x6f, 0x76,
-	0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
-	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x33, 0x0a, 0x16,
-	0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72,
-	0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x73, 0x69,
-	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x55, 0x70, 0x70, 0x65, 0x72, 0x42, 0x6f, 0x75, 0x6e,
-	0x64, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x0c, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x07,
-	0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0xb0, 0x01, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61,
-	0x69, 0x6c, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74,
-	0x65, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6e, 0x67,
-	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69,
-	0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
-	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x76, 0x61, 0x6c, 0x69,
-	0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x23, 0x0a, 0x09, 0x43, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x42, 0x06, 0xe2, 0xde, 0x1f, 0x02, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x22,
-	0x6f, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f,
-	0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53,
-	0x65, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73,
-	0x22, 0xc8, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x20, 0x0a,
-	0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12,
-	0x2d, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x38,
-	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65,
-	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69,
-	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x6f, 0x0a, 0x11, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
-	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65,
-	0x70, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x36, 0x0a, 0x12,
-	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04,
-	0x72, 0x65, 0x70, 0x6f, 0x22, 0x25, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x65, 0x70, 0x6f,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x22, 0x4b, 0x0a, 0x11, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c,
-	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65,
-	0x70, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x86, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x61,
-	0x72, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x26, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
-	0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x75,
+	0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x55, 0x70, 0x70, 0x65, 0x72, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x34, 0x0a,
+	0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x49, 0x6e,
+	0x66, 0x6f, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x1a, 0x28, 0x0a, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x7e, 0x0a,
+	0x07, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61,
+	0x6c, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x72, 0x6f, 0x6e, 0x5f, 0x73, 0x70, 0x65, 0x63, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x72, 0x6f, 0x6e, 0x53, 0x70, 0x65, 0x63, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x36, 0x0a,
+	0x0c, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x26, 0x0a,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52,
+	0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x4e, 0x0a, 0x06, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
+	0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52,
+	0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x42, 0x06, 0xe2, 0xde, 0x1f, 0x02, 0x49, 0x44, 0x52, 0x02, 0x69, 0x64, 0x3a,
+	0x04, 0x98, 0xa0, 0x1f, 0x00, 0x22, 0x87, 0x06, 0x0a, 0x0a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x06,
+	0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x0d,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x52, 0x0c, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x12, 0x33, 0x0a, 0x0d, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x0c, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x38, 0x0a, 0x09,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x12, 0x36, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x3b,
+	0x0a, 0x11, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x10, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x33, 0x0a, 0x16, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x75, 0x70, 0x70, 0x65, 0x72, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x13, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x55, 0x70, 0x70, 0x65,
+	0x72, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x44, 0x65, 0x74, 0x61,
+	0x69, 0x6c, 0x73, 0x52, 0x07, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x1a, 0xb0, 0x01, 0x0a,
+	0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69,
+	0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0e, 0x63, 0x6f, 0x6d,
+	0x70, 0x61, 0x63, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x42, 0x0a, 0x0f, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6e, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x22,
+	0x23, 0x0a, 0x09, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x12, 0x16, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x06, 0xe2, 0xde, 0x1f, 0x02, 0x49, 0x44,
+	0x52, 0x02, 0x69, 0x64, 0x22, 0x6f, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65,
+	0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f,
+	0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0xc8, 0x01, 0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x2d, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68,
+	0x22, 0x6f, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
 	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d,
-	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72,
-	0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22,
-	0x67, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
-	0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12,
-	0x27, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61,
-	0x74, 0x65, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22, 0xf2, 0x01, 0x0a, 0x11, 0x4c, 0x69, 0x73,
-	0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20,
-	0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76,
-	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f,
-	0x12, 0x22, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
-	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x04,
-	0x66, 0x72, 0x6f, 0x6d, 0x12, 0x1e, 0x0a, 0x02, 0x74, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07,
-	0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72,
-	0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x33, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e,
-	0x64, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x22, 0x5f, 0x0a,
-	0x17, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76,
-	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52,
-	0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x61,
-	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22, 0x16,
-	0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4a, 0x0a, 0x16, 0x53, 0x71, 0x75, 0x61, 0x73, 0x68,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53,
-	0x65, 0x74, 0x22, 0x48, 0x0a, 0x14, 0x44, 0x72, 0x6f, 0x70, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
-	0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
-	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65,
-	0x74, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x22, 0xe8, 0x01, 0x0a,
-	0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x22, 0x36, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x52, 0x65, 0x70, 0x6f,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52,
-	0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x12, 0x22, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
-	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
-	0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61,
-	0x6c, 0x6c, 0x12, 0x33, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x6b, 0x69, 0x6e,
-	0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
-	0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0a, 0x6f, 0x72, 0x69,
-	0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x22, 0x3c, 0x0a, 0x12, 0x43, 0x6c, 0x65, 0x61, 0x72,
-	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a,
-	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x13, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x22, 0x0a,
-	0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x04, 0x68, 0x65, 0x61,
-	0x64, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e, 0x0a, 0x0a, 0x70, 0x72, 0x6f,
-	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x0a, 0x70,
-	0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x74, 0x72, 0x69,
-	0x67, 0x67, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x31, 0x2e,
-	0x70, 0x66, 0x73, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x52, 0x07, 0x74, 0x72, 0x69,
-	0x67, 0x67, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x65, 0x77, 0x5f, 0x63, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6e, 0x65,
-	0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x22, 0x3e, 0x0a, 0x14, 0x49, 0x6e,
-	0x73, 0x70, 0x65, 0x63, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e,
-	0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x22, 0x4f, 0x0a, 0x11, 0x4c, 0x69,
-	0x73, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70,
-	0x6f, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x22, 0x53, 0x0a, 0x13, 0x44,
-	0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x22, 0x25, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x22, 0x4b, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70,
+	0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0x86, 0x01,
+	0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26,
+	0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06,
+	0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x22, 0x8b, 0x01, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26,
+	0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66,
+	0x6f, 0x72, 0x63, 0x65, 0x22, 0x67, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x12, 0x27, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x22, 0xf2, 0x01,
+	0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52,
+	0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x22, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x1e, 0x0a, 0x02, 0x74, 0x6f, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x6c, 0x6c, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x33, 0x0a,
+	0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69,
+	0x6e, 0x64, 0x22, 0x5f, 0x0a, 0x17, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x12,
+	0x12, 0x0a, 0x04, 0x77, 0x61, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x77,
+	0x61, 0x69, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x4a, 0x0a, 0x16, 0x53,
+	0x71, 0x75, 0x61, 0x73, 0x68, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f,
+	0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x22, 0x48, 0x0a, 0x14, 0x44, 0x72, 0x6f, 0x70, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x30, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65,
+	0x74, 0x22, 0xe8, 0x01, 0x0a, 0x16, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04,
+	0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e,
+	0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16,
+	0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x29, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x33, 0x0a, 0x0b, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x5f, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x4f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64,
+	0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x4b, 0x69, 0x6e, 0x64, 0x22, 0x3c, 0x0a, 0x12,
+	0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0xe2, 0x01, 0x0a, 0x13, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x22, 0x0a, 0x04, 0x68, 0x65, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x52, 0x04, 0x68, 0x65, 0x61, 0x64, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
+	0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x2e,
+	0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x03,
 	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e,
-	0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6f,
-	0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65,
-	0x22, 0xda, 0x01, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04,
-	0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
-	0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x12, 0x2f, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x48, 0x00, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x2d, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x64,
-	0x64, 0x46, 0x69, 0x6c, 0x65, 0x2e, 0x55, 0x52, 0x4c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x48,
-	0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x3b, 0x0a, 0x09, 0x55, 0x52, 0x4c, 0x53, 0x6f, 0x75,
-	0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x52, 0x4c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x03, 0x55, 0x52, 0x4c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73, 0x69,
-	0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72, 0x65, 0x63, 0x75, 0x72, 0x73,
-	0x69, 0x76, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x22, 0x36, 0x0a,
-	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
-	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
-	0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
-	0x64, 0x61, 0x74, 0x75, 0x6d, 0x22, 0x6a, 0x0a, 0x08, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x64, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x12, 0x1e, 0x0a, 0x03, 0x73, 0x72, 0x63,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x46, 0x69, 0x6c, 0x65, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x70, 0x70,
-	0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e,
-	0x64, 0x22, 0xe2, 0x01, 0x0a, 0x11, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x46, 0x69, 0x6c, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x73, 0x65, 0x74, 0x5f, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x48, 0x00, 0x52, 0x09, 0x73,
-	0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a, 0x08, 0x61, 0x64, 0x64, 0x5f,
-	0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x76, 0x31, 0x2e,
-	0x70, 0x66, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x07, 0x61,
-	0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65,
-	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x48,
-	0x00, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2f, 0x0a,
-	0x09, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x69,
-	0x6c, 0x65, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x06,
-	0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x5c, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x52,
-	0x4c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x12, 0x16, 0x0a, 0x06,
-	0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66,
-	0x66, 0x73, 0x65, 0x74, 0x22, 0x36, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
-	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x39, 0x0a, 0x0f,
-	0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c,
-	0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x33, 0x0a, 0x0f, 0x57, 0x61, 0x6c, 0x6b, 0x46,
-	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69,
-	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
-	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x22, 0x53, 0x0a, 0x0f,
-	0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
-	0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52,
-	0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65,
-	0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72,
-	0x6e, 0x22, 0x7d, 0x0a, 0x0f, 0x44, 0x69, 0x66, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x66, 0x69, 0x6c, 0x65,
+	0x63, 0x68, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x29,
+	0x0a, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x54, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72,
+	0x52, 0x07, 0x74, 0x72, 0x69, 0x67, 0x67, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0e, 0x6e, 0x65, 0x77,
+	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x6e, 0x65, 0x77, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x65, 0x74, 0x22,
+	0x3e, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x22,
+	0x4f, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x65, 0x76, 0x65, 0x72, 0x73, 0x65,
+	0x22, 0x53, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12,
+	0x14, 0x0a, 0x05, 0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x22, 0xda, 0x01, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x12, 0x2f, 0x0a, 0x03, 0x72,
+	0x61, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x48, 0x00, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x2d, 0x0a, 0x03,
+	0x75, 0x72, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x2e, 0x55, 0x52, 0x4c, 0x53, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x48, 0x00, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x1a, 0x3b, 0x0a, 0x09, 0x55,
+	0x52, 0x4c, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x52, 0x4c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x72,
+	0x65, 0x63, 0x75, 0x72, 0x73, 0x69, 0x76, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x22, 0x36, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x22, 0x6a, 0x0a, 0x08, 0x43, 0x6f,
+	0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x64, 0x73, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x64, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75,
+	0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x12, 0x1e,
+	0x0a, 0x03, 0x73, 0x72, 0x63, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x03, 0x73, 0x72, 0x63, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x61, 0x70, 0x70, 0x65, 0x6e, 0x64, 0x22, 0xe2, 0x01, 0x0a, 0x11, 0x4d, 0x6f, 0x64, 0x69, 0x66,
+	0x79, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0a,
+	0x73, 0x65, 0x74, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x48, 0x00, 0x52, 0x09, 0x73, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x2c, 0x0a,
+	0x08, 0x61, 0x64, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x61, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x35, 0x0a, 0x0b, 0x64,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x0a, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x12, 0x2f, 0x0a, 0x09, 0x63, 0x6f, 0x70, 0x79, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43,
+	0x6f, 0x70, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x48, 0x00, 0x52, 0x08, 0x63, 0x6f, 0x70, 0x79, 0x46,
+	0x69, 0x6c, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x22, 0x42, 0x0a, 0x09, 0x42,
+	0x79, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0xa6, 0x01, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04,
+	0x66, 0x69, 0x6c, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x55, 0x52, 0x4c, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x55, 0x52, 0x4c, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x29, 0x0a,
+	0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65,
+	0x52, 0x06, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0x36, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x70,
+	0x65, 0x63, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x22, 0x39, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52,
+	0x04, 0x66, 0x69, 0x6c, 0x65, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x03, 0x22, 0x33, 0x0a, 0x0f, 0x57,
+	0x61, 0x6c, 0x6b, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x22, 0x53, 0x0a, 0x0f, 0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x74, 0x74, 0x65, 0x72, 0x6e, 0x22, 0x7d, 0x0a, 0x0f, 0x44, 0x69, 0x66, 0x66, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e,
+	0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c,
+	0x65, 0x12, 0x27, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c,
+	0x65, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x68,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x68, 0x61,
+	0x6c, 0x6c, 0x6f, 0x77, 0x22, 0x6c, 0x0a, 0x10, 0x44, 0x69, 0x66, 0x66, 0x46, 0x69, 0x6c, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f,
+	0x66, 0x69, 0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e,
+	0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6e, 0x65,
+	0x77, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x22, 0x6b, 0x0a, 0x12, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x46, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x27, 0x0a,
-	0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x07, 0x6f,
-	0x6c, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x68, 0x61, 0x6c, 0x6c, 0x6f,
-	0x77, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x68, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
-	0x22, 0x6c, 0x0a, 0x10, 0x44, 0x69, 0x66, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6e, 0x65, 0x77, 0x5f, 0x66, 0x69, 0x6c, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x46, 0x69, 0x6c, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c,
-	0x65, 0x12, 0x2b, 0x0a, 0x08, 0x6f, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c,
-	0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6f, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x22, 0x1f,
-	0x0a, 0x0b, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
-	0x03, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x66, 0x69, 0x78, 0x22,
-	0x36, 0x0a, 0x0c, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x10, 0x0a, 0x03, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69,
-	0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x37, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x61,
+	0x74, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22,
+	0xfa, 0x01, 0x0a, 0x0a, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20,
+	0x0a, 0x04, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x04, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x73, 0x69, 0x7a, 0x65,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70, 0x61, 0x72, 0x74, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d,
+	0x70, 0x61, 0x72, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x34, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x22, 0x5b, 0x0a, 0x10,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x16, 0x0a,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x6f,
+	0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x22, 0x85, 0x01, 0x0a, 0x14, 0x50, 0x75,
+	0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x32, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06,
+	0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x79, 0x74, 0x65, 0x73, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x48, 0x00, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x42, 0x06, 0x0a, 0x04, 0x62, 0x6f, 0x64,
+	0x79, 0x22, 0x33, 0x0a, 0x14, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x22, 0x32, 0x0a, 0x13, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a,
+	0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x22, 0x31, 0x0a, 0x12, 0x41, 0x62,
+	0x6f, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x22, 0x1f, 0x0a,
+	0x0b, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x66, 0x69, 0x78, 0x22, 0x36,
+	0x0a, 0x0c, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x66, 0x69, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x78,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x37, 0x0a, 0x15, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1e, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64, 0x22,
+	0x3b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x5b, 0x0a, 0x11,
+	0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x13, 0x52, 0x65, 0x6e,
+	0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
 	0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64,
-	0x22, 0x3b, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x22, 0x5b, 0x0a,
-	0x11, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x69,
-	0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64, 0x22, 0x56, 0x0a, 0x13, 0x52, 0x65,
-	0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1e, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49,
-	0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
-	0x64, 0x73, 0x22, 0x5a, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x66,
-	0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a,
-	0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x7b,
-	0x0a, 0x13, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x68,
-	0x75, 0x6e, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d,
-	0x72, 0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a,
-	0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x1b,
-	0x0a, 0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x64, 0x22, 0x44, 0x0a, 0x14, 0x43,
-	0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x6f, 0x62, 0x6a,
-	0x65, 0x63, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x10, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e,
-	0x74, 0x22, 0x83, 0x01, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f,
-	0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53,
-	0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x23, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61,
-	0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
-	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3e, 0x0a, 0x10,
-	0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x32, 0x0a, 0x11,
-	0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x67, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78,
-	0x22, 0x15, 0x0a, 0x13, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x41, 0x63, 0x74, 0x69, 0x76,
-	0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x64, 0x0a, 0x12, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61,
-	0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x04, 0x73, 0x65, 0x65, 0x64, 0x22, 0xb2, 0x01, 0x0a, 0x13, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61,
-	0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a,
-	0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x70, 0x65,
-	0x63, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63,
-	0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65,
-	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
-	0x72, 0x6f, 0x72, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x27, 0x0a, 0x13, 0x4f, 0x62,
-	0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73,
-	0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x75, 0x72, 0x6c, 0x22, 0xf3, 0x02, 0x0a, 0x11, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x62,
-	0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12, 0x45, 0x0a, 0x0b, 0x66,
-	0x69, 0x6c, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x24, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74,
-	0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65,
-	0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44,
-	0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x53, 0x65,
-	0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x1a, 0x9a, 0x01, 0x0a,
-	0x0a, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x3d, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x29, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67,
-	0x72, 0x65, 0x73, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2e,
-	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f,
-	0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c,
-	0x75, 0x6d, 0x6e, 0x73, 0x22, 0x33, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07,
-	0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x53, 0x56,
-	0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07,
-	0x50, 0x41, 0x52, 0x51, 0x55, 0x45, 0x54, 0x10, 0x03, 0x1a, 0x2e, 0x0a, 0x06, 0x53, 0x65, 0x63,
-	0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xc7, 0x01, 0x0a, 0x0d, 0x45, 0x67,
+	0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x22, 0x5a, 0x0a, 0x15, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x69,
+	0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x49, 0x64, 0x73, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x74, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x74, 0x74, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0x7b, 0x0a,
+	0x13, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x61, 0x64, 0x5f, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x72,
+	0x65, 0x61, 0x64, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x44, 0x61, 0x74, 0x61, 0x12, 0x1f, 0x0a, 0x0b,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x62, 0x65, 0x67, 0x69, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x65, 0x67, 0x69, 0x6e, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x65, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x08, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x64, 0x22, 0x44, 0x0a, 0x14, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x6f, 0x62, 0x6a, 0x65,
+	0x63, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x63, 0x68, 0x75, 0x6e, 0x6b, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x22, 0x83, 0x01, 0x0a, 0x0f, 0x50, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x12, 0x20, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x66, 0x69, 0x6c, 0x65, 0x53, 0x65,
+	0x74, 0x49, 0x64, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x61, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x74, 0x61, 0x67, 0x22, 0x23, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x3e, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x41, 0x6e, 0x79, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x32, 0x0a, 0x11, 0x43,
+	0x6c, 0x65, 0x61, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x67, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x61, 0x67, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22,
+	0x15, 0x0a, 0x13, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x64,
+	0x0a, 0x12, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04,
+	0x73, 0x65, 0x65, 0x64, 0x22, 0x89, 0x02, 0x0a, 0x13, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64,
+	0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x70, 0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63,
+	0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68,
+	0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x65, 0x65, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x65, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f,
+	0x70, 0x65, 0x72, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0e, 0x62, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x22, 0x8d, 0x02, 0x0a, 0x0d, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2b, 0x0a, 0x03,
+	0x70, 0x35, 0x30, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x70, 0x35, 0x30, 0x12, 0x2b, 0x0a, 0x03, 0x70, 0x39, 0x30,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x03, 0x70, 0x39, 0x30, 0x12, 0x2b, 0x0a, 0x03, 0x70, 0x39, 0x39, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03,
+	0x70, 0x39, 0x39, 0x12, 0x2b, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x61, 0x78,
+	0x22, 0x27, 0x0a, 0x13, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67,
+	0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x22, 0xf3, 0x02, 0x0a, 0x11, 0x53, 0x51,
+	0x4c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x10, 0x0a, 0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72,
+	0x6c, 0x12, 0x45, 0x0a, 0x0b, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
+	0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x0a, 0x66, 0x69,
+	0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x38, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61, 0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72,
+	0x65, 0x74, 0x1a, 0x9a, 0x01, 0x0a, 0x0a, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x3d, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x29, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x46,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x73, 0x22, 0x33, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x07, 0x0a, 0x03, 0x43, 0x53, 0x56, 0x10, 0x01, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e,
+	0x10, 0x02, 0x12, 0x0b, 0x0a, 0x07, 0x50, 0x41, 0x52, 0x51, 0x55, 0x45, 0x54, 0x10, 0x03, 0x1a,
+	0x2e, 0x0a, 0x06, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22,
+	0x8c, 0x02, 0x0a, 0x09, 0x53, 0x51, 0x4c, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x75, 0x72, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x75, 0x72, 0x6c, 0x12,
+	0x38, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x53, 0x51, 0x4c, 0x44, 0x61, 0x74, 0x61,
+	0x62, 0x61, 0x73, 0x65, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12,
+	0x2f, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x09, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x12, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x66,
+	0x69, 0x6c, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x75, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x44, 0x61, 0x74, 0x75,
+	0x6d, 0x73, 0x12, 0x2a, 0x0a, 0x11, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x66, 0x69, 0x6c,
+	0x65, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x5f,
+	0x0a, 0x10, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x51, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x42, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x12, 0x23, 0x0a, 0x03, 0x73, 0x71,
+	0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
+	0x2e, 0x53, 0x51, 0x4c, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x52, 0x03, 0x73, 0x71, 0x6c, 0x22,
+	0x58, 0x0a, 0x11, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x51, 0x4c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x72, 0x6f, 0x77, 0x73, 0x5f, 0x72, 0x65, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x72, 0x6f, 0x77, 0x73, 0x52, 0x65, 0x61, 0x64, 0x22, 0xc7, 0x01, 0x0a, 0x0d, 0x45, 0x67,
 	0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x06, 0x63,
 	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x76, 0x31,
 	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x52, 0x06, 0x63, 0x6f, 0x6d,
@@ -5104,7 +6150,7 @@ var file_pkg_api_v1_pfs_pfs_proto_rawDesc = []byte{
 	0x3b, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x08, 0x0a, 0x04,
 	0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x4a, 0x53, 0x4f, 0x4e, 0x10, 0x01,
 	0x12, 0x08, 0x0a, 0x04, 0x4c, 0x49, 0x4e, 0x45, 0x10, 0x02, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x51,
-	0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x53, 0x56, 0x10, 0x04, 0x32, 0xba, 0x16, 0x0a,
+	0x4c, 0x10, 0x03, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x53, 0x56, 0x10, 0x04, 0x32, 0xd7, 0x19, 0x0a,
 	0x03, 0x41, 0x50, 0x49, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x52, 0x65,
 	0x70, 0x6f, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61,
 	0x74, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
@@ -5215,79 +6261,105 @@ var file_pkg_api_v1_pfs_pfs_proto_rawDesc = []byte{
 	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x44, 0x69, 0x66, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x44, 0x69,
 	0x66, 0x66, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
-	0x30, 0x01, 0x12, 0x4b, 0x0a, 0x0c, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75,
-	0x74, 0x68, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69,
-	0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74,
-	0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3d, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67,
+	0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0b, 0x53, 0x74, 0x61, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74,
+	0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66,
+	0x6f, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0d, 0x50, 0x75, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64,
+	0x50, 0x61, 0x72, 0x74, 0x12, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x50, 0x75,
+	0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x50, 0x61, 0x72, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x28, 0x01, 0x12, 0x43, 0x0a, 0x0d, 0x49, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x55, 0x70, 0x6c, 0x6f,
+	0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12,
+	0x45, 0x0a, 0x0c, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12,
+	0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
 	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x35,
-	0x0a, 0x04, 0x46, 0x73, 0x63, 0x6b, 0x12, 0x13, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46,
-	0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
-	0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x46, 0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
-	0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53,
-	0x65, 0x74, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46,
-	0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e,
-	0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c,
-	0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41,
-	0x0a, 0x0a, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x76,
-	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x43, 0x0a, 0x0b, 0x41, 0x62, 0x6f, 0x72, 0x74, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41,
+	0x62, 0x6f, 0x72, 0x74, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x12, 0x1b, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74,
+	0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x09, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x41, 0x6c, 0x6c, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x16, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x35, 0x0a, 0x04, 0x46, 0x73, 0x63, 0x6b, 0x12,
+	0x13, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x73, 0x63, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x46, 0x73,
+	0x63, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x4d,
+	0x0a, 0x0d, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12,
+	0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x46,
+	0x69, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e,
+	0x70, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65,
+	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x28, 0x01, 0x12, 0x48, 0x0a,
+	0x0a, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0a, 0x41, 0x64, 0x64, 0x46, 0x69,
+	0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x41,
+	0x64, 0x64, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0c, 0x52, 0x65,
+	0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e,
+	0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74,
 	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
-	0x00, 0x12, 0x45, 0x0a, 0x0c, 0x52, 0x65, 0x6e, 0x65, 0x77, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65,
-	0x74, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x65, 0x6e, 0x65, 0x77,
-	0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16,
-	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x50, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70,
-	0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x2e,
-	0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53,
-	0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x68,
-	0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e,
-	0x70, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73,
-	0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x43, 0x61,
-	0x63, 0x68, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x50, 0x75, 0x74,
-	0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
-	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63,
-	0x68, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43,
-	0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31,
-	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x41, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x72,
-	0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43,
-	0x6c, 0x65, 0x61, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x52, 0x75,
-	0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70,
-	0x66, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52,
-	0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x12, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54,
-	0x65, 0x73, 0x74, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f,
-	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70,
-	0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x4c,
-	0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x3b, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x2e,
-	0x76, 0x31, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x74, 0x61, 0x73,
-	0x6b, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00, 0x30, 0x01, 0x12, 0x39,
-	0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
-	0x73, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x16, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x68, 0x6f, 0x6a, 0x70, 0x75, 0x72, 0x2f,
-	0x64, 0x61, 0x74, 0x61, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f,
-	0x70, 0x66, 0x73, 0x3b, 0x70, 0x66, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x00, 0x12, 0x50, 0x0a, 0x0e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65,
+	0x53, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6f, 0x6d,
+	0x70, 0x6f, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0c, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72,
+	0x61, 0x67, 0x65, 0x12, 0x1b, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1c, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x3d, 0x0a, 0x08, 0x50, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x17, 0x2e, 0x76,
+	0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x50, 0x75, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12,
+	0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x17, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x41, 0x0a, 0x0a, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x19,
+	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x43, 0x6c, 0x65, 0x61, 0x72, 0x43, 0x61, 0x63,
+	0x68, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x22, 0x00, 0x12, 0x48, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65,
+	0x73, 0x74, 0x12, 0x1a, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x4c,
+	0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
+	0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4b, 0x0a,
+	0x12, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74, 0x44, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1b, 0x2e, 0x76, 0x31,
+	0x2e, 0x70, 0x66, 0x73, 0x2e, 0x52, 0x75, 0x6e, 0x4c, 0x6f, 0x61, 0x64, 0x54, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3b, 0x0a, 0x08, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x18, 0x2e, 0x76, 0x31, 0x2e, 0x74, 0x61, 0x73, 0x6b,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x11, 0x2e, 0x76, 0x31, 0x2e, 0x74, 0x61, 0x73, 0x6b, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0x00, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x06, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x15, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66,
+	0x73, 0x2e, 0x45, 0x67, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x42, 0x0a, 0x09, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x51, 0x4c, 0x12,
+	0x18, 0x2e, 0x76, 0x31, 0x2e, 0x70, 0x66, 0x73, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53,
+	0x51, 0x4c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x31, 0x2e, 0x70,
+	0x66, 0x73, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x53, 0x51, 0x4c, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x68, 0x6f, 0x6a, 0x70, 0x75, 0x72, 0x2f, 0x64, 0x61, 0x74,
+	0x61, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x70, 0x66, 0x73,
+	0x3b, 0x70, 0x66, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -5303,7 +6375,7 @@ func file_pkg_api_v1_pfs_pfs_proto_rawDescGZIP() []byte {
 }
 
 var file_pkg_api_v1_pfs_pfs_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
-var file_pkg_api_v1_pfs_pfs_proto_msgTypes = make([]protoimpl.MessageInfo, 71)
+var file_pkg_api_v1_pfs_pfs_proto_msgTypes = make([]protoimpl.MessageInfo, 84)
 var file_pkg_api_v1_pfs_pfs_proto_goTypes = []interface{}{
 	(OriginKind)(0),                            // 0: v1.pfs.OriginKind
 	(FileType)(0),                              // 1: v1.pfs.FileType
@@ -5345,232 +6417,274 @@ var file_pkg_api_v1_pfs_pfs_proto_goTypes = []interface{}{
 	(*DeleteFile)(nil),                         // 37: v1.pfs.DeleteFile
 	(*CopyFile)(nil),                           // 38: v1.pfs.CopyFile
 	(*ModifyFileRequest)(nil),                  // 39: v1.pfs.ModifyFileRequest
-	(*GetFileRequest)(nil),                     // 40: v1.pfs.GetFileRequest
-	(*InspectFileRequest)(nil),                 // 41: v1.pfs.InspectFileRequest
-	(*ListFileRequest)(nil),                    // 42: v1.pfs.ListFileRequest
-	(*WalkFileRequest)(nil),                    // 43: v1.pfs.WalkFileRequest
-	(*GlobFileRequest)(nil),                    // 44: v1.pfs.GlobFileRequest
-	(*DiffFileRequest)(nil),                    // 45: v1.pfs.DiffFileRequest
-	(*DiffFileResponse)(nil),                   // 46: v1.pfs.DiffFileResponse
-	(*FsckRequest)(nil),                        // 47: v1.pfs.FsckRequest
-	(*FsckResponse)(nil),                       // 48: v1.pfs.FsckResponse
-	(*CreateFileSetResponse)(nil),              // 49: v1.pfs.CreateFileSetResponse
-	(*GetFileSetRequest)(nil),                  // 50: v1.pfs.GetFileSetRequest
-	(*AddFileSetRequest)(nil),                  // 51: v1.pfs.AddFileSetRequest
-	(*RenewFileSetRequest)(nil),                // 52: v1.pfs.RenewFileSetRequest
-	(*ComposeFileSetRequest)(nil),              // 53: v1.pfs.ComposeFileSetRequest
-	(*CheckStorageRequest)(nil),                // 54: v1.pfs.CheckStorageRequest
-	(*CheckStorageResponse)(nil),               // 55: v1.pfs.CheckStorageResponse
-	(*PutCacheRequest)(nil),                    // 56: v1.pfs.PutCacheRequest
-	(*GetCacheRequest)(nil),                    // 57: v1.pfs.GetCacheRequest
-	(*GetCacheResponse)(nil),                   // 58: v1.pfs.GetCacheResponse
-	(*ClearCacheRequest)(nil),                  // 59: v1.pfs.ClearCacheRequest
-	(*ActivateAuthRequest)(nil),                // 60: v1.pfs.ActivateAuthRequest
-	(*ActivateAuthResponse)(nil),               // 61: v1.pfs.ActivateAuthResponse
-	(*RunLoadTestRequest)(nil),                 // 62: v1.pfs.RunLoadTestRequest
-	(*RunLoadTestResponse)(nil),                // 63: v1.pfs.RunLoadTestResponse
-	(*ObjectStorageEgress)(nil),                // 64: v1.pfs.ObjectStorageEgress
-	(*SQLDatabaseEgress)(nil),                  // 65: v1.pfs.SQLDatabaseEgress
-	(*EgressRequest)(nil),                      // 66: v1.pfs.EgressRequest
-	(*EgressResponse)(nil),                     // 67: v1.pfs.EgressResponse
-	(*RepoInfo_Details)(nil),                   // 68: v1.pfs.RepoInfo.Details
-	(*CommitInfo_Details)(nil),                 // 69: v1.pfs.CommitInfo.Details
-	(*AddFile_URLSource)(nil),                  // 70: v1.pfs.AddFile.URLSource
-	(*SQLDatabaseEgress_FileFormat)(nil),       // 71: v1.pfs.SQLDatabaseEgress.FileFormat
-	(*SQLDatabaseEgress_Secret)(nil),           // 72: v1.pfs.SQLDatabaseEgress.Secret
-	(*EgressResponse_ObjectStorageResult)(nil), // 73: v1.pfs.EgressResponse.ObjectStorageResult
-	(*EgressResponse_SQLDatabaseResult)(nil),   // 74: v1.pfs.EgressResponse.SQLDatabaseResult
-	nil,                                        // 75: v1.pfs.EgressResponse.SQLDatabaseResult.RowsWrittenEntry
-	(*timestamppb.Timestamp)(nil),              // 76: google.protobuf.Timestamp
-	(auth.Permission)(0),                       // 77: v1.auth.Permission
-	(*wrapperspb.BytesValue)(nil),              // 78: google.protobuf.BytesValue
-	(*anypb.Any)(nil),                          // 79: google.protobuf.Any
-	(*durationpb.Duration)(nil),                // 80: google.protobuf.Duration
-	(*emptypb.Empty)(nil),                      // 81: google.protobuf.Empty
-	(*task.ListTaskRequest)(nil),               // 82: v1.task.ListTaskRequest
-	(*task.TaskInfo)(nil),                      // 83: v1.task.TaskInfo
+	(*ByteRange)(nil),                          // 40: v1.pfs.ByteRange
+	(*GetFileRequest)(nil),                     // 41: v1.pfs.GetFileRequest
+	(*InspectFileRequest)(nil),                 // 42: v1.pfs.InspectFileRequest
+	(*ListFileRequest)(nil),                    // 43: v1.pfs.ListFileRequest
+	(*WalkFileRequest)(nil),                    // 44: v1.pfs.WalkFileRequest
+	(*GlobFileRequest)(nil),                    // 45: v1.pfs.GlobFileRequest
+	(*DiffFileRequest)(nil),                    // 46: v1.pfs.DiffFileRequest
+	(*DiffFileResponse)(nil),                   // 47: v1.pfs.DiffFileResponse
+	(*StartUploadRequest)(nil),                 // 48: v1.pfs.StartUploadRequest
+	(*UploadInfo)(nil),                         // 49: v1.pfs.UploadInfo
+	(*UploadPartHeader)(nil),                   // 50: v1.pfs.UploadPartHeader
+	(*PutUploadPartRequest)(nil),               // 51: v1.pfs.PutUploadPartRequest
+	(*InspectUploadRequest)(nil),               // 52: v1.pfs.InspectUploadRequest
+	(*FinishUploadRequest)(nil),                // 53: v1.pfs.FinishUploadRequest
+	(*AbortUploadRequest)(nil),                 // 54: v1.pfs.AbortUploadRequest
+	(*FsckRequest)(nil),                        // 55: v1.pfs.FsckRequest
+	(*FsckResponse)(nil),                       // 56: v1.pfs.FsckResponse
+	(*CreateFileSetResponse)(nil),              // 57: v1.pfs.CreateFileSetResponse
+	(*GetFileSetRequest)(nil),                  // 58: v1.pfs.GetFileSetRequest
+	(*AddFileSetRequest)(nil),                  // 59: v1.pfs.AddFileSetRequest
+	(*RenewFileSetRequest)(nil),                // 60: v1.pfs.RenewFileSetRequest
+	(*ComposeFileSetRequest)(nil),              // 61: v1.pfs.ComposeFileSetRequest
+	(*CheckStorageRequest)(nil),                // 62: v1.pfs.CheckStorageRequest
+	(*CheckStorageResponse)(nil),               // 63: v1.pfs.CheckStorageResponse
+	(*PutCacheRequest)(nil),                    // 64: v1.pfs.PutCacheRequest
+	(*GetCacheRequest)(nil),                    // 65: v1.pfs.GetCacheRequest
+	(*GetCacheResponse)(nil),                   // 66: v1.pfs.GetCacheResponse
+	(*ClearCacheRequest)(nil),                  // 67: v1.pfs.ClearCacheRequest
+	(*ActivateAuthRequest)(nil),                // 68: v1.pfs.ActivateAuthRequest
+	(*ActivateAuthResponse)(nil),               // 69: v1.pfs.ActivateAuthResponse
+	(*RunLoadTestRequest)(nil),                 // 70: v1.pfs.RunLoadTestRequest
+	(*RunLoadTestResponse)(nil),                // 71: v1.pfs.RunLoadTestResponse
+	(*LoadTestStats)(nil),                      // 72: v1.pfs.LoadTestStats
+	(*ObjectStorageEgress)(nil),                // 73: v1.pfs.ObjectStorageEgress
+	(*SQLDatabaseEgress)(nil),                  // 74: v1.pfs.SQLDatabaseEgress
+	(*SQLIngest)(nil),                          // 75: v1.pfs.SQLIngest
+	(*IngestSQLRequest)(nil),                   // 76: v1.pfs.IngestSQLRequest
+	(*IngestSQLResponse)(nil),                  // 77: v1.pfs.IngestSQLResponse
+	(*EgressRequest)(nil),                      // 78: v1.pfs.EgressRequest
+	(*EgressResponse)(nil),                     // 79: v1.pfs.EgressResponse
+	(*RepoInfo_Details)(nil),                   // 80: v1.pfs.RepoInfo.Details
+	(*BranchInfo_Details)(nil),                 // 81: v1.pfs.BranchInfo.Details
+	(*CommitInfo_Details)(nil),                 // 82: v1.pfs.CommitInfo.Details
+	(*AddFile_URLSource)(nil),                  // 83: v1.pfs.AddFile.URLSource
+	(*SQLDatabaseEgress_FileFormat)(nil),       // 84: v1.pfs.SQLDatabaseEgress.FileFormat
+	(*SQLDatabaseEgress_Secret)(nil),           // 85: v1.pfs.SQLDatabaseEgress.Secret
+	(*EgressResponse_ObjectStorageResult)(nil), // 86: v1.pfs.EgressResponse.ObjectStorageResult
+	(*EgressResponse_SQLDatabaseResult)(nil),   // 87: v1.pfs.EgressResponse.SQLDatabaseResult
+	nil,                                        // 88: v1.pfs.EgressResponse.SQLDatabaseResult.RowsWrittenEntry
+	(*timestamppb.Timestamp)(nil),              // 89: google.protobuf.Timestamp
+	(auth.Permission)(0),                       // 90: v1.auth.Permission
+	(*wrapperspb.BytesValue)(nil),              // 91: google.protobuf.BytesValue
+	(*anypb.Any)(nil),                          // 92: google.protobuf.Any
+	(*durationpb.Duration)(nil),                // 93: google.protobuf.Duration
+	(*emptypb.Empty)(nil),                      // 94: google.protobuf.Empty
+	(*task.ListTaskRequest)(nil),               // 95: v1.task.ListTaskRequest
+	(*task.TaskInfo)(nil),                      // 96: v1.task.TaskInfo
 }
 var file_pkg_api_v1_pfs_pfs_proto_depIdxs = []int32{
 	5,   // 0: v1.pfs.Branch.repo:type_name -> v1.pfs.Repo
 	13,  // 1: v1.pfs.File.commit:type_name -> v1.pfs.Commit
 	5,   // 2: v1.pfs.RepoInfo.repo:type_name -> v1.pfs.Repo
-	76,  // 3: v1.pfs.RepoInfo.created:type_name -> google.protobuf.Timestamp
+	89,  // 3: v1.pfs.RepoInfo.created:type_name -> google.protobuf.Timestamp
 	6,   // 4: v1.pfs.RepoInfo.branches:type_name -> v1.pfs.Branch
 	9,   // 5: v1.pfs.RepoInfo.auth_info:type_name -> v1.pfs.RepoAuthInfo
-	68,  // 6: v1.pfs.RepoInfo.details:type_name -> v1.pfs.RepoInfo.Details
-	77,  // 7: v1.pfs.RepoAuthInfo.permissions:type_name -> v1.auth.Permission
+	80,  // 6: v1.pfs.RepoInfo.details:type_name -> v1.pfs.RepoInfo.Details
+	90,  // 7: v1.pfs.RepoAuthInfo.permissions:type_name -> v1.auth.Permission
 	6,   // 8: v1.pfs.BranchInfo.branch:type_name -> v1.pfs.Branch
 	13,  // 9: v1.pfs.BranchInfo.head:type_name -> v1.pfs.Commit
 	6,   // 10: v1.pfs.BranchInfo.provenance:type_name -> v1.pfs.Branch
 	6,   // 11: v1.pfs.BranchInfo.subvenance:type_name -> v1.pfs.Branch
 	6,   // 12: v1.pfs.BranchInfo.direct_provenance:type_name -> v1.pfs.Branch
 	11,  // 13: v1.pfs.BranchInfo.trigger:type_name -> v1.pfs.Trigger
-	0,   // 14: v1.pfs.CommitOrigin.kind:type_name -> v1.pfs.OriginKind
-	6,   // 15: v1.pfs.Commit.branch:type_name -> v1.pfs.Branch
-	13,  // 16: v1.pfs.CommitInfo.commit:type_name -> v1.pfs.Commit
-	12,  // 17: v1.pfs.CommitInfo.origin:type_name -> v1.pfs.CommitOrigin
-	13,  // 18: v1.pfs.CommitInfo.parent_commit:type_name -> v1.pfs.Commit
-	13,  // 19: v1.pfs.CommitInfo.child_commits:type_name -> v1.pfs.Commit
-	76,  // 20: v1.pfs.CommitInfo.started:type_name -> google.protobuf.Timestamp
-	76,  // 21: v1.pfs.CommitInfo.finishing:type_name -> google.protobuf.Timestamp
-	76,  // 22: v1.pfs.CommitInfo.finished:type_name -> google.protobuf.Timestamp
-	6,   // 23: v1.pfs.CommitInfo.direct_provenance:type_name -> v1.pfs.Branch
-	69,  // 24: v1.pfs.CommitInfo.details:type_name -> v1.pfs.CommitInfo.Details
-	15,  // 25: v1.pfs.CommitSetInfo.commit_set:type_name -> v1.pfs.CommitSet
-	14,  // 26: v1.pfs.CommitSetInfo.commits:type_name -> v1.pfs.CommitInfo
-	7,   // 27: v1.pfs.FileInfo.file:type_name -> v1.pfs.File
-	1,   // 28: v1.pfs.FileInfo.file_type:type_name -> v1.pfs.FileType
-	76,  // 29: v1.pfs.FileInfo.committed:type_name -> google.protobuf.Timestamp
-	5,   // 30: v1.pfs.CreateRepoRequest.repo:type_name -> v1.pfs.Repo
-	5,   // 31: v1.pfs.InspectRepoRequest.repo:type_name -> v1.pfs.Repo
-	5,   // 32: v1.pfs.DeleteRepoRequest.repo:type_name -> v1.pfs.Repo
-	13,  // 33: v1.pfs.StartCommitRequest.parent:type_name -> v1.pfs.Commit
-	6,   // 34: v1.pfs.StartCommitRequest.branch:type_name -> v1.pfs.Branch
-	13,  // 35: v1.pfs.FinishCommitRequest.commit:type_name -> v1.pfs.Commit
-	13,  // 36: v1.pfs.InspectCommitRequest.commit:type_name -> v1.pfs.Commit
-	2,   // 37: v1.pfs.InspectCommitRequest.wait:type_name -> v1.pfs.CommitState
-	5,   // 38: v1.pfs.ListCommitRequest.repo:type_name -> v1.pfs.Repo
-	13,  // 39: v1.pfs.ListCommitRequest.from:type_name -> v1.pfs.Commit
-	13,  // 40: v1.pfs.ListCommitRequest.to:type_name -> v1.pfs.Commit
-	0,   // 41: v1.pfs.ListCommitRequest.origin_kind:type_name -> v1.pfs.OriginKind
-	15,  // 42: v1.pfs.InspectCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
-	15,  // 43: v1.pfs.SquashCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
-	15,  // 44: v1.pfs.DropCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
-	5,   // 45: v1.pfs.SubscribeCommitRequest.repo:type_name -> v1.pfs.Repo
-	13,  // 46: v1.pfs.SubscribeCommitRequest.from:type_name -> v1.pfs.Commit
-	2,   // 47: v1.pfs.SubscribeCommitRequest.state:type_name -> v1.pfs.CommitState
-	0,   // 48: v1.pfs.SubscribeCommitRequest.origin_kind:type_name -> v1.pfs.OriginKind
-	13,  // 49: v1.pfs.ClearCommitRequest.commit:type_name -> v1.pfs.Commit
-	13,  // 50: v1.pfs.CreateBranchRequest.head:type_name -> v1.pfs.Commit
-	6,   // 51: v1.pfs.CreateBranchRequest.branch:type_name -> v1.pfs.Branch
-	6,   // 52: v1.pfs.CreateBranchRequest.provenance:type_name -> v1.pfs.Branch
-	11,  // 53: v1.pfs.CreateBranchRequest.trigger:type_name -> v1.pfs.Trigger
-	6,   // 54: v1.pfs.InspectBranchRequest.branch:type_name -> v1.pfs.Branch
-	5,   // 55: v1.pfs.ListBranchRequest.repo:type_name -> v1.pfs.Repo
-	6,   // 56: v1.pfs.DeleteBranchRequest.branch:type_name -> v1.pfs.Branch
-	78,  // 57: v1.pfs.AddFile.raw:type_name -> google.protobuf.BytesValue
-	70,  // 58: v1.pfs.AddFile.url:type_name -> v1.pfs.AddFile.URLSource
-	7,   // 59: v1.pfs.CopyFile.src:type_name -> v1.pfs.File
-	13,  // 60: v1.pfs.ModifyFileRequest.set_commit:type_name -> v1.pfs.Commit
-	36,  // 61: v1.pfs.ModifyFileRequest.add_file:type_name -> v1.pfs.AddFile
-	37,  // 62: v1.pfs.ModifyFileRequest.delete_file:type_name -> v1.pfs.DeleteFile
-	38,  // 63: v1.pfs.ModifyFileRequest.copy_file:type_name -> v1.pfs.CopyFile
-	7,   // 64: v1.pfs.GetFileRequest.file:type_name -> v1.pfs.File
-	7,   // 65: v1.pfs.InspectFileRequest.file:type_name -> v1.pfs.File
-	7,   // 66: v1.pfs.ListFileRequest.file:type_name -> v1.pfs.File
-	7,   // 67: v1.pfs.WalkFileRequest.file:type_name -> v1.pfs.File
-	13,  // 68: v1.pfs.GlobFileRequest.commit:type_name -> v1.pfs.Commit
-	7,   // 69: v1.pfs.DiffFileRequest.new_file:type_name -> v1.pfs.File
-	7,   // 70: v1.pfs.DiffFileRequest.old_file:type_name -> v1.pfs.File
-	17,  // 71: v1.pfs.DiffFileResponse.new_file:type_name -> v1.pfs.FileInfo
-	17,  // 72: v1.pfs.DiffFileResponse.old_file:type_name -> v1.pfs.FileInfo
-	13,  // 73: v1.pfs.GetFileSetRequest.commit:type_name -> v1.pfs.Commit
-	13,  // 74: v1.pfs.AddFileSetRequest.commit:type_name -> v1.pfs.Commit
-	79,  // 75: v1.pfs.PutCacheRequest.value:type_name -> google.protobuf.Any
-	79,  // 76: v1.pfs.GetCacheResponse.value:type_name -> google.protobuf.Any
-	6,   // 77: v1.pfs.RunLoadTestRequest.branch:type_name -> v1.pfs.Branch
-	6,   // 78: v1.pfs.RunLoadTestResponse.branch:type_name -> v1.pfs.Branch
-	80,  // 79: v1.pfs.RunLoadTestResponse.duration:type_name -> google.protobuf.Duration
-	71,  // 80: v1.pfs.SQLDatabaseEgress.file_format:type_name -> v1.pfs.SQLDatabaseEgress.FileFormat
-	72,  // 81: v1.pfs.SQLDatabaseEgress.secret:type_name -> v1.pfs.SQLDatabaseEgress.Secret
-	13,  // 82: v1.pfs.EgressRequest.commit:type_name -> v1.pfs.Commit
-	64,  // 83: v1.pfs.EgressRequest.object_storage:type_name -> v1.pfs.ObjectStorageEgress
-	65,  // 84: v1.pfs.EgressRequest.sql_database:type_name -> v1.pfs.SQLDatabaseEgress
-	73,  // 85: v1.pfs.EgressResponse.object_storage:type_name -> v1.pfs.EgressResponse.ObjectStorageResult
-	74,  // 86: v1.pfs.EgressResponse.sql_database:type_name -> v1.pfs.EgressResponse.SQLDatabaseResult
-	80,  // 87: v1.pfs.CommitInfo.Details.compacting_time:type_name -> google.protobuf.Duration
-	80,  // 88: v1.pfs.CommitInfo.Details.validating_time:type_name -> google.protobuf.Duration
-	4,   // 89: v1.pfs.SQLDatabaseEgress.FileFormat.type:type_name -> v1.pfs.SQLDatabaseEgress.FileFormat.Type
-	75,  // 90: v1.pfs.EgressResponse.SQLDatabaseResult.rows_written:type_name -> v1.pfs.EgressResponse.SQLDatabaseResult.RowsWrittenEntry
-	18,  // 91: v1.pfs.API.CreateRepo:input_type -> v1.pfs.CreateRepoRequest
-	19,  // 92: v1.pfs.API.InspectRepo:input_type -> v1.pfs.InspectRepoRequest
-	20,  // 93: v1.pfs.API.ListRepo:input_type -> v1.pfs.ListRepoRequest
-	21,  // 94: v1.pfs.API.DeleteRepo:input_type -> v1.pfs.DeleteRepoRequest
-	22,  // 95: v1.pfs.API.StartCommit:input_type -> v1.pfs.StartCommitRequest
-	23,  // 96: v1.pfs.API.FinishCommit:input_type -> v1.pfs.FinishCommitRequest
-	31,  // 97: v1.pfs.API.ClearCommit:input_type -> v1.pfs.ClearCommitRequest
-	24,  // 98: v1.pfs.API.InspectCommit:input_type -> v1.pfs.InspectCommitRequest
-	25,  // 99: v1.pfs.API.ListCommit:input_type -> v1.pfs.ListCommitRequest
-	30,  // 100: v1.pfs.API.SubscribeCommit:input_type -> v1.pfs.SubscribeCommitRequest
-	26,  // 101: v1.pfs.API.InspectCommitSet:input_type -> v1.pfs.InspectCommitSetRequest
-	27,  // 102: v1.pfs.API.ListCommitSet:input_type -> v1.pfs.ListCommitSetRequest
-	28,  // 103: v1.pfs.API.SquashCommitSet:input_type -> v1.pfs.SquashCommitSetRequest
-	29,  // 104: v1.pfs.API.DropCommitSet:input_type -> v1.pfs.DropCommitSetRequest
-	32,  // 105: v1.pfs.API.CreateBranch:input_type -> v1.pfs.CreateBranchRequest
-	33,  // 106: v1.pfs.API.InspectBranch:input_type -> v1.pfs.InspectBranchRequest
-	34,  // 107: v1.pfs.API.ListBranch:input_type -> v1.pfs.ListBranchRequest
-	35,  // 108: v1.pfs.API.DeleteBranch:input_type -> v1.pfs.DeleteBranchRequest
-	39,  // 109: v1.pfs.API.ModifyFile:input_type -> v1.pfs.ModifyFileRequest
-	40,  // 110: v1.pfs.API.GetFile:input_type -> v1.pfs.GetFileRequest
-	40,  // 111: v1.pfs.API.GetFileTAR:input_type -> v1.pfs.GetFileRequest
-	41,  // 112: v1.pfs.API.InspectFile:input_type -> v1.pfs.InspectFileRequest
-	42,  // 113: v1.pfs.API.ListFile:input_type -> v1.pfs.ListFileRequest
-	43,  // 114: v1.pfs.API.WalkFile:input_type -> v1.pfs.WalkFileRequest
-	44,  // 115: v1.pfs.API.GlobFile:input_type -> v1.pfs.GlobFileRequest
-	45,  // 116: v1.pfs.API.DiffFile:input_type -> v1.pfs.DiffFileRequest
-	60,  // 117: v1.pfs.API.ActivateAuth:input_type -> v1.pfs.ActivateAuthRequest
-	81,  // 118: v1.pfs.API.DeleteAll:input_type -> google.protobuf.Empty
-	47,  // 119: v1.pfs.API.Fsck:input_type -> v1.pfs.FsckRequest
-	39,  // 120: v1.pfs.API.CreateFileSet:input_type -> v1.pfs.ModifyFileRequest
-	50,  // 121: v1.pfs.API.GetFileSet:input_type -> v1.pfs.GetFileSetRequest
-	51,  // 122: v1.pfs.API.AddFileSet:input_type -> v1.pfs.AddFileSetRequest
-	52,  // 123: v1.pfs.API.RenewFileSet:input_type -> v1.pfs.RenewFileSetRequest
-	53,  // 124: v1.pfs.API.ComposeFileSet:input_type -> v1.pfs.ComposeFileSetRequest
-	54,  // 125: v1.pfs.API.CheckStorage:input_type -> v1.pfs.CheckStorageRequest
-	56,  // 126: v1.pfs.API.PutCache:input_type -> v1.pfs.PutCacheRequest
-	57,  // 127: v1.pfs.API.GetCache:input_type -> v1.pfs.GetCacheRequest
-	59,  // 128: v1.pfs.API.ClearCache:input_type -> v1.pfs.ClearCacheRequest
-	62,  // 129: v1.pfs.API.RunLoadTest:input_type -> v1.pfs.RunLoadTestRequest
-	81,  // 130: v1.pfs.API.RunLoadTestDefault:input_type -> google.protobuf.Empty
-	82,  // 131: v1.pfs.API.ListTask:input_type -> v1.task.ListTaskRequest
-	66,  // 132: v1.pfs.API.Egress:input_type -> v1.pfs.EgressRequest
-	81,  // 133: v1.pfs.API.CreateRepo:output_type -> google.protobuf.Empty
-	8,   // 134: v1.pfs.API.InspectRepo:output_type -> v1.pfs.RepoInfo
-	8,   // 135: v1.pfs.API.ListRepo:output_type -> v1.pfs.RepoInfo
-	81,  // 136: v1.pfs.API.DeleteRepo:output_type -> google.protobuf.Empty
-	13,  // 137: v1.pfs.API.StartCommit:output_type -> v1.pfs.Commit
-	81,  // 138: v1.pfs.API.FinishCommit:output_type -> google.protobuf.Empty
-	81,  // 139: v1.pfs.API.ClearCommit:output_type -> google.protobuf.Empty
-	14,  // 140: v1.pfs.API.InspectCommit:output_type -> v1.pfs.CommitInfo
-	14,  // 141: v1.pfs.API.ListCommit:output_type -> v1.pfs.CommitInfo
-	14,  // 142: v1.pfs.API.SubscribeCommit:output_type -> v1.pfs.CommitInfo
-	14,  // 143: v1.pfs.API.InspectCommitSet:output_type -> v1.pfs.CommitInfo
-	16,  // 144: v1.pfs.API.ListCommitSet:output_type -> v1.pfs.CommitSetInfo
-	81,  // 145: v1.pfs.API.SquashCommitSet:output_type -> google.protobuf.Empty
-	81,  // 146: v1.pfs.API.DropCommitSet:output_type -> google.protobuf.Empty
-	81,  // 147: v1.pfs.API.CreateBranch:output_type -> google.protobuf.Empty
-	10,  // 148: v1.pfs.API.InspectBranch:output_type -> v1.pfs.BranchInfo
-	10,  // 149: v1.pfs.API.ListBranch:output_type -> v1.pfs.BranchInfo
-	81,  // 150: v1.pfs.API.DeleteBranch:output_type -> google.protobuf.Empty
-	81,  // 151: v1.pfs.API.ModifyFile:output_type -> google.protobuf.Empty
-	78,  // 152: v1.pfs.API.GetFile:output_type -> google.protobuf.BytesValue
-	78,  // 153: v1.pfs.API.GetFileTAR:output_type -> google.protobuf.BytesValue
-	17,  // 154: v1.pfs.API.InspectFile:output_type -> v1.pfs.FileInfo
-	17,  // 155: v1.pfs.API.ListFile:output_type -> v1.pfs.FileInfo
-	17,  // 156: v1.pfs.API.WalkFile:output_type -> v1.pfs.FileInfo
-	17,  // 157: v1.pfs.API.GlobFile:output_type -> v1.pfs.FileInfo
-	46,  // 158: v1.pfs.API.DiffFile:output_type -> v1.pfs.DiffFileResponse
-	61,  // 159: v1.pfs.API.ActivateAuth:output_type -> v1.pfs.ActivateAuthResponse
-	81,  // 160: v1.pfs.API.DeleteAll:output_type -> google.protobuf.Empty
-	48,  // 161: v1.pfs.API.Fsck:output_type -> v1.pfs.FsckResponse
-	49,  // 162: v1.pfs.API.CreateFileSet:output_type -> v1.pfs.CreateFileSetResponse
-	49,  // 163: v1.pfs.API.GetFileSet:output_type -> v1.pfs.CreateFileSetResponse
-	81,  // 164: v1.pfs.API.AddFileSet:output_type -> google.protobuf.Empty
-	81,  // 165: v1.pfs.API.RenewFileSet:output_type -> google.protobuf.Empty
-	49,  // 166: v1.pfs.API.ComposeFileSet:output_type -> v1.pfs.CreateFileSetResponse
-	55,  // 167: v1.pfs.API.CheckStorage:output_type -> v1.pfs.CheckStorageResponse
-	81,  // 168: v1.pfs.API.PutCache:output_type -> google.protobuf.Empty
-	58,  // 169: v1.pfs.API.GetCache:output_type -> v1.pfs.GetCacheResponse
-	81,  // 170: v1.pfs.API.ClearCache:output_type -> google.protobuf.Empty
-	63,  // 171: v1.pfs.API.RunLoadTest:output_type -> v1.pfs.RunLoadTestResponse
-	63,  // 172: v1.pfs.API.RunLoadTestDefault:output_type -> v1.pfs.RunLoadTestResponse
-	83,  // 173: v1.pfs.API.ListTask:output_type -> v1.task.TaskInfo
-	67,  // 174: v1.pfs.API.Egress:output_type -> v1.pfs.EgressResponse
-	133, // [133:175] is the sub-list for method output_type
-	91,  // [91:133] is the sub-list for method input_type
-	91,  // [91:91] is the sub-list for extension type_name
-	91,  // [91:91] is the sub-list for extension extendee
-	0,   // [0:91] is the sub-list for field type_name
+	81,  // 14: v1.pfs.BranchInfo.details:type_name -> v1.pfs.BranchInfo.Details
+	0,   // 15: v1.pfs.CommitOrigin.kind:type_name -> v1.pfs.OriginKind
+	6,   // 16: v1.pfs.Commit.branch:type_name -> v1.pfs.Branch
+	13,  // 17: v1.pfs.CommitInfo.commit:type_name -> v1.pfs.Commit
+	12,  // 18: v1.pfs.CommitInfo.origin:type_name -> v1.pfs.CommitOrigin
+	13,  // 19: v1.pfs.CommitInfo.parent_commit:type_name -> v1.pfs.Commit
+	13,  // 20: v1.pfs.CommitInfo.child_commits:type_name -> v1.pfs.Commit
+	89,  // 21: v1.pfs.CommitInfo.started:type_name -> google.protobuf.Timestamp
+	89,  // 22: v1.pfs.CommitInfo.finishing:type_name -> google.protobuf.Timestamp
+	89,  // 23: v1.pfs.CommitInfo.finished:type_name -> google.protobuf.Timestamp
+	6,   // 24: v1.pfs.CommitInfo.direct_provenance:type_name -> v1.pfs.Branch
+	82,  // 25: v1.pfs.CommitInfo.details:type_name -> v1.pfs.CommitInfo.Details
+	15,  // 26: v1.pfs.CommitSetInfo.commit_set:type_name -> v1.pfs.CommitSet
+	14,  // 27: v1.pfs.CommitSetInfo.commits:type_name -> v1.pfs.CommitInfo
+	7,   // 28: v1.pfs.FileInfo.file:type_name -> v1.pfs.File
+	1,   // 29: v1.pfs.FileInfo.file_type:type_name -> v1.pfs.FileType
+	89,  // 30: v1.pfs.FileInfo.committed:type_name -> google.protobuf.Timestamp
+	5,   // 31: v1.pfs.CreateRepoRequest.repo:type_name -> v1.pfs.Repo
+	5,   // 32: v1.pfs.InspectRepoRequest.repo:type_name -> v1.pfs.Repo
+	5,   // 33: v1.pfs.DeleteRepoRequest.repo:type_name -> v1.pfs.Repo
+	13,  // 34: v1.pfs.StartCommitRequest.parent:type_name -> v1.pfs.Commit
+	6,   // 35: v1.pfs.StartCommitRequest.branch:type_name -> v1.pfs.Branch
+	13,  // 36: v1.pfs.FinishCommitRequest.commit:type_name -> v1.pfs.Commit
+	13,  // 37: v1.pfs.InspectCommitRequest.commit:type_name -> v1.pfs.Commit
+	2,   // 38: v1.pfs.InspectCommitRequest.wait:type_name -> v1.pfs.CommitState
+	5,   // 39: v1.pfs.ListCommitRequest.repo:type_name -> v1.pfs.Repo
+	13,  // 40: v1.pfs.ListCommitRequest.from:type_name -> v1.pfs.Commit
+	13,  // 41: v1.pfs.ListCommitRequest.to:type_name -> v1.pfs.Commit
+	0,   // 42: v1.pfs.ListCommitRequest.origin_kind:type_name -> v1.pfs.OriginKind
+	15,  // 43: v1.pfs.InspectCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
+	15,  // 44: v1.pfs.SquashCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
+	15,  // 45: v1.pfs.DropCommitSetRequest.commit_set:type_name -> v1.pfs.CommitSet
+	5,   // 46: v1.pfs.SubscribeCommitRequest.repo:type_name -> v1.pfs.Repo
+	13,  // 47: v1.pfs.SubscribeCommitRequest.from:type_name -> v1.pfs.Commit
+	2,   // 48: v1.pfs.SubscribeCommitRequest.state:type_name -> v1.pfs.CommitState
+	0,   // 49: v1.pfs.SubscribeCommitRequest.origin_kind:type_name -> v1.pfs.OriginKind
+	13,  // 50: v1.pfs.ClearCommitRequest.commit:type_name -> v1.pfs.Commit
+	13,  // 51: v1.pfs.CreateBranchRequest.head:type_name -> v1.pfs.Commit
+	6,   // 52: v1.pfs.CreateBranchRequest.branch:type_name -> v1.pfs.Branch
+	6,   // 53: v1.pfs.CreateBranchRequest.provenance:type_name -> v1.pfs.Branch
+	11,  // 54: v1.pfs.CreateBranchRequest.trigger:type_name -> v1.pfs.Trigger
+	6,   // 55: v1.pfs.InspectBranchRequest.branch:type_name -> v1.pfs.Branch
+	5,   // 56: v1.pfs.ListBranchRequest.repo:type_name -> v1.pfs.Repo
+	6,   // 57: v1.pfs.DeleteBranchRequest.branch:type_name -> v1.pfs.Branch
+	91,  // 58: v1.pfs.AddFile.raw:type_name -> google.protobuf.BytesValue
+	83,  // 59: v1.pfs.AddFile.url:type_name -> v1.pfs.AddFile.URLSource
+	7,   // 60: v1.pfs.CopyFile.src:type_name -> v1.pfs.File
+	13,  // 61: v1.pfs.ModifyFileRequest.set_commit:type_name -> v1.pfs.Commit
+	36,  // 62: v1.pfs.ModifyFileRequest.add_file:type_name -> v1.pfs.AddFile
+	37,  // 63: v1.pfs.ModifyFileRequest.delete_file:type_name -> v1.pfs.DeleteFile
+	38,  // 64: v1.pfs.ModifyFileRequest.copy_file:type_name -> v1.pfs.CopyFile
+	7,   // 65: v1.pfs.GetFileRequest.file:type_name -> v1.pfs.File
+	40,  // 66: v1.pfs.GetFileRequest.ranges:type_name -> v1.pfs.ByteRange
+	7,   // 67: v1.pfs.InspectFileRequest.file:type_name -> v1.pfs.File
+	7,   // 68: v1.pfs.ListFileRequest.file:type_name -> v1.pfs.File
+	7,   // 69: v1.pfs.WalkFileRequest.file:type_name -> v1.pfs.File
+	13,  // 70: v1.pfs.GlobFileRequest.commit:type_name -> v1.pfs.Commit
+	7,   // 71: v1.pfs.DiffFileRequest.new_file:type_name -> v1.pfs.File
+	7,   // 72: v1.pfs.DiffFileRequest.old_file:type_name -> v1.pfs.File
+	17,  // 73: v1.pfs.DiffFileResponse.new_file:type_name -> v1.pfs.FileInfo
+	17,  // 74: v1.pfs.DiffFileResponse.old_file:type_name -> v1.pfs.FileInfo
+	7,   // 75: v1.pfs.StartUploadRequest.file:type_name -> v1.pfs.File
+	7,   // 76: v1.pfs.UploadInfo.file:type_name -> v1.pfs.File
+	89,  // 77: v1.pfs.UploadInfo.started:type_name -> google.protobuf.Timestamp
+	50,  // 78: v1.pfs.PutUploadPartRequest.header:type_name -> v1.pfs.UploadPartHeader
+	91,  // 79: v1.pfs.PutUploadPartRequest.data:type_name -> google.protobuf.BytesValue
+	13,  // 80: v1.pfs.GetFileSetRequest.commit:type_name -> v1.pfs.Commit
+	13,  // 81: v1.pfs.AddFileSetRequest.commit:type_name -> v1.pfs.Commit
+	92,  // 82: v1.pfs.PutCacheRequest.value:type_name -> google.protobuf.Any
+	92,  // 83: v1.pfs.GetCacheResponse.value:type_name -> google.protobuf.Any
+	6,   // 84: v1.pfs.RunLoadTestRequest.branch:type_name -> v1.pfs.Branch
+	6,   // 85: v1.pfs.RunLoadTestResponse.branch:type_name -> v1.pfs.Branch
+	93,  // 86: v1.pfs.RunLoadTestResponse.duration:type_name -> google.protobuf.Duration
+	72,  // 87: v1.pfs.RunLoadTestResponse.stats:type_name -> v1.pfs.LoadTestStats
+	93,  // 88: v1.pfs.LoadTestStats.p50:type_name -> google.protobuf.Duration
+	93,  // 89: v1.pfs.LoadTestStats.p90:type_name -> google.protobuf.Duration
+	93,  // 90: v1.pfs.LoadTestStats.p99:type_name -> google.protobuf.Duration
+	93,  // 91: v1.pfs.LoadTestStats.max:type_name -> google.protobuf.Duration
+	84,  // 92: v1.pfs.SQLDatabaseEgress.file_format:type_name -> v1.pfs.SQLDatabaseEgress.FileFormat
+	85,  // 93: v1.pfs.SQLDatabaseEgress.secret:type_name -> v1.pfs.SQLDatabaseEgress.Secret
+	85,  // 94: v1.pfs.SQLIngest.secret:type_name -> v1.pfs.SQLDatabaseEgress.Secret
+	3,   // 95: v1.pfs.SQLIngest.delimiter:type_name -> v1.pfs.Delimiter
+	6,   // 96: v1.pfs.IngestSQLRequest.branch:type_name -> v1.pfs.Branch
+	75,  // 97: v1.pfs.IngestSQLRequest.sql:type_name -> v1.pfs.SQLIngest
+	13,  // 98: v1.pfs.IngestSQLResponse.commit:type_name -> v1.pfs.Commit
+	13,  // 99: v1.pfs.EgressRequest.commit:type_name -> v1.pfs.Commit
+	73,  // 100: v1.pfs.EgressRequest.object_storage:type_name -> v1.pfs.ObjectStorageEgress
+	74,  // 101: v1.pfs.EgressRequest.sql_database:type_name -> v1.pfs.SQLDatabaseEgress
+	86,  // 102: v1.pfs.EgressResponse.object_storage:type_name -> v1.pfs.EgressResponse.ObjectStorageResult
+	87,  // 103: v1.pfs.EgressResponse.sql_database:type_name -> v1.pfs.EgressResponse.SQLDatabaseResult
+	93,  // 104: v1.pfs.CommitInfo.Details.compacting_time:type_name -> google.protobuf.Duration
+	93,  // 105: v1.pfs.CommitInfo.Details.validating_time:type_name -> google.protobuf.Duration
+	4,   // 106: v1.pfs.SQLDatabaseEgress.FileFormat.type:type_name -> v1.pfs.SQLDatabaseEgress.FileFormat.Type
+	88,  // 107: v1.pfs.EgressResponse.SQLDatabaseResult.rows_written:type_name -> v1.pfs.EgressResponse.SQLDatabaseResult.RowsWrittenEntry
+	18,  // 108: v1.pfs.API.CreateRepo:input_type -> v1.pfs.CreateRepoRequest
+	19,  // 109: v1.pfs.API.InspectRepo:input_type -> v1.pfs.InspectRepoRequest
+	20,  // 110: v1.pfs.API.ListRepo:input_type -> v1.pfs.ListRepoRequest
+	21,  // 111: v1.pfs.API.DeleteRepo:input_type -> v1.pfs.DeleteRepoRequest
+	22,  // 112: v1.pfs.API.StartCommit:input_type -> v1.pfs.StartCommitRequest
+	23,  // 113: v1.pfs.API.FinishCommit:input_type -> v1.pfs.FinishCommitRequest
+	31,  // 114: v1.pfs.API.ClearCommit:input_type -> v1.pfs.ClearCommitRequest
+	24,  // 115: v1.pfs.API.InspectCommit:input_type -> v1.pfs.InspectCommitRequest
+	25,  // 116: v1.pfs.API.ListCommit:input_type -> v1.pfs.ListCommitRequest
+	30,  // 117: v1.pfs.API.SubscribeCommit:input_type -> v1.pfs.SubscribeCommitRequest
+	26,  // 118: v1.pfs.API.InspectCommitSet:input_type -> v1.pfs.InspectCommitSetRequest
+	27,  // 119: v1.pfs.API.ListCommitSet:input_type -> v1.pfs.ListCommitSetRequest
+	28,  // 120: v1.pfs.API.SquashCommitSet:input_type -> v1.pfs.SquashCommitSetRequest
+	29,  // 121: v1.pfs.API.DropCommitSet:input_type -> v1.pfs.DropCommitSetRequest
+	32,  // 122: v1.pfs.API.CreateBranch:input_type -> v1.pfs.CreateBranchRequest
+	33,  // 123: v1.pfs.API.InspectBranch:input_type -> v1.pfs.InspectBranchRequest
+	34,  // 124: v1.pfs.API.ListBranch:input_type -> v1.pfs.ListBranchRequest
+	35,  // 125: v1.pfs.API.DeleteBranch:input_type -> v1.pfs.DeleteBranchRequest
+	39,  // 126: v1.pfs.API.ModifyFile:input_type -> v1.pfs.ModifyFileRequest
+	41,  // 127: v1.pfs.API.GetFile:input_type -> v1.pfs.GetFileRequest
+	41,  // 128: v1.pfs.API.GetFileTAR:input_type -> v1.pfs.GetFileRequest
+	42,  // 129: v1.pfs.API.InspectFile:input_type -> v1.pfs.InspectFileRequest
+	43,  // 130: v1.pfs.API.ListFile:input_type -> v1.pfs.ListFileRequest
+	44,  // 131: v1.pfs.API.WalkFile:input_type -> v1.pfs.WalkFileRequest
+	45,  // 132: v1.pfs.API.GlobFile:input_type -> v1.pfs.GlobFileRequest
+	46,  // 133: v1.pfs.API.DiffFile:input_type -> v1.pfs.DiffFileRequest
+	48,  // 134: v1.pfs.API.StartUpload:input_type -> v1.pfs.StartUploadRequest
+	51,  // 135: v1.pfs.API.PutUploadPart:input_type -> v1.pfs.PutUploadPartRequest
+	52,  // 136: v1.pfs.API.InspectUpload:input_type -> v1.pfs.InspectUploadRequest
+	53,  // 137: v1.pfs.API.FinishUpload:input_type -> v1.pfs.FinishUploadRequest
+	54,  // 138: v1.pfs.API.AbortUpload:input_type -> v1.pfs.AbortUploadRequest
+	68,  // 139: v1.pfs.API.ActivateAuth:input_type -> v1.pfs.ActivateAuthRequest
+	94,  // 140: v1.pfs.API.DeleteAll:input_type -> google.protobuf.Empty
+	55,  // 141: v1.pfs.API.Fsck:input_type -> v1.pfs.FsckRequest
+	39,  // 142: v1.pfs.API.CreateFileSet:input_type -> v1.pfs.ModifyFileRequest
+	58,  // 143: v1.pfs.API.GetFileSet:input_type -> v1.pfs.GetFileSetRequest
+	59,  // 144: v1.pfs.API.AddFileSet:input_type -> v1.pfs.AddFileSetRequest
+	60,  // 145: v1.pfs.API.RenewFileSet:input_type -> v1.pfs.RenewFileSetRequest
+	61,  // 146: v1.pfs.API.ComposeFileSet:input_type -> v1.pfs.ComposeFileSetRequest
+	62,  // 147: v1.pfs.API.CheckStorage:input_type -> v1.pfs.CheckStorageRequest
+	64,  // 148: v1.pfs.API.PutCache:input_type -> v1.pfs.PutCacheRequest
+	65,  // 149: v1.pfs.API.GetCache:input_type -> v1.pfs.GetCacheRequest
+	67,  // 150: v1.pfs.API.ClearCache:input_type -> v1.pfs.ClearCacheRequest
+	70,  // 151: v1.pfs.API.RunLoadTest:input_type -> v1.pfs.RunLoadTestRequest
+	94,  // 152: v1.pfs.API.RunLoadTestDefault:input_type -> google.protobuf.Empty
+	95,  // 153: v1.pfs.API.ListTask:input_type -> v1.task.ListTaskRequest
+	78,  // 154: v1.pfs.API.Egress:input_type -> v1.pfs.EgressRequest
+	76,  // 155: v1.pfs.API.IngestSQL:input_type -> v1.pfs.IngestSQLRequest
+	94,  // 156: v1.pfs.API.CreateRepo:output_type -> google.protobuf.Empty
+	8,   // 157: v1.pfs.API.InspectRepo:output_type -> v1.pfs.RepoInfo
+	8,   // 158: v1.pfs.API.ListRepo:output_type -> v1.pfs.RepoInfo
+	94,  // 159: v1.pfs.API.DeleteRepo:output_type -> google.protobuf.Empty
+	13,  // 160: v1.pfs.API.StartCommit:output_type -> v1.pfs.Commit
+	94,  // 161: v1.pfs.API.FinishCommit:output_type -> google.protobuf.Empty
+	94,  // 162: v1.pfs.API.ClearCommit:output_type -> google.protobuf.Empty
+	14,  // 163: v1.pfs.API.InspectCommit:output_type -> v1.pfs.CommitInfo
+	14,  // 164: v1.pfs.API.ListCommit:output_type -> v1.pfs.CommitInfo
+	14,  // 165: v1.pfs.API.SubscribeCommit:output_type -> v1.pfs.CommitInfo
+	14,  // 166: v1.pfs.API.InspectCommitSet:output_type -> v1.pfs.CommitInfo
+	16,  // 167: v1.pfs.API.ListCommitSet:output_type -> v1.pfs.CommitSetInfo
+	94,  // 168: v1.pfs.API.SquashCommitSet:output_type -> google.protobuf.Empty
+	94,  // 169: v1.pfs.API.DropCommitSet:output_type -> google.protobuf.Empty
+	94,  // 170: v1.pfs.API.CreateBranch:output_type -> google.protobuf.Empty
+	10,  // 171: v1.pfs.API.InspectBranch:output_type -> v1.pfs.BranchInfo
+	10,  // 172: v1.pfs.API.ListBranch:output_type -> v1.pfs.BranchInfo
+	94,  // 173: v1.pfs.API.DeleteBranch:output_type -> google.protobuf.Empty
+	94,  // 174: v1.pfs.API.ModifyFile:output_type -> google.protobuf.Empty
+	91,  // 175: v1.pfs.API.GetFile:output_type -> google.protobuf.BytesValue
+	91,  // 176: v1.pfs.API.GetFileTAR:output_type -> google.protobuf.BytesValue
+	17,  // 177: v1.pfs.API.InspectFile:output_type -> v1.pfs.FileInfo
+	17,  // 178: v1.pfs.API.ListFile:output_type -> v1.pfs.FileInfo
+	17,  // 179: v1.pfs.API.WalkFile:output_type -> v1.pfs.FileInfo
+	17,  // 180: v1.pfs.API.GlobFile:output_type -> v1.pfs.FileInfo
+	47,  // 181: v1.pfs.API.DiffFile:output_type -> v1.pfs.DiffFileResponse
+	49,  // 182: v1.pfs.API.StartUpload:output_type -> v1.pfs.UploadInfo
+	49,  // 183: v1.pfs.API.PutUploadPart:output_type -> v1.pfs.UploadInfo
+	49,  // 184: v1.pfs.API.InspectUpload:output_type -> v1.pfs.UploadInfo
+	94,  // 185: v1.pfs.API.FinishUpload:output_type -> google.protobuf.Empty
+	94,  // 186: v1.pfs.API.AbortUpload:output_type -> google.protobuf.Empty
+	69,  // 187: v1.pfs.API.ActivateAuth:output_type -> v1.pfs.ActivateAuthResponse
+	94,  // 188: v1.pfs.API.DeleteAll:output_type -> google.protobuf.Empty
+	56,  // 189: v1.pfs.API.Fsck:output_type -> v1.pfs.FsckResponse
+	57,  // 190: v1.pfs.API.CreateFileSet:output_type -> v1.pfs.CreateFileSetResponse
+	57,  // 191: v1.pfs.API.GetFileSet:output_type -> v1.pfs.CreateFileSetResponse
+	94,  // 192: v1.pfs.API.AddFileSet:output_type -> google.protobuf.Empty
+	94,  // 193: v1.pfs.API.RenewFileSet:output_type -> google.protobuf.Empty
+	57,  // 194: v1.pfs.API.ComposeFileSet:output_type -> v1.pfs.CreateFileSetResponse
+	63,  // 195: v1.pfs.API.CheckStorage:output_type -> v1.pfs.CheckStorageResponse
+	94,  // 196: v1.pfs.API.PutCache:output_type -> google.protobuf.Empty
+	66,  // 197: v1.pfs.API.GetCache:output_type -> v1.pfs.GetCacheResponse
+	94,  // 198: v1.pfs.API.ClearCache:output_type -> google.protobuf.Empty
+	71,  // 199: v1.pfs.API.RunLoadTest:output_type -> v1.pfs.RunLoadTestResponse
+	71,  // 200: v1.pfs.API.RunLoadTestDefault:output_type -> v1.pfs.RunLoadTestResponse
+	96,  // 201: v1.pfs.API.ListTask:output_type -> v1.task.TaskInfo
+	79,  // 202: v1.pfs.API.Egress:output_type -> v1.pfs.EgressResponse
+	77,  // 203: v1.pfs.API.IngestSQL:output_type -> v1.pfs.IngestSQLResponse
+	156, // [156:204] is the sub-list for method output_type
+	108, // [108:156] is the sub-list for method input_type
+	108, // [108:108] is the sub-list for extension type_name
+	108, // [108:108] is the sub-list for extension extendee
+	0,   // [0:108] is the sub-list for field type_name
 }
 
 func init() { file_pkg_api_v1_pfs_pfs_proto_init() }
@@ -6000,7 +7114,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFileRequest); i {
+			switch v := v.(*ByteRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6012,7 +7126,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InspectFileRequest); i {
+			switch v := v.(*GetFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6024,7 +7138,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListFileRequest); i {
+			switch v := v.(*InspectFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6036,7 +7150,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WalkFileRequest); i {
+			switch v := v.(*ListFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6048,7 +7162,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GlobFileRequest); i {
+			switch v := v.(*WalkFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6060,7 +7174,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiffFileRequest); i {
+			switch v := v.(*GlobFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6072,7 +7186,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DiffFileResponse); i {
+			switch v := v.(*DiffFileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6084,7 +7198,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FsckRequest); i {
+			switch v := v.(*DiffFileResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6096,7 +7210,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*FsckResponse); i {
+			switch v := v.(*StartUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6108,7 +7222,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateFileSetResponse); i {
+			switch v := v.(*UploadInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6120,7 +7234,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetFileSetRequest); i {
+			switch v := v.(*UploadPartHeader); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6132,7 +7246,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddFileSetRequest); i {
+			switch v := v.(*PutUploadPartRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6144,7 +7258,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RenewFileSetRequest); i {
+			switch v := v.(*InspectUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6156,7 +7270,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ComposeFileSetRequest); i {
+			switch v := v.(*FinishUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6168,7 +7282,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckStorageRequest); i {
+			switch v := v.(*AbortUploadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6180,7 +7294,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CheckStorageResponse); i {
+			switch v := v.(*FsckRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6192,7 +7306,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PutCacheRequest); i {
+			switch v := v.(*FsckResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6204,7 +7318,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCacheRequest); i {
+			switch v := v.(*CreateFileSetResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6216,7 +7330,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetCacheResponse); i {
+			switch v := v.(*GetFileSetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6228,7 +7342,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ClearCacheRequest); i {
+			switch v := v.(*AddFileSetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6240,7 +7354,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActivateAuthRequest); i {
+			switch v := v.(*RenewFileSetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6252,7 +7366,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActivateAuthResponse); i {
+			switch v := v.(*ComposeFileSetRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6264,7 +7378,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunLoadTestRequest); i {
+			switch v := v.(*CheckStorageRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6276,7 +7390,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RunLoadTestResponse); i {
+			switch v := v.(*CheckStorageResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6288,7 +7402,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ObjectStorageEgress); i {
+			switch v := v.(*PutCacheRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6300,7 +7414,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLDatabaseEgress); i {
+			switch v := v.(*GetCacheRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6312,7 +7426,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[61].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EgressRequest); i {
+			switch v := v.(*GetCacheResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6324,7 +7438,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[62].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EgressResponse); i {
+			switch v := v.(*ClearCacheRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6336,7 +7450,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[63].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RepoInfo_Details); i {
+			switch v := v.(*ActivateAuthRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6348,7 +7462,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[64].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitInfo_Details); i {
+			switch v := v.(*ActivateAuthResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6360,7 +7474,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[65].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AddFile_URLSource); i {
+			switch v := v.(*RunLoadTestRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6372,7 +7486,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[66].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLDatabaseEgress_FileFormat); i {
+			switch v := v.(*RunLoadTestResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6384,7 +7498,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[67].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SQLDatabaseEgress_Secret); i {
+			switch v := v.(*LoadTestStats); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6396,7 +7510,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[68].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*EgressResponse_ObjectStorageResult); i {
+			switch v := v.(*ObjectStorageEgress); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -6408,6 +7522,162 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			}
 		}
 		file_pkg_api_v1_pfs_pfs_proto_msgTypes[69].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLDatabaseEgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[70].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLIngest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[71].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestSQLRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[72].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IngestSQLResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[73].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[74].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[75].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RepoInfo_Details); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[76].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BranchInfo_Details); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[77].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitInfo_Details); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[78].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddFile_URLSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[79].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLDatabaseEgress_FileFormat); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[80].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SQLDatabaseEgress_Secret); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[81].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EgressResponse_ObjectStorageResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_pkg_api_v1_pfs_pfs_proto_msgTypes[82].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*EgressResponse_SQLDatabaseResult); i {
 			case 0:
 				return &v.state
@@ -6430,11 +7700,15 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 		(*ModifyFileRequest_DeleteFile)(nil),
 		(*ModifyFileRequest_CopyFile)(nil),
 	}
-	file_pkg_api_v1_pfs_pfs_proto_msgTypes[61].OneofWrappers = []interface{}{
+	file_pkg_api_v1_pfs_pfs_proto_msgTypes[46].OneofWrappers = []interface{}{
+		(*PutUploadPartRequest_Header)(nil),
+		(*PutUploadPartRequest_Data)(nil),
+	}
+	file_pkg_api_v1_pfs_pfs_proto_msgTypes[73].OneofWrappers = []interface{}{
 		(*EgressRequest_ObjectStorage)(nil),
 		(*EgressRequest_SqlDatabase)(nil),
 	}
-	file_pkg_api_v1_pfs_pfs_proto_msgTypes[62].OneofWrappers = []interface{}{
+	file_pkg_api_v1_pfs_pfs_proto_msgTypes[74].OneofWrappers = []interface{}{
 		(*EgressResponse_ObjectStorage)(nil),
 		(*EgressResponse_SqlDatabase)(nil),
 	}
@@ -6444,7 +7718,7 @@ func file_pkg_api_v1_pfs_pfs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_pkg_api_v1_pfs_pfs_proto_rawDesc,
 			NumEnums:      5,
-			NumMessages:   71,
+			NumMessages:   84,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -73,6 +73,16 @@ type APIClient interface {
 	GlobFile(ctx context.Context, in *GlobFileRequest, opts ...grpc.CallOption) (API_GlobFileClient, error)
 	// DiffFile returns the differences between 2 paths at 2 commits.
 	DiffFile(ctx context.Context, in *DiffFileRequest, opts ...grpc.CallOption) (API_DiffFileClient, error)
+	// StartUpload starts a resumable upload session for a file.
+	StartUpload(ctx context.Context, in *StartUploadRequest, opts ...grpc.CallOption) (*UploadInfo, error)
+	// PutUploadPart sends the next part of an upload.
+	PutUploadPart(ctx context.Context, opts ...grpc.CallOption) (API_PutUploadPartClient, error)
+	// InspectUpload returns how much of an upload has been received.
+	InspectUpload(ctx context.Context, in *InspectUploadRequest, opts ...grpc.CallOption) (*UploadInfo, error)
+	// FinishUpload atomically writes a complete upload's file into its commit.
+	FinishUpload(ctx context.Context, in *FinishUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// AbortUpload discards an upload.
+	AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// ActivateAuth creates a role binding for all existing repos
 	ActivateAuth(ctx context.Context, in *ActivateAuthRequest, opts ...grpc.CallOption) (*ActivateAuthResponse, error)
 	// DeleteAll deletes everything.
@@ -103,6 +113,8 @@ type APIClient interface {
 	ListTask(ctx context.Context, in *task.ListTaskRequest, opts ...grpc.CallOption) (API_ListTaskClient, error)
 	// Egress writes data from a commit to an external system
 	Egress(ctx context.Context, in *EgressRequest, opts ...grpc.CallOption) (*EgressResponse, error)
+	// IngestSQL commits a snapshot of the result of an SQL query to a branch
+	IngestSQL(ctx context.Context, in *IngestSQLRequest, opts ...grpc.CallOption) (*IngestSQLResponse, error)
 }
 
 type aPIClient struct {
@@ -648,6 +660,76 @@ func (x *aPIDiffFileClient) Recv() (*DiffFileResponse, error) {
 	return m, nil
 }
 
+func (c *aPIClient) StartUpload(ctx context.Context, in *StartUploadRequest, opts ...grpc.CallOption) (*UploadInfo, error) {
+	out := new(UploadInfo)
+	err := c.cc.Invoke(ctx, "/v1.pfs.API/StartUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) PutUploadPart(ctx context.Context, opts ...grpc.CallOption) (API_PutUploadPartClient, error) {
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[13], "/v1.pfs.API/PutUploadPart", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &aPIPutUploadPartClient{stream}
+	return x, nil
+}
+
+type API_PutUploadPartClient interface {
+	Send(*PutUploadPartRequest) error
+	CloseAndRecv() (*UploadInfo, error)
+	grpc.ClientStream
+}
+
+type aPIPutUploadPartClient struct {
+	grpc.ClientStream
+}
+
+func (x *aPIPutUploadPartClient) Send(m *PutUploadPartRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *aPIPutUploadPartClient) CloseAndRecv() (*UploadInfo, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *aPIClient) InspectUpload(ctx context.Context, in *InspectUploadRequest, opts ...grpc.CallOption) (*UploadInfo, error) {
+	out := new(UploadInfo)
+	err := c.cc.Invoke(ctx, "/v1.pfs.API/InspectUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) FinishUpload(ctx context.Context, in *FinishUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.pfs.API/FinishUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *aPIClient) AbortUpload(ctx context.Context, in *AbortUploadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/v1.pfs.API/AbortUpload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *aPIClient) ActivateAuth(ctx context.Context, in *ActivateAuthRequest, opts ...grpc.CallOption) (*ActivateAuthResponse, error) {
 	out := new(ActivateAuthResponse)
 	err := c.cc.Invoke(ctx, "/v1.pfs.API/ActivateAuth", in, out, opts...)
@@ -667,7 +749,7 @@ func (c *aPIClient) DeleteAll(ctx context.Context, in *emptypb.Empty, opts ...gr
 }
 
 func (c *aPIClient) Fsck(ctx context.Context, in *FsckRequest, opts ...grpc.CallOption) (API_FsckClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[13], "/v1.pfs.API/Fsck", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[14], "/v1.pfs.API/Fsck", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -699,7 +781,7 @@ func (x *aPIFsckClient) Recv() (*FsckResponse, error) {
 }
 
 func (c *aPIClient) CreateFileSet(ctx context.Context, opts ...grpc.CallOption) (API_CreateFileSetClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[14], "/v1.pfs.API/CreateFileSet", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[15], "/v1.pfs.API/CreateFileSet", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -823,7 +905,7 @@ func (c *aPIClient) RunLoadTestDefault(ctx context.Context, in *emptypb.Empty, o
 }
 
 func (c *aPIClient) ListTask(ctx context.Context, in *task.ListTaskRequest, opts ...grpc.CallOption) (API_ListTaskClient, error) {
-	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[15], "/v1.pfs.API/ListTask", opts...)
+	stream, err := c.cc.NewStream(ctx, &API_ServiceDesc.Streams[16], "/v1.pfs.API/ListTask", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -863,6 +945,15 @@ func (c *aPIClient) Egress(ctx context.Context, in *EgressRequest, opts ...grpc.
 	return out, nil
 }
 
+func (c *aPIClient) IngestSQL(ctx context.Context, in *IngestSQLRequest, opts ...grpc.CallOption) (*IngestSQLResponse, error) {
+	out := new(IngestSQLResponse)
+	err := c.cc.Invoke(ctx, "/v1.pfs.API/IngestSQL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // APIServer is the server API for API service.
 // All implementations must embed UnimplementedAPIServer
 // for forward compatibility
@@ -919,6 +1010,16 @@ type APIServer interface {
 	GlobFile(*GlobFileRequest, API_GlobFileServer) error
 	// DiffFile returns the differences between 2 paths at 2 commits.
 	DiffFile(*DiffFileRequest, API_DiffFileServer) error
+	// StartUpload starts a resumable upload session for a file.
+	StartUpload(context.Context, *StartUploadRequest) (*UploadInfo, error)
+	// PutUploadPart sends the next part of an upload.
+	PutUploadPart(API_PutUploadPartServer) error
+	// InspectUpload returns how much of an upload has been received.
+	InspectUpload(context.Context, *InspectUploadRequest) (*UploadInfo, error)
+	// FinishUpload atomically writes a complete upload's file into its commit.
+	FinishUpload(context.Context, *FinishUploadRequest) (*emptypb.Empty, error)
+	// AbortUpload discards an upload.
+	AbortUpload(context.Context, *AbortUploadRequest) (*emptypb.Empty, error)
 	// ActivateAuth creates a role binding for all existing repos
 	ActivateAuth(context.Context, *ActivateAuthRequest) (*ActivateAuthResponse, error)
 	// DeleteAll deletes everything.
@@ -949,6 +1050,8 @@ type APIServer interface {
 	ListTask(*task.ListTaskRequest, API_ListTaskServer) error
 	// Egress writes data from a commit to an external system
 	Egress(context.Context, *EgressRequest) (*EgressResponse, error)
+	// IngestSQL commits a snapshot of the result of an SQL query to a branch
+	IngestSQL(context.Context, *IngestSQLRequest) (*IngestSQLResponse, error)
 	mustEmbedUnimplementedAPIServer()
 }
 
@@ -1034,6 +1137,21 @@ func (UnimplementedAPIServer) GlobFile(*GlobFileRequest, API_GlobFileServer) err
 func (UnimplementedAPIServer) DiffFile(*DiffFileRequest, API_DiffFileServer) error {
 	return status.Errorf(codes.Unimplemented, "method DiffFile not implemented")
 }
+func (UnimplementedAPIServer) StartUpload(context.Context, *StartUploadRequest) (*UploadInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartUpload not implemented")
+}
+func (UnimplementedAPIServer) PutUploadPart(API_PutUploadPartServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutUploadPart not implemented")
+}
+func (UnimplementedAPIServer) InspectUpload(context.Context, *InspectUploadRequest) (*UploadInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectUpload not implemented")
+}
+func (UnimplementedAPIServer) FinishUpload(context.Context, *FinishUploadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishUpload not implemented")
+}
+func (UnimplementedAPIServer) AbortUpload(context.Context, *AbortUploadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AbortUpload not implemented")
+}
 func (UnimplementedAPIServer) ActivateAuth(context.Context, *ActivateAuthRequest) (*ActivateAuthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ActivateAuth not implemented")
 }
@@ -1082,6 +1200,9 @@ func (UnimplementedAPIServer) ListTask(*task.ListTaskRequest, API_ListTaskServer
 func (UnimplementedAPIServer) Egress(context.Context, *EgressRequest) (*EgressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Egress not implemented")
 }
+func (UnimplementedAPIServer) IngestSQL(context.Context, *IngestSQLRequest) (*IngestSQLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IngestSQL not implemented")
+}
 func (UnimplementedAPIServer) mustEmbedUnimplementedAPIServer() {}
 
 // UnsafeAPIServer may be embedded to opt out of forward compatibility for this service.
@@ -1607,6 +1728,104 @@ func (x *aPIDiffFileServer) Send(m *DiffFileResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _API_StartUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).StartUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.pfs.API/StartUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).StartUpload(ctx, req.(*StartUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_PutUploadPart_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(APIServer).PutUploadPart(&aPIPutUploadPartServer{stream})
+}
+
+type API_PutUploadPartServer interface {
+	SendAndClose(*UploadInfo) error
+	Recv() (*PutUploadPartRequest, error)
+	grpc.ServerStream
+}
+
+type aPIPutUploadPartServer struct {
+	grpc.ServerStream
+}
+
+func (x *aPIPutUploadPartServer) SendAndClose(m *UploadInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *aPIPutUploadPartServer) Recv() (*PutUploadPartRequest, error) {
+	m := new(PutUploadPartRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _API_InspectUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).InspectUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.pfs.API/InspectUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).InspectUpload(ctx, req.(*InspectUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_FinishUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).FinishUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.pfs.API/FinishUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).FinishUpload(ctx, req.(*FinishUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _API_AbortUpload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortUploadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).AbortUpload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.pfs.API/AbortUpload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).AbortUpload(ctx, req.(*AbortUploadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _API_ActivateAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ActivateAuthRequest)
 	if err := dec(in); err != nil {
@@ -1909,6 +2128,24 @@ func _API_Egress_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _API_IngestSQL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IngestSQLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(APIServer).IngestSQL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v1.pfs.API/IngestSQL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(APIServer).IngestSQL(ctx, req.(*IngestSQLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // API_ServiceDesc is the grpc.ServiceDesc for API service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1968,6 +2205,22 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "InspectFile",
 			Handler:    _API_InspectFile_Handler,
 		},
+		{
+			MethodName: "StartUpload",
+			Handler:    _API_StartUpload_Handler,
+		},
+		{
+			MethodName: "InspectUpload",
+			Handler:    _API_InspectUpload_Handler,
+		},
+		{
+			MethodName: "FinishUpload",
+			Handler:    _API_FinishUpload_Handler,
+		},
+		{
+			MethodName: "AbortUpload",
+			Handler:    _API_AbortUpload_Handler,
+		},
 		{
 			MethodName: "ActivateAuth",
 			Handler:    _API_ActivateAuth_Handler,
@@ -2020,6 +2273,10 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Egress",
 			Handler:    _API_Egress_Handler,
 		},
+		{
+			MethodName: "IngestSQL",
+			Handler:    _API_IngestSQL_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -2087,6 +2344,11 @@ var API_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _API_DiffFile_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "PutUploadPart",
+			Handler:       _API_PutUploadPart_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "Fsck",
 			Handler:       _API_Fsck_Handler,
@@ -0,0 +1,52 @@
+package pfs
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestParseFile(t *testing.T) {
+	for s, expected := range map[string]string{
+		"data":                  "data@master=:/",
+		"data@dev":              "data@dev=:/",
+		"data@dev=abc:/a/b":     "data@dev=abc:/a/b",
+		"data=abc:/a":           "data@=abc:/a",
+		"data.meta@master^2:/a": "data.meta@master^2=:/a",
+	} {
+		f, err := ParseFile(s)
+		require.NoError(t, err, s)
+		require.Equal(t, expected, f.Commit.Key()+":"+f.Path)
+	}
+	for _, s := range []string{"", "@master", "data@", "data@=:/a", ".meta"} {
+		_, err := ParseFile(s)
+		require.YesError(t, err, s)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	commit := (&Repo{Name: "data", Type: MetaRepoType}).NewCommit("dev", "abc")
+	require.Equal(t, "commit data.meta@dev=abc of branch data.meta@dev", fmt.Sprintf("commit %v of branch %s", commit, commit.Branch))
+	require.Equal(t, `"data.meta"`, fmt.Sprintf("%q", commit.Branch.Repo))
+}
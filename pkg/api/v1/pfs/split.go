@@ -0,0 +1,68 @@
+package pfs
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+	"github.com/bhojpur/data/pkg/internal/split"
+)
+
+// PutFileSplit splits the content read from r into records delimited by
+// delimiter and uploads them over mfc as numbered files in the directory at
+// dir, which is replaced. Each file holds targetRecords records or at least
+// targetBytes bytes of records, whichever comes first, or a single record if
+// neither is set. CSV files and SQL dumps keep their header in every file. The
+// commit must already have been set on mfc.
+func PutFileSplit(mfc API_ModifyFileClient, dir string, r io.Reader, delimiter Delimiter, targetRecords, targetBytes int64) error {
+	var format split.Format
+	switch delimiter {
+	case Delimiter_LINE:
+		format = split.Line
+	case Delimiter_JSON:
+		format = split.JSON
+	case Delimiter_SQL:
+		format = split.SQL
+	case Delimiter_CSV:
+		format = split.CSV
+	default:
+		return errors.Errorf("cannot split %s: unsupported delimiter %v", dir, delimiter)
+	}
+	dir = path.Join("/", dir)
+	if err := mfc.Send(&ModifyFileRequest{Body: &ModifyFileRequest_DeleteFile{DeleteFile: &DeleteFile{Path: dir}}}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	buf := make([]byte, grpcutil.ChunkSize)
+	return split.Split(r, format, split.Options{TargetRecords: targetRecords, TargetBytes: targetBytes}, func(i int, data []byte) error {
+		return sendAddFile(mfc, SplitFileName(dir, i), bytes.NewReader(data), buf)
+	})
+}
+
+// SplitFileName returns the path of the ith file PutFileSplit uploads to dir.
+// The names sort in the order of the files.
+func SplitFileName(dir string, i int) string {
+	return path.Join(dir, fmt.Sprintf("%016x", i))
+}
@@ -0,0 +1,98 @@
+package pfs
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"archive/tar"
+	"io"
+	"path"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+)
+
+// PutFileTAR expands the tar archive read from r into files, sending them
+// over mfc as AddFile requests, so a whole archive is uploaded in a single
+// ModifyFile stream. Entries are named relative to the root directory, and
+// directory entries are skipped as directories exist implicitly. If overwrite
+// is set each file is deleted before its content is added, otherwise the
+// content is appended to any existing file. The commit must already have been
+// set on mfc.
+//
+// The archive is expanded on the client, so the server never sees it: only
+// the names and content of its regular files are sent. Modes, modification
+// times, owners and every other header field are discarded, and entries of
+// any type other than regular files and directories are rejected.
+func PutFileTAR(mfc API_ModifyFileClient, r io.Reader, overwrite bool) error {
+	tr := tar.NewReader(r)
+	buf := make([]byte, grpcutil.ChunkSize)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeReg:
+		default:
+			return errors.Errorf("cannot add %s: unsupported tar entry type %q", hdr.Name, hdr.Typeflag)
+		}
+		p := path.Join("/", hdr.Name)
+		if overwrite {
+			if err := mfc.Send(&ModifyFileRequest{Body: &ModifyFileRequest_DeleteFile{DeleteFile: &DeleteFile{Path: p}}}); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		if err := sendAddFile(mfc, p, tr, buf); err != nil {
+			return err
+		}
+	}
+}
+
+// sendAddFile sends the content of r over mfc as AddFile requests of at most
+// len(buf) bytes each, using buf to read into.
+func sendAddFile(mfc API_ModifyFileClient, p string, r io.Reader, buf []byte) error {
+	// send at least one request, so that empty files are created
+	for sent := false; ; sent = true {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return errors.EnsureStack(err)
+		}
+		if n == 0 && sent {
+			return nil
+		}
+		if err := mfc.Send(&ModifyFileRequest{Body: &ModifyFileRequest_AddFile{AddFile: &AddFile{
+			Path:   p,
+			Source: &AddFile_Raw{Raw: wrapperspb.Bytes(append([]byte(nil), buf[:n]...))},
+		}}}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if n < len(buf) {
+			return nil
+		}
+	}
+}
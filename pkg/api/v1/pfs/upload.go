@@ -0,0 +1,97 @@
+package pfs
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+)
+
+// UploadParts sends the parts of an upload that the server has not yet
+// acknowledged, reading their content from r, and returns the upload's state
+// after the last part. If it fails, for instance because the connection was
+// lost, calling it again resumes the upload after the last acknowledged part.
+// The upload still has to be finished with FinishUpload.
+func UploadParts(ctx context.Context, c APIClient, id string, r io.ReaderAt) (*UploadInfo, error) {
+	info, err := c.InspectUpload(ctx, &InspectUploadRequest{UploadId: id})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	buf := make([]byte, grpcutil.ChunkSize)
+	for info.CommittedBytes < info.SizeBytes {
+		size := info.SizeBytes - info.CommittedBytes
+		if size > info.PartSizeBytes {
+			size = info.PartSizeBytes
+		}
+		part := io.NewSectionReader(r, info.CommittedBytes, size)
+		h := NewHash()
+		if _, err := io.CopyBuffer(h, part, buf); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		if info, err = putUploadPart(ctx, c, &UploadPartHeader{
+			UploadId: id,
+			Offset:   info.CommittedBytes,
+			Hash:     h.Sum(nil),
+		}, io.NewSectionReader(r, info.CommittedBytes, size), buf); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+// putUploadPart sends a single part of an upload, with the content of r split
+// into messages of at most len(buf) bytes.
+func putUploadPart(ctx context.Context, c APIClient, header *UploadPartHeader, r io.Reader, buf []byte) (*UploadInfo, error) {
+	pc, err := c.PutUploadPart(ctx)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := pc.Send(&PutUploadPartRequest{Body: &PutUploadPartRequest_Header{Header: header}}); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if err := pc.Send(&PutUploadPartRequest{Body: &PutUploadPartRequest_Data{
+				Data: wrapperspb.Bytes(append([]byte(nil), buf[:n]...)),
+			}}); err != nil {
+				// the server's error is returned by CloseAndRecv
+				if err == io.EOF {
+					break
+				}
+				return nil, errors.EnsureStack(err)
+			}
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	}
+	info, err := pc.CloseAndRecv()
+	return info, errors.EnsureStack(err)
+}
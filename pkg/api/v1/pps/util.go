@@ -22,6 +22,7 @@ package pps
 
 import (
 	"fmt"
+	"io"
 	"sort"
 	"strings"
 
@@ -50,8 +51,19 @@ func init() {
 	errInvalidPipelineStateName = fmt.Sprintf("state %%s must be one of %s, or %s, etc", strings.Join(states, ", "), PipelineState_name[0])
 }
 
-func (j *Job) String() string {
-	return fmt.Sprintf("%s@%s", j.Pipeline.Name, j.ID)
+// Key returns the job's string form, "pipeline@id", which identifies it.
+func (j *Job) Key() string {
+	return fmt.Sprintf("%s@%s", j.Pipeline.Name, j.Id)
+}
+
+// Format formats a job by its key, rather than its generated String method,
+// so that it reads naturally in errors and logs.
+func (j *Job) Format(f fmt.State, verb rune) {
+	if verb == 'q' {
+		fmt.Fprintf(f, "%q", j.Key())
+		return
+	}
+	io.WriteString(f, j.Key())
 }
 
 // VisitInput visits each input recursively in ascending order (root last)
@@ -0,0 +1,238 @@
+// Package glob matches slash separated paths against shell style patterns.
+//
+// Patterns support:
+//   - '*', which matches any sequence of characters other than '/'
+//   - '**', which matches any sequence of characters, including '/'; "/**/"
+//     also matches a single '/'
+//   - '?', which matches any single character other than '/'
+//   - character classes such as [abc], which may contain ranges ([a-z]) and be
+//     negated ([!abc] or [^abc]); negated classes never match '/'
+//   - alternations such as {a,b}, whose alternatives may contain other
+//     patterns
+//   - capture groups such as (*), which substitutions like "$1" can refer to
+//     (see Glob.Replace)
+//   - '\x', which matches the literal character x
+//
+// Patterns are anchored at both ends.
+package glob
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// metaChars are the characters with a special meaning in patterns.
+const metaChars = `*?[{()\`
+
+// Glob is a compiled pattern.
+type Glob struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// Compile parses a pattern.
+func Compile(pattern string) (*Glob, error) {
+	expr, err := translate(pattern)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid glob pattern %q", pattern)
+	}
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid glob pattern %q", pattern)
+	}
+	return &Glob{pattern: pattern, re: re}, nil
+}
+
+// MustCompile is like Compile but panics if the pattern is invalid.
+func MustCompile(pattern string) *Glob {
+	g, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return g
+}
+
+// String returns the pattern g was compiled from.
+func (g *Glob) String() string {
+	return g.pattern
+}
+
+// Match reports whether path matches g.
+func (g *Glob) Match(path string) bool {
+	return g.re.MatchString(path)
+}
+
+// Capture returns the text matched by each of g's capture groups, or nil if
+// path doesn't match g.
+func (g *Glob) Capture(path string) []string {
+	m := g.re.FindStringSubmatch(path)
+	if m == nil {
+		return nil
+	}
+	return m[1:]
+}
+
+// Replace expands template with the capture groups of path. In template, $n
+// or ${n} is replaced with the text matched by the nth capture group, and $$
+// with a literal $. It returns false if path doesn't match g.
+func (g *Glob) Replace(path, template string) (string, bool) {
+	m := g.re.FindStringSubmatchIndex(path)
+	if m == nil {
+		return "", false
+	}
+	return string(g.re.ExpandString(nil, template, path, m)), true
+}
+
+// IsGlob reports whether pattern contains any special characters, as
+// opposed to only matching the literal path it spells.
+func IsGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, metaChars)
+}
+
+// Prefix returns the longest directory that contains every path g can
+// match, ending in '/', or "" if there is no such directory.
+func (g *Glob) Prefix() string {
+	i := strings.IndexAny(g.pattern, metaChars)
+	literal := g.pattern
+	if i >= 0 {
+		literal = g.pattern[:i]
+	}
+	return literal[:strings.LastIndex(literal, "/")+1]
+}
+
+// translate converts a glob pattern into a regular expression.
+func translate(pattern string) (string, error) {
+	var sb strings.Builder
+	// open is the stack of unclosed '{' and '(' in the pattern
+	var open []byte
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '\\':
+			if i+1 == len(pattern) {
+				return "", errors.New("trailing backslash")
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				for i+1 < len(pattern) && pattern[i+1] == '*' {
+					i++
+				}
+				if (i-2 < 0 || pattern[i-2] == '/') && i+1 < len(pattern) && pattern[i+1] == '/' {
+					// "/**/" matches any number of directories, including none
+					sb.WriteString("(?:.*/)?")
+					i++
+				} else {
+					sb.WriteString(".*")
+				}
+				continue
+			}
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			n, class, err := translateClass(pattern[i:])
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(class)
+			i += n - 1
+		case '{':
+			open = append(open, c)
+			sb.WriteString("(?:")
+		case ',':
+			if len(open) > 0 && open[len(open)-1] == '{' {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		case '}':
+			if len(open) == 0 || open[len(open)-1] != '{' {
+				return "", errors.New("unmatched '}'")
+			}
+			open = open[:len(open)-1]
+			sb.WriteString(")")
+		case '(':
+			open = append(open, c)
+			sb.WriteString("(")
+		case ')':
+			if len(open) == 0 || open[len(open)-1] != '(' {
+				return "", errors.New("unmatched ')'")
+			}
+			open = open[:len(open)-1]
+			sb.WriteString(")")
+		default:
+			sb.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if len(open) > 0 {
+		return "", errors.Errorf("unclosed '%c'", open[len(open)-1])
+	}
+	return sb.String(), nil
+}
+
+// translateClass converts the character class at the start of s into a
+// regular expression, returning the number of bytes of s it consumed.
+func translateClass(s string) (int, string, error) {
+	var sb strings.Builder
+	sb.WriteString("[")
+	i := 1
+	negated := i < len(s) && (s[i] == '!' || s[i] == '^')
+	if negated {
+		sb.WriteString("^/")
+		i++
+	}
+	start := i
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == ']' && i > start:
+			sb.WriteString("]")
+			return i + 1, sb.String(), nil
+		case c == '\\' && i+1 < len(s):
+			// an escaped character is always literal, so that "\d" is a "d"
+			// rather than the regexp class of digits
+			i++
+			if isPunct(s[i]) {
+				sb.WriteString(`\`)
+			}
+			sb.WriteString(s[i : i+1])
+		case c == '-' && i > start && i+1 < len(s) && s[i+1] != ']':
+			sb.WriteString("-")
+		case c == '\\' || c == '[' || c == ']' || c == '^' || c == '-':
+			sb.WriteString(`\` + s[i:i+1])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return 0, "", errors.New("unclosed '['")
+}
+
+// isPunct returns true if c is ASCII punctuation, which regexp lets be
+// escaped with a backslash.
+func isPunct(c byte) bool {
+	return c < 0x80 && !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '_' || c <= ' ')
+}
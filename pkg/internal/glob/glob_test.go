@@ -0,0 +1,128 @@
+package glob
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"/a", "/a", true},
+		{"/a", "/a/b", false},
+		{"/*", "/abc", true},
+		{"/*", "/a/b", false},
+		{"/*.txt", "/a.txt", true},
+		{"/*.txt", "/atxt", false},
+		{"/a?c", "/abc", true},
+		{"/a?c", "/a/c", false},
+		{"/**", "/a/b/c", true},
+		{"/a/**/c", "/a/c", true},
+		{"/a/**/c", "/a/b/c", true},
+		{"/a/**/c", "/a/b/b/c", true},
+		{"/a/**/c", "/ac", false},
+		{"/a**", "/abc/d", true},
+		{"/[abc]", "/b", true},
+		{"/[abc]", "/d", false},
+		{"/[a-c]x", "/cx", true},
+		{"/[!a-c]", "/d", true},
+		{"/[!a-c]", "/a", false},
+		{"/[^a-c]", "/d", true},
+		{"/a[!b]c", "/a/c", false},
+		{"/[]]", "/]", true},
+		{"/[a-]", "/-", true},
+		{`/[\d]`, "/d", true},
+		{`/[\d]`, "/1", false},
+		{`/[\w\s]`, "/w", true},
+		{`/[\w\s]`, "/_", false},
+		{`/[a\-c]`, "/-", true},
+		{`/[a\-c]`, "/b", false},
+		{`/[\]]`, "/]", true},
+		{"/{a,b}", "/a", true},
+		{"/{a,b}", "/b", true},
+		{"/{a,b}", "/c", false},
+		{"/{a,b*/c}", "/bx/c", true},
+		{"/{a,{b,c}}", "/c", true},
+		{"/a,b", "/a,b", true},
+		{"/(*)/(*)", "/a/b", true},
+		{`/\*`, "/*", true},
+		{`/\*`, "/a", false},
+		{"/a.b", "/axb", false},
+		{"/a+", "/a+", true},
+	} {
+		g, err := Compile(tc.pattern)
+		require.NoError(t, err)
+		require.Equal(t, tc.match, g.Match(tc.path), "%q matching %q", tc.pattern, tc.path)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, pattern := range []string{"/[a", "/{a,b", "/a}", "/(a", "/a)", "/{a)", `/a\`} {
+		_, err := Compile(pattern)
+		require.YesError(t, err, "%q", pattern)
+	}
+}
+
+func TestCapture(t *testing.T) {
+	g := MustCompile("/(*)/(*).{txt,csv}")
+	require.Equal(t, []string{"a", "b"}, g.Capture("/a/b.csv"))
+	require.True(t, g.Capture("/a/b.json") == nil)
+
+	s, ok := g.Replace("/users/alice.txt", "$2-$1")
+	require.True(t, ok)
+	require.Equal(t, "alice-users", s)
+	s, ok = g.Replace("/users/alice.txt", "${1}x$$")
+	require.True(t, ok)
+	require.Equal(t, "usersx$", s)
+	_, ok = g.Replace("/users", "$1")
+	require.False(t, ok)
+}
+
+func TestIsGlob(t *testing.T) {
+	require.False(t, IsGlob("/a/b.txt"))
+	require.False(t, IsGlob("/a,b"))
+	for _, pattern := range []string{"/*", "/a?", "/[ab]", "/{a,b}", "/(a)", `/\a`} {
+		require.True(t, IsGlob(pattern), "%q", pattern)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	for pattern, prefix := range map[string]string{
+		"/":           "/",
+		"/a/b":        "/a/",
+		"/a/b/*":      "/a/b/",
+		"/a/b*/c":     "/a/",
+		"/a/{b,c}/d":  "/a/",
+		"/**":         "/",
+		`/a/\*`:       "/a/",
+		"*":           "",
+		"/a/(*)/b":    "/a/",
+		"/a/[bc]/(d)": "/a/",
+	} {
+		require.Equal(t, prefix, MustCompile(pattern).Prefix(), "%q", pattern)
+	}
+}
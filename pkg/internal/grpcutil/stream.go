@@ -0,0 +1,103 @@
+package grpcutil
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"io"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+const (
+	// MaxMsgSize is the maximum size of a gRPC message we send or accept.
+	MaxMsgSize = 20 * 1024 * 1024
+	// ChunkSize is the size of the chunks file data is streamed in.
+	ChunkSize = 1024 * 1024
+)
+
+// StreamingBytesServer is the server side of a stream of BytesValues, for
+// example API_GetFileServer.
+type StreamingBytesServer interface {
+	Send(*wrapperspb.BytesValue) error
+}
+
+// StreamingBytesClient is the client side of a stream of BytesValues, for
+// example API_GetFileClient.
+type StreamingBytesClient interface {
+	Recv() (*wrapperspb.BytesValue, error)
+}
+
+type streamingBytesWriter struct {
+	server StreamingBytesServer
+}
+
+// NewStreamingBytesWriter returns an io.Writer that sends everything written
+// to it over server, split into messages of at most ChunkSize bytes.
+func NewStreamingBytesWriter(server StreamingBytesServer) io.Writer {
+	return &streamingBytesWriter{server: server}
+}
+
+func (w *streamingBytesWriter) Write(p []byte) (int, error) {
+	for written := 0; written < len(p); {
+		n := len(p) - written
+		if n > ChunkSize {
+			n = ChunkSize
+		}
+		// the message may be serialized after Write returns, so don't send
+		// the caller's buffer
+		chunk := make([]byte, n)
+		copy(chunk, p[written:written+n])
+		if err := w.server.Send(wrapperspb.Bytes(chunk)); err != nil {
+			return written, errors.EnsureStack(err)
+		}
+		written += n
+	}
+	return len(p), nil
+}
+
+type streamingBytesReader struct {
+	client StreamingBytesClient
+	buf    bytes.Buffer
+}
+
+// NewStreamingBytesReader returns an io.Reader over the data received from
+// client.
+func NewStreamingBytesReader(client StreamingBytesClient) io.Reader {
+	return &streamingBytesReader{client: client}
+}
+
+func (r *streamingBytesReader) Read(p []byte) (int, error) {
+	for r.buf.Len() == 0 {
+		msg, err := r.client.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return 0, io.EOF
+			}
+			return 0, errors.EnsureStack(err)
+		}
+		r.buf.Write(msg.Value)
+	}
+	n, err := r.buf.Read(p)
+	return n, errors.EnsureStack(err)
+}
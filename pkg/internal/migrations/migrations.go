@@ -0,0 +1,94 @@
+package migrations
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// Migration is a single schema change. Once a migration has been released it
+// must never be edited; change the schema by appending a new migration
+// instead.
+type Migration struct {
+	Name string
+	Up   func(ctx context.Context, tx *sql.Tx) error
+}
+
+// Exec returns a migration that runs a fixed list of SQL statements.
+func Exec(name string, stmts ...string) Migration {
+	return Migration{
+		Name: name,
+		Up: func(ctx context.Context, tx *sql.Tx) error {
+			for _, stmt := range stmts {
+				if _, err := tx.ExecContext(ctx, stmt); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			return nil
+		},
+	}
+}
+
+// Apply brings the migrations of namespace up to date, running every
+// migration that has not been applied yet, in order, in a single transaction.
+// Concurrent callers are serialized, so it is safe for several servers to
+// start against the same database at once.
+func Apply(ctx context.Context, db *sql.DB, namespace string, ms []Migration) error {
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS migrations (
+		namespace TEXT NOT NULL,
+		version INT NOT NULL,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (namespace, version)
+	)`); err != nil && !dbutil.IsUniqueViolation(err) {
+		// CREATE TABLE IF NOT EXISTS can race with itself and fail with a
+		// unique violation, which means the table exists
+		return errors.EnsureStack(err)
+	}
+	return dbutil.WithTx(ctx, db, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE migrations IN EXCLUSIVE MODE`); err != nil {
+			return errors.EnsureStack(err)
+		}
+		var applied int
+		if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM migrations WHERE namespace = $1`, namespace).Scan(&applied); err != nil {
+			return errors.EnsureStack(err)
+		}
+		if applied > len(ms) {
+			return errors.Errorf("database has %d %s migrations applied, but only %d are known; is this server older than the database?", applied, namespace, len(ms))
+		}
+		for i := applied; i < len(ms); i++ {
+			log.WithFields(log.Fields{"namespace": namespace, "version": i, "name": ms[i].Name}).Info("applying migration")
+			if err := ms[i].Up(ctx, tx); err != nil {
+				return errors.Wrapf(err, "migration %s %d (%s)", namespace, i, ms[i].Name)
+			}
+			if _, err := tx.ExecContext(ctx, `INSERT INTO migrations (namespace, version, name) VALUES ($1, $2, $3)`, namespace, i, ms[i].Name); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	})
+}
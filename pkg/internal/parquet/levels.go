@@ -0,0 +1,106 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/binary"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// decodeHybrid decodes n values of bitWidth bits from the RLE/bit-packed
+// hybrid encoding used for levels and dictionary indices.
+func decodeHybrid(buf []byte, bitWidth, n int) ([]int32, error) {
+	values := make([]int32, 0, n)
+	if bitWidth == 0 {
+		return values[:n], nil
+	}
+	if bitWidth > 32 {
+		return nil, errors.Errorf("invalid parquet bit width %d", bitWidth)
+	}
+	byteWidth := (bitWidth + 7) / 8
+	for len(values) < n {
+		header, size := binary.Uvarint(buf)
+		if size <= 0 {
+			return nil, errors.New("truncated parquet levels")
+		}
+		buf = buf[size:]
+		if header&1 == 0 {
+			// an RLE run of a single value
+			count := int(header >> 1)
+			if len(buf) < byteWidth {
+				return nil, errors.New("truncated parquet levels")
+			}
+			var v int32
+			for i := 0; i < byteWidth; i++ {
+				v |= int32(buf[i]) << (8 * i)
+			}
+			buf = buf[byteWidth:]
+			for i := 0; i < count && len(values) < n; i++ {
+				values = append(values, v)
+			}
+			continue
+		}
+		// groups of 8 bit-packed values, least significant bit first
+		count := int(header>>1) * 8
+		size = int(header>>1) * bitWidth
+		if len(buf) < size {
+			return nil, errors.New("truncated parquet levels")
+		}
+		for i := 0; i < count && len(values) < n; i++ {
+			var v int32
+			for b := 0; b < bitWidth; b++ {
+				bit := i*bitWidth + b
+				v |= int32(buf[bit/8]>>(bit%8)&1) << b
+			}
+			values = append(values, v)
+		}
+		buf = buf[size:]
+	}
+	return values, nil
+}
+
+// encodeHybrid encodes values that fit in a byte as RLE runs of the hybrid
+// encoding.
+func encodeHybrid(values []int32) []byte {
+	var buf []byte
+	var tmp [binary.MaxVarintLen64]byte
+	for i := 0; i < len(values); {
+		j := i + 1
+		for j < len(values) && values[j] == values[i] {
+			j++
+		}
+		buf = append(buf, tmp[:binary.PutUvarint(tmp[:], uint64(j-i)<<1)]...)
+		buf = append(buf, byte(values[i]))
+		i = j
+	}
+	return buf
+}
+
+// bitWidth returns the number of bits needed for values up to max.
+func bitWidth(max int) int {
+	n := 0
+	for max > 0 {
+		n++
+		max >>= 1
+	}
+	return n
+}
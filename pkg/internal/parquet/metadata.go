@@ -0,0 +1,248 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// timestamp units
+const (
+	unitMillis = iota + 1
+	unitMicros
+	unitNanos
+)
+
+// columnSchema is a leaf of the schema of a file.
+type columnSchema struct {
+	Column
+	physical   int32
+	typeLength int
+	// unit is the unit of timestamp columns stored as int64s.
+	unit int
+	// decimal is set for decimal columns, which are read as strings.
+	decimal bool
+	scale   int
+}
+
+type rowGroup struct {
+	numRows int64
+	chunks  []columnChunk
+}
+
+type columnChunk struct {
+	codec     int32
+	numValues int64
+	// offset and size locate the pages of the chunk, dictionary page first.
+	offset int64
+	size   int64
+}
+
+type fileMetadata struct {
+	numRows   int64
+	columns   []*columnSchema
+	rowGroups []rowGroup
+}
+
+func decodeFileMetadata(buf []byte) (*fileMetadata, error) {
+	d := &thriftDecoder{buf: buf}
+	s, err := d.strct()
+	if err != nil {
+		return nil, err
+	}
+	md := &fileMetadata{numRows: s.int(3)}
+	elements := s.list(2)
+	if len(elements) == 0 {
+		return nil, errors.New("parquet file has no schema")
+	}
+	for _, e := range elements[1:] {
+		c, err := decodeColumnSchema(e.(tstruct))
+		if err != nil {
+			return nil, err
+		}
+		md.columns = append(md.columns, c)
+	}
+	if root := elements[0].(tstruct); int(root.int(5)) != len(md.columns) {
+		return nil, errors.New("parquet files with nested columns are not supported")
+	}
+	for _, rg := range s.list(4) {
+		rg := rg.(tstruct)
+		chunks := rg.list(1)
+		if len(chunks) != len(md.columns) {
+			return nil, errors.Errorf("parquet row group has %d columns, expected %d", len(chunks), len(md.columns))
+		}
+		g := rowGroup{numRows: rg.int(3)}
+		for _, cc := range chunks {
+			cmd := cc.(tstruct).strct(3)
+			if cmd == nil {
+				return nil, errors.New("parquet column chunks in other files are not supported")
+			}
+			chunk := columnChunk{
+				codec:     int32(cmd.int(4)),
+				numValues: cmd.int(5),
+				offset:    cmd.int(9),
+				size:      cmd.int(7),
+			}
+			if cmd.has(11) && cmd.int(11) > 0 && cmd.int(11) < chunk.offset {
+				chunk.offset = cmd.int(11)
+			}
+			g.chunks = append(g.chunks, chunk)
+		}
+		md.rowGroups = append(md.rowGroups, g)
+	}
+	return md, nil
+}
+
+func decodeColumnSchema(e tstruct) (*columnSchema, error) {
+	c := &columnSchema{
+		Column:     Column{Name: e.string(4)},
+		physical:   int32(e.int(1)),
+		typeLength: int(e.int(2)),
+	}
+	if e.int(5) > 0 || !e.has(1) {
+		return nil, errors.Errorf("parquet column %s is nested, which is not supported", c.Name)
+	}
+	switch int32(e.int(3)) {
+	case repetitionRequired:
+		c.Required = true
+	case repetitionRepeated:
+		return nil, errors.Errorf("parquet column %s is repeated, which is not supported", c.Name)
+	}
+	switch c.physical {
+	case typeBoolean:
+		c.Kind = Bool
+	case typeInt32:
+		c.Kind = Int32
+	case typeInt64:
+		c.Kind = Int64
+	case typeInt96:
+		c.Kind = Timestamp
+	case typeFloat:
+		c.Kind = Float
+	case typeDouble:
+		c.Kind = Double
+	case typeByteArray, typeFixedLenByteArray:
+		c.Kind = Bytes
+	default:
+		return nil, errors.Errorf("parquet column %s has invalid type %d", c.Name, c.physical)
+	}
+	if lt := e.strct(10); lt != nil {
+		switch {
+		case lt.has(1), lt.has(4), lt.has(12):
+			c.Kind = String
+		case lt.has(5):
+			c.decimal, c.scale = true, int(lt.strct(5).int(1))
+		case lt.has(6):
+			c.Kind = Date
+		case lt.has(8):
+			unit := lt.strct(8).strct(2)
+			switch {
+			case unit.has(1):
+				c.unit = unitMillis
+			case unit.has(2):
+				c.unit = unitMicros
+			case unit.has(3):
+				c.unit = unitNanos
+			}
+			c.Kind = Timestamp
+		}
+	} else if e.has(6) {
+		switch int32(e.int(6)) {
+		case convertedUTF8, convertedEnum, convertedJSON:
+			c.Kind = String
+		case convertedDecimal:
+			c.decimal, c.scale = true, int(e.int(7))
+		case convertedDate:
+			c.Kind = Date
+		case convertedTimestampMillis:
+			c.Kind, c.unit = Timestamp, unitMillis
+		case convertedTimestampMicros:
+			c.Kind, c.unit = Timestamp, unitMicros
+		}
+	}
+	if c.decimal {
+		c.Kind = String
+	}
+	if err := c.check(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// check returns an error if the column's kind cannot be stored in its
+// physical type.
+func (c *columnSchema) check() error {
+	ok := true
+	switch c.Kind {
+	case String:
+		ok = c.physical == typeByteArray || c.physical == typeFixedLenByteArray ||
+			(c.decimal && (c.physical == typeInt32 || c.physical == typeInt64))
+	case Date:
+		ok = c.physical == typeInt32
+	case Timestamp:
+		ok = c.physical == typeInt96 || (c.physical == typeInt64 && c.unit != 0)
+	}
+	if !ok {
+		return errors.Errorf("parquet column %s cannot hold %v values", c.Name, c.Kind)
+	}
+	return nil
+}
+
+type pageHeader struct {
+	typ              int32
+	uncompressedSize int
+	compressedSize   int
+	numValues        int
+	encoding         int32
+	// set for version 2 data pages
+	defLevelsSize int
+	repLevelsSize int
+	compressed    bool
+}
+
+func decodePageHeader(d *thriftDecoder) (*pageHeader, error) {
+	s, err := d.strct()
+	if err != nil {
+		return nil, err
+	}
+	h := &pageHeader{
+		typ:              int32(s.int(1)),
+		uncompressedSize: int(s.int(2)),
+		compressedSize:   int(s.int(3)),
+	}
+	switch h.typ {
+	case pageData:
+		dph := s.strct(5)
+		h.numValues, h.encoding = int(dph.int(1)), int32(dph.int(2))
+	case pageDictionary:
+		dph := s.strct(7)
+		h.numValues, h.encoding = int(dph.int(1)), int32(dph.int(2))
+	case pageDataV2:
+		dph := s.strct(8)
+		h.numValues, h.encoding = int(dph.int(1)), int32(dph.int(4))
+		h.defLevelsSize, h.repLevelsSize = int(dph.int(5)), int(dph.int(6))
+		h.compressed = !dph.has(7) || dph.bool(7)
+	}
+	if h.compressedSize < 0 || h.uncompressedSize < 0 || h.numValues < 0 {
+		return nil, errors.New("invalid parquet page header")
+	}
+	return h, nil
+}
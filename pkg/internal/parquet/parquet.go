@@ -0,0 +1,127 @@
+// Package parquet reads and writes Apache Parquet files with a flat schema of
+// optional or required columns. The Reader supports the encodings and
+// compression codecs common writers use by default: plain and dictionary
+// encoded pages, version 1 and 2 data pages, and uncompressed, Snappy, gzip
+// or Zstandard compressed column chunks. The Writer writes plain encoded,
+// Snappy compressed pages.
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "fmt"
+
+// magic starts and ends every Parquet file.
+const magic = "PAR1"
+
+// Kind is the type of the values in a column, as seen by Go code.
+type Kind int
+
+const (
+	// Bool values are bools.
+	Bool Kind = iota
+	// Int32 values are int32s.
+	Int32
+	// Int64 values are int64s.
+	Int64
+	// Float values are float32s.
+	Float
+	// Double values are float64s.
+	Double
+	// String values are strings. Decimal columns are read as strings too.
+	String
+	// Bytes values are []bytes.
+	Bytes
+	// Date values are time.Times at midnight UTC.
+	Date
+	// Timestamp values are time.Times in UTC, with microsecond precision when
+	// written.
+	Timestamp
+)
+
+var kindNames = []string{"bool", "int32", "int64", "float", "double", "string", "bytes", "date", "timestamp"}
+
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// Column describes a column of a Parquet file.
+type Column struct {
+	Name string
+	Kind Kind
+	// Required columns cannot hold nulls.
+	Required bool
+}
+
+// physical types
+const (
+	typeBoolean int32 = iota
+	typeInt32
+	typeInt64
+	typeInt96
+	typeFloat
+	typeDouble
+	typeByteArray
+	typeFixedLenByteArray
+)
+
+// repetition types
+const (
+	repetitionRequired int32 = iota
+	repetitionOptional
+	repetitionRepeated
+)
+
+// converted types
+const (
+	convertedUTF8            int32 = 0
+	convertedEnum            int32 = 4
+	convertedDecimal         int32 = 5
+	convertedDate            int32 = 6
+	convertedTimestampMillis int32 = 9
+	convertedTimestampMicros int32 = 10
+	convertedJSON            int32 = 19
+)
+
+// encodings
+const (
+	encodingPlain           int32 = 0
+	encodingPlainDictionary int32 = 2
+	encodingRLE             int32 = 3
+	encodingRLEDictionary   int32 = 8
+)
+
+// compression codecs
+const (
+	codecUncompressed int32 = 0
+	codecSnappy       int32 = 1
+	codecGzip         int32 = 2
+	codecZstd         int32 = 6
+)
+
+// page types
+const (
+	pageData       int32 = 0
+	pageDictionary int32 = 2
+	pageDataV2     int32 = 3
+)
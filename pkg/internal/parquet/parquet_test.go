@@ -0,0 +1,128 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestRoundTrip(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Kind: Int64, Required: true},
+		{Name: "ok", Kind: Bool},
+		{Name: "small", Kind: Int32},
+		{Name: "ratio", Kind: Float},
+		{Name: "score", Kind: Double},
+		{Name: "name", Kind: String},
+		{Name: "blob", Kind: Bytes},
+		{Name: "day", Kind: Date},
+		{Name: "at", Kind: Timestamp},
+	}
+	at := time.Date(2021, 3, 4, 5, 6, 7, 891011000, time.UTC)
+	day := time.Date(1969, 12, 31, 0, 0, 0, 0, time.UTC)
+	rows := [][]interface{}{
+		{int64(1), true, int32(-7), float32(0.5), 2.25, "alice", []byte{0, 1}, day, at},
+		{int64(2), nil, nil, nil, nil, nil, nil, nil, nil},
+		{int64(3), false, int32(1 << 30), float32(-1), -0.125, "", []byte{}, day.AddDate(0, 0, 2), at.Add(-time.Hour)},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	// every row group but the last is full
+	w.RowGroupSize = 2
+	for _, row := range rows {
+		require.NoError(t, w.Write(row))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, columns, r.Columns())
+	require.Equal(t, int64(len(rows)), r.NumRows())
+	for _, expected := range rows {
+		row, err := r.Read()
+		require.NoError(t, err)
+		require.Equal(t, expected, row)
+	}
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestWriterConversions(t *testing.T) {
+	columns := []Column{
+		{Name: "n", Kind: Int32},
+		{Name: "x", Kind: Double, Required: true},
+		{Name: "s", Kind: String},
+		{Name: "at", Kind: Timestamp},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	require.NoError(t, err)
+	// values as a database driver returns them
+	require.NoError(t, w.Write([]interface{}{int64(5), []byte("1.5"), []byte("text"), "2021-03-04T05:06:07Z"}))
+	require.YesError(t, w.Write([]interface{}{int64(1) << 40, 1.0, nil, nil}))
+	require.YesError(t, w.Write([]interface{}{nil, nil, nil, nil}))
+	require.YesError(t, w.Write([]interface{}{"five", 1.0, nil, nil}))
+	require.YesError(t, w.Write([]interface{}{nil, 1.0}))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	row, err := r.Read()
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{int32(5), 1.5, "text", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}, row)
+	_, err = r.Read()
+	require.Equal(t, io.EOF, err)
+
+	_, err = NewWriter(&buf, []Column{{Name: "a", Kind: Int32}, {Name: "a", Kind: Int64}})
+	require.YesError(t, err)
+}
+
+func TestNotParquet(t *testing.T) {
+	data := []byte("definitely not a parquet file")
+	_, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	require.YesError(t, err)
+}
+
+func TestDecodeHybrid(t *testing.T) {
+	// the bit-packed example from the Parquet specification: 0 to 7 with a
+	// bit width of 3
+	values, err := decodeHybrid([]byte{3, 0x88, 0xc6, 0xfa}, 3, 8)
+	require.NoError(t, err)
+	require.Equal(t, []int32{0, 1, 2, 3, 4, 5, 6, 7}, values)
+
+	levels := []int32{1, 1, 1, 0, 1, 0, 0}
+	values, err = decodeHybrid(encodeHybrid(levels), 1, len(levels))
+	require.NoError(t, err)
+	require.Equal(t, levels, values)
+}
+
+func TestFormatDecimal(t *testing.T) {
+	require.Equal(t, "123.45", formatDecimal(big.NewInt(12345), 2))
+	require.Equal(t, "-0.05", formatDecimal(big.NewInt(-5), 2))
+	require.Equal(t, "42", formatDecimal(big.NewInt(42), 0))
+}
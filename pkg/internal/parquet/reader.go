@@ -0,0 +1,427 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// maxFooterSize bounds the metadata a Reader is willing to load.
+const maxFooterSize = 64 << 20
+
+// julianUnixEpoch is the Julian day of 1970-01-01, used by INT96 timestamps.
+const julianUnixEpoch = 2440588
+
+// Reader reads the rows of a Parquet file, one row group at a time.
+type Reader struct {
+	r  io.ReaderAt
+	md *fileMetadata
+	// group is the index of the next row group to load.
+	group int
+	// values holds the values of the current row group, by column.
+	values [][]interface{}
+	row    int
+}
+
+// NewReader reads the metadata of the Parquet file of the given size in r.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, errors.New("not a parquet file: too small")
+	}
+	tail := make([]byte, 4+len(magic))
+	if _, err := r.ReadAt(tail, size-int64(len(tail))); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if string(tail[4:]) != magic {
+		return nil, errors.New("not a parquet file: missing magic number")
+	}
+	footerSize := int64(binary.LittleEndian.Uint32(tail))
+	if footerSize > maxFooterSize || footerSize > size-int64(len(tail)+len(magic)) {
+		return nil, errors.Errorf("invalid parquet footer size %d", footerSize)
+	}
+	footer := make([]byte, footerSize)
+	if _, err := r.ReadAt(footer, size-int64(len(tail))-footerSize); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	md, err := decodeFileMetadata(footer)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid parquet metadata")
+	}
+	return &Reader{r: r, md: md}, nil
+}
+
+// Columns returns the columns of the file.
+func (r *Reader) Columns() []Column {
+	columns := make([]Column, len(r.md.columns))
+	for i, c := range r.md.columns {
+		columns[i] = c.Column
+	}
+	return columns
+}
+
+// NumRows returns the number of rows in the file.
+func (r *Reader) NumRows() int64 {
+	return r.md.numRows
+}
+
+// Read returns the next row, with a value for every column, or io.EOF once
+// there are no rows left. Nulls are nil.
+func (r *Reader) Read() ([]interface{}, error) {
+	for r.values == nil || r.row == len(r.values[0]) {
+		if r.group == len(r.md.rowGroups) {
+			return nil, io.EOF
+		}
+		if err := r.loadRowGroup(r.md.rowGroups[r.group]); err != nil {
+			return nil, err
+		}
+		r.group++
+		r.row = 0
+		if len(r.values) == 0 {
+			return nil, io.EOF
+		}
+	}
+	row := make([]interface{}, len(r.values))
+	for i := range r.values {
+		row[i] = r.values[i][r.row]
+	}
+	r.row++
+	return row, nil
+}
+
+func (r *Reader) loadRowGroup(g rowGroup) error {
+	r.values = make([][]interface{}, len(r.md.columns))
+	for i, c := range r.md.columns {
+		values, err := r.readChunk(c, g.chunks[i])
+		if err != nil {
+			return errors.Wrapf(err, "cannot read parquet column %s", c.Name)
+		}
+		if int64(len(values)) != g.numRows {
+			return errors.Errorf("parquet column %s has %d values in a row group of %d rows", c.Name, len(values), g.numRows)
+		}
+		r.values[i] = values
+	}
+	return nil
+}
+
+func (r *Reader) readChunk(c *columnSchema, chunk columnChunk) ([]interface{}, error) {
+	if chunk.size < 0 || chunk.size > math.MaxInt32 {
+		return nil, errors.Errorf("invalid column chunk size %d", chunk.size)
+	}
+	buf := make([]byte, chunk.size)
+	if _, err := r.r.ReadAt(buf, chunk.offset); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	d := &thriftDecoder{buf: buf}
+	var dict []interface{}
+	values := make([]interface{}, 0, chunk.numValues)
+	for int64(len(values)) < chunk.numValues {
+		h, err := decodePageHeader(d)
+		if err != nil {
+			return nil, err
+		}
+		if d.pos+h.compressedSize > len(buf) {
+			return nil, errors.New("truncated parquet page")
+		}
+		page := buf[d.pos : d.pos+h.compressedSize]
+		d.pos += h.compressedSize
+		switch h.typ {
+		case pageDictionary:
+			data, err := decompress(chunk.codec, page, h.uncompressedSize)
+			if err != nil {
+				return nil, err
+			}
+			if dict, _, err = decodePlain(c, data, h.numValues); err != nil {
+				return nil, err
+			}
+		case pageData, pageDataV2:
+			if values, err = readDataPage(c, chunk.codec, h, page, dict, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return values, nil
+}
+
+// readDataPage appends the values of a data page to values.
+func readDataPage(c *columnSchema, codec int32, h *pageHeader, page []byte, dict, values []interface{}) ([]interface{}, error) {
+	var defLevels, data []byte
+	if h.typ == pageDataV2 {
+		levelsSize := h.repLevelsSize + h.defLevelsSize
+		if levelsSize > len(page) {
+			return nil, errors.New("truncated parquet page")
+		}
+		defLevels, data = page[h.repLevelsSize:levelsSize], page[levelsSize:]
+		if h.compressed {
+			var err error
+			if data, err = decompress(codec, data, h.uncompressedSize-levelsSize); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var err error
+		if data, err = decompress(codec, page, h.uncompressedSize); err != nil {
+			return nil, err
+		}
+		if !c.Required {
+			if len(data) < 4 || int(binary.LittleEndian.Uint32(data)) > len(data)-4 {
+				return nil, errors.New("truncated parquet levels")
+			}
+			n := int(binary.LittleEndian.Uint32(data))
+			defLevels, data = data[4:4+n], data[4+n:]
+		}
+	}
+	present := h.numValues
+	var levels []int32
+	if !c.Required {
+		var err error
+		if levels, err = decodeHybrid(defLevels, 1, h.numValues); err != nil {
+			return nil, err
+		}
+		present = 0
+		for _, l := range levels {
+			present += int(l)
+		}
+	}
+	var decoded []interface{}
+	var err error
+	switch h.encoding {
+	case encodingPlain:
+		decoded, _, err = decodePlain(c, data, present)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		decoded, err = decodeDictionary(dict, data, present)
+	case encodingRLE:
+		if c.physical != typeBoolean {
+			return nil, errors.New("RLE encoding is only supported for booleans")
+		}
+		decoded, err = decodeRLEBooleans(data, present)
+	default:
+		return nil, errors.Errorf("parquet encoding %d is not supported", h.encoding)
+	}
+	if err != nil {
+		return nil, err
+	}
+	next := 0
+	for i := 0; i < h.numValues; i++ {
+		if levels != nil && levels[i] == 0 {
+			values = append(values, nil)
+			continue
+		}
+		v, err := c.convert(decoded[next])
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		next++
+	}
+	return values, nil
+}
+
+func decompress(codec int32, data []byte, size int) ([]byte, error) {
+	var out []byte
+	var err error
+	switch codec {
+	case codecUncompressed:
+		return data, nil
+	case codecSnappy:
+		out, err = snappy.Decode(nil, data)
+	case codecGzip:
+		var zr *gzip.Reader
+		if zr, err = gzip.NewReader(bytes.NewReader(data)); err == nil {
+			out, err = ioutil.ReadAll(zr)
+		}
+	case codecZstd:
+		var zr *zstd.Decoder
+		if zr, err = zstd.NewReader(nil); err == nil {
+			out, err = zr.DecodeAll(data, make([]byte, 0, size))
+			zr.Close()
+		}
+	default:
+		return nil, errors.Errorf("parquet compression codec %d is not supported", codec)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot decompress parquet page")
+	}
+	if len(out) != size {
+		return nil, errors.Errorf("parquet page decompressed to %d bytes, expected %d", len(out), size)
+	}
+	return out, nil
+}
+
+// decodePlain decodes n plain encoded values of c's physical type, and
+// returns them along with the rest of data.
+func decodePlain(c *columnSchema, data []byte, n int) ([]interface{}, []byte, error) {
+	values := make([]interface{}, 0, n)
+	truncated := errors.New("truncated parquet values")
+	if c.physical == typeBoolean {
+		if len(data) < (n+7)/8 {
+			return nil, nil, truncated
+		}
+		for i := 0; i < n; i++ {
+			values = append(values, data[i/8]>>(i%8)&1 == 1)
+		}
+		return values, data[(n+7)/8:], nil
+	}
+	for i := 0; i < n; i++ {
+		size := 0
+		switch c.physical {
+		case typeInt32, typeFloat:
+			size = 4
+		case typeInt64, typeDouble:
+			size = 8
+		case typeInt96:
+			size = 12
+		case typeFixedLenByteArray:
+			size = c.typeLength
+		case typeByteArray:
+			if len(data) < 4 {
+				return nil, nil, truncated
+			}
+			size = int(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		}
+		if size < 0 || len(data) < size {
+			return nil, nil, truncated
+		}
+		b := data[:size]
+		data = data[size:]
+		switch c.physical {
+		case typeInt32:
+			values = append(values, int32(binary.LittleEndian.Uint32(b)))
+		case typeFloat:
+			values = append(values, math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case typeInt64:
+			values = append(values, int64(binary.LittleEndian.Uint64(b)))
+		case typeDouble:
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		default:
+			values = append(values, b)
+		}
+	}
+	return values, data, nil
+}
+
+func decodeDictionary(dict []interface{}, data []byte, n int) ([]interface{}, error) {
+	if n == 0 {
+		return nil, nil
+	}
+	if len(data) == 0 {
+		return nil, errors.New("truncated parquet dictionary indices")
+	}
+	indices, err := decodeHybrid(data[1:], int(data[0]), n)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i, idx := range indices {
+		if int(idx) >= len(dict) || idx < 0 {
+			return nil, errors.Errorf("parquet dictionary index %d out of range", idx)
+		}
+		values[i] = dict[idx]
+	}
+	return values, nil
+}
+
+func decodeRLEBooleans(data []byte, n int) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, errors.New("truncated parquet values")
+	}
+	bits, err := decodeHybrid(data[4:], 1, n)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, n)
+	for i, b := range bits {
+		values[i] = b == 1
+	}
+	return values, nil
+}
+
+// convert converts a physical value of c to a value of c's Kind.
+func (c *columnSchema) convert(v interface{}) (interface{}, error) {
+	if c.decimal {
+		var n *big.Int
+		switch v := v.(type) {
+		case int32:
+			n = big.NewInt(int64(v))
+		case int64:
+			n = big.NewInt(v)
+		case []byte:
+			n = new(big.Int).SetBytes(v)
+			if len(v) > 0 && v[0]&0x80 != 0 {
+				// two's complement
+				n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(8*len(v))))
+			}
+		}
+		return formatDecimal(n, c.scale), nil
+	}
+	switch c.Kind {
+	case String:
+		return string(v.([]byte)), nil
+	case Bytes:
+		b := make([]byte, len(v.([]byte)))
+		copy(b, v.([]byte))
+		return b, nil
+	case Date:
+		return time.Unix(int64(v.(int32))*86400, 0).UTC(), nil
+	case Timestamp:
+		if b, ok := v.([]byte); ok {
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			days := int64(binary.LittleEndian.Uint32(b[8:])) - julianUnixEpoch
+			return time.Unix(days*86400, nanos).UTC(), nil
+		}
+		n := v.(int64)
+		switch c.unit {
+		case unitMillis:
+			return time.Unix(n/1e3, n%1e3*1e6).UTC(), nil
+		case unitMicros:
+			return time.Unix(n/1e6, n%1e6*1e3).UTC(), nil
+		}
+		return time.Unix(0, n).UTC(), nil
+	}
+	return v, nil
+}
+
+func formatDecimal(n *big.Int, scale int) string {
+	s := new(big.Int).Abs(n).String()
+	if scale > 0 {
+		if len(s) <= scale {
+			s = strings.Repeat("0", scale-len(s)+1) + s
+		}
+		s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if n.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,317 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// Parquet metadata is serialized with the Thrift compact protocol. Rather
+// than generating code from parquet.thrift, structs are decoded into a
+// generic form, and encoded field by field.
+
+// compact protocol types
+const (
+	tStop        = 0
+	tBoolTrue    = 1
+	tBoolFalse   = 2
+	tByte        = 3
+	tI16         = 4
+	tI32         = 5
+	tI64         = 6
+	tDouble      = 7
+	tBinary      = 8
+	tList        = 9
+	tSet         = 10
+	tMap         = 11
+	tStruct      = 12
+	maxThriftLen = 1 << 28
+)
+
+// tstruct is a decoded Thrift struct. Integers are int64s, binaries are
+// []bytes, lists and sets are []interface{}s, maps are ignored, and nested
+// structs are tstructs.
+type tstruct map[int16]interface{}
+
+func (s tstruct) int(id int16) int64 {
+	n, _ := s[id].(int64)
+	return n
+}
+
+func (s tstruct) has(id int16) bool {
+	_, ok := s[id]
+	return ok
+}
+
+func (s tstruct) bool(id int16) bool {
+	b, _ := s[id].(bool)
+	return b
+}
+
+func (s tstruct) string(id int16) string {
+	b, _ := s[id].([]byte)
+	return string(b)
+}
+
+func (s tstruct) strct(id int16) tstruct {
+	st, _ := s[id].(tstruct)
+	return st
+}
+
+func (s tstruct) list(id int16) []interface{} {
+	l, _ := s[id].([]interface{})
+	return l
+}
+
+// thriftDecoder decodes compact protocol structs from a byte slice.
+type thriftDecoder struct {
+	buf []byte
+	pos int
+}
+
+func (d *thriftDecoder) byte() (byte, error) {
+	if d.pos >= len(d.buf) {
+		return 0, errors.New("truncated thrift data")
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *thriftDecoder) uvarint() (uint64, error) {
+	n, size := binary.Uvarint(d.buf[d.pos:])
+	if size <= 0 {
+		return 0, errors.New("invalid thrift varint")
+	}
+	d.pos += size
+	return n, nil
+}
+
+func (d *thriftDecoder) varint() (int64, error) {
+	n, err := d.uvarint()
+	return int64(n>>1) ^ -int64(n&1), err
+}
+
+func (d *thriftDecoder) binary() ([]byte, error) {
+	n, err := d.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > maxThriftLen || d.pos+int(n) > len(d.buf) {
+		return nil, errors.New("truncated thrift data")
+	}
+	b := d.buf[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return b, nil
+}
+
+func (d *thriftDecoder) strct() (tstruct, error) {
+	s := make(tstruct)
+	var id int16
+	for {
+		b, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		typ := b & 0x0f
+		if typ == tStop {
+			return s, nil
+		}
+		if delta := b >> 4; delta != 0 {
+			id += int16(delta)
+		} else {
+			n, err := d.varint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(n)
+		}
+		v, err := d.value(typ)
+		if err != nil {
+			return nil, err
+		}
+		if v != nil {
+			s[id] = v
+		}
+	}
+}
+
+func (d *thriftDecoder) value(typ byte) (interface{}, error) {
+	switch typ {
+	case tBoolTrue:
+		return true, nil
+	case tBoolFalse:
+		return false, nil
+	case tByte:
+		b, err := d.byte()
+		return int64(int8(b)), err
+	case tI16, tI32, tI64:
+		return d.varint()
+	case tDouble:
+		if d.pos+8 > len(d.buf) {
+			return nil, errors.New("truncated thrift data")
+		}
+		f := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[d.pos:]))
+		d.pos += 8
+		return f, nil
+	case tBinary:
+		return d.binary()
+	case tList, tSet:
+		b, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		n, elem := uint64(b>>4), b&0x0f
+		if n == 15 {
+			if n, err = d.uvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if n > maxThriftLen {
+			return nil, errors.New("invalid thrift list size")
+		}
+		l := make([]interface{}, 0, n)
+		for i := uint64(0); i < n; i++ {
+			var v interface{}
+			if elem == tBoolTrue || elem == tBoolFalse {
+				var b byte
+				b, err = d.byte()
+				v = b == tBoolTrue
+			} else {
+				v, err = d.value(elem)
+			}
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	case tMap:
+		n, err := d.uvarint()
+		if err != nil || n == 0 {
+			return nil, err
+		}
+		b, err := d.byte()
+		if err != nil {
+			return nil, err
+		}
+		for i := uint64(0); i < n; i++ {
+			if _, err := d.value(b >> 4); err != nil {
+				return nil, err
+			}
+			if _, err := d.value(b & 0x0f); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case tStruct:
+		return d.strct()
+	}
+	return nil, errors.Errorf("invalid thrift type %d", typ)
+}
+
+// thriftEncoder encodes compact protocol structs. Fields must be written in
+// increasing id order, and every struct ended with end.
+type thriftEncoder struct {
+	buf []byte
+	ids []int16
+}
+
+func (e *thriftEncoder) uvarint(n uint64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf = append(e.buf, b[:binary.PutUvarint(b[:], n)]...)
+}
+
+func (e *thriftEncoder) varint(n int64) {
+	e.uvarint(uint64(n<<1) ^ uint64(n>>63))
+}
+
+func (e *thriftEncoder) field(id int16, typ byte) {
+	last := int16(0)
+	if len(e.ids) > 0 {
+		last = e.ids[len(e.ids)-1]
+	}
+	if delta := id - last; delta > 0 && delta <= 15 {
+		e.buf = append(e.buf, byte(delta)<<4|typ)
+	} else {
+		e.buf = append(e.buf, typ)
+		e.varint(int64(id))
+	}
+	if len(e.ids) > 0 {
+		e.ids[len(e.ids)-1] = id
+	}
+}
+
+func (e *thriftEncoder) i32(id int16, n int32) {
+	e.field(id, tI32)
+	e.varint(int64(n))
+}
+
+func (e *thriftEncoder) i64(id int16, n int64) {
+	e.field(id, tI64)
+	e.varint(n)
+}
+
+func (e *thriftEncoder) bool(id int16, b bool) {
+	if b {
+		e.field(id, tBoolTrue)
+	} else {
+		e.field(id, tBoolFalse)
+	}
+}
+
+func (e *thriftEncoder) string(id int16, s string) {
+	e.field(id, tBinary)
+	e.uvarint(uint64(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// list starts a list of n elements of type elem. Struct elements are each
+// written with begin and end.
+func (e *thriftEncoder) list(id int16, elem byte, n int) {
+	e.field(id, tList)
+	if n < 15 {
+		e.buf = append(e.buf, byte(n)<<4|elem)
+		return
+	}
+	e.buf = append(e.buf, 0xf0|elem)
+	e.uvarint(uint64(n))
+}
+
+// strct starts a struct field, which must be ended with end.
+func (e *thriftEncoder) strct(id int16) {
+	e.field(id, tStruct)
+	e.begin()
+}
+
+// begin starts a struct that is not a field, such as a list element or a
+// top-level struct.
+func (e *thriftEncoder) begin() {
+	e.ids = append(e.ids, 0)
+}
+
+func (e *thriftEncoder) end() {
+	e.buf = append(e.buf, tStop)
+	e.ids = e.ids[:len(e.ids)-1]
+}
@@ -0,0 +1,460 @@
+package parquet
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// DefaultRowGroupSize is the number of rows a Writer buffers in memory before
+// writing them out as a row group.
+const DefaultRowGroupSize = 64 * 1024
+
+// createdBy is recorded as the writer of every file.
+const createdBy = "bhojpur-data"
+
+// Writer writes rows to a Parquet file. Nothing is readable until Close
+// writes the file's metadata.
+type Writer struct {
+	w       io.Writer
+	offset  int64
+	columns []*columnSchema
+	// RowGroupSize is the number of rows in each row group.
+	RowGroupSize int
+	// values holds the buffered values of the current row group, by column.
+	values    [][]interface{}
+	rowGroups []writtenRowGroup
+	numRows   int64
+	err       error
+}
+
+type writtenRowGroup struct {
+	numRows int64
+	size    int64
+	chunks  []writtenChunk
+}
+
+type writtenChunk struct {
+	offset           int64
+	compressedSize   int64
+	uncompressedSize int64
+}
+
+// NewWriter starts a Parquet file with the given columns on w.
+func NewWriter(w io.Writer, columns []Column) (*Writer, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("a parquet file needs at least one column")
+	}
+	pw := &Writer{w: w, RowGroupSize: DefaultRowGroupSize, values: make([][]interface{}, len(columns))}
+	names := make(map[string]bool)
+	for _, c := range columns {
+		if c.Name == "" || names[c.Name] {
+			return nil, errors.Errorf("invalid parquet column name %q", c.Name)
+		}
+		names[c.Name] = true
+		cs := &columnSchema{Column: c}
+		switch c.Kind {
+		case Bool:
+			cs.physical = typeBoolean
+		case Int32, Date:
+			cs.physical = typeInt32
+		case Int64:
+			cs.physical = typeInt64
+		case Timestamp:
+			cs.physical, cs.unit = typeInt64, unitMicros
+		case Float:
+			cs.physical = typeFloat
+		case Double:
+			cs.physical = typeDouble
+		case String, Bytes:
+			cs.physical = typeByteArray
+		default:
+			return nil, errors.Errorf("invalid kind %v of parquet column %s", c.Kind, c.Name)
+		}
+		pw.columns = append(pw.columns, cs)
+	}
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// Write buffers a row, which must have a value for every column. Values are
+// converted to the column's Kind where that is lossless, so for example an
+// int64 can be written to an Int32 column if it fits, and a string to a
+// Double column if it parses.
+func (w *Writer) Write(row []interface{}) error {
+	if w.err != nil {
+		return w.err
+	}
+	if len(row) != len(w.columns) {
+		return errors.Errorf("row has %d values, expected %d", len(row), len(w.columns))
+	}
+	converted := make([]interface{}, len(row))
+	for i, c := range w.columns {
+		v, err := c.physicalValue(row[i])
+		if err != nil {
+			return errors.Wrapf(err, "invalid value for parquet column %s", c.Name)
+		}
+		if v == nil && c.Required {
+			return errors.Errorf("parquet column %s cannot be null", c.Name)
+		}
+		converted[i] = v
+	}
+	for i, v := range converted {
+		w.values[i] = append(w.values[i], v)
+	}
+	if len(w.values[0]) >= w.RowGroupSize {
+		return w.flush()
+	}
+	return nil
+}
+
+// Close writes any buffered rows and the file's metadata. It does not close
+// the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	footer := w.encodeFileMetadata()
+	footer = append(footer, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(footer[len(footer)-4:], uint32(len(footer)-4))
+	footer = append(footer, magic...)
+	return w.write(footer)
+}
+
+func (w *Writer) write(b []byte) error {
+	if w.err != nil {
+		return w.err
+	}
+	n, err := w.w.Write(b)
+	w.offset += int64(n)
+	if err != nil {
+		w.err = errors.EnsureStack(err)
+	}
+	return w.err
+}
+
+// flush writes the buffered rows as a row group, with a single page per
+// column.
+func (w *Writer) flush() error {
+	numRows := len(w.values[0])
+	if numRows == 0 {
+		return nil
+	}
+	g := writtenRowGroup{numRows: int64(numRows)}
+	for i, c := range w.columns {
+		var body []byte
+		if !c.Required {
+			levels := make([]int32, numRows)
+			for j, v := range w.values[i] {
+				if v != nil {
+					levels[j] = 1
+				}
+			}
+			encoded := encodeHybrid(levels)
+			body = append(body, 0, 0, 0, 0)
+			binary.LittleEndian.PutUint32(body, uint32(len(encoded)))
+			body = append(body, encoded...)
+		}
+		body = c.encodePlain(body, w.values[i])
+		compressed := snappy.Encode(nil, body)
+		header := encodePageHeader(len(body), len(compressed), numRows)
+		chunk := writtenChunk{
+			offset:           w.offset,
+			compressedSize:   int64(len(header) + len(compressed)),
+			uncompressedSize: int64(len(header) + len(body)),
+		}
+		if err := w.write(header); err != nil {
+			return err
+		}
+		if err := w.write(compressed); err != nil {
+			return err
+		}
+		g.chunks = append(g.chunks, chunk)
+		g.size += chunk.uncompressedSize
+		w.values[i] = w.values[i][:0]
+	}
+	w.rowGroups = append(w.rowGroups, g)
+	w.numRows += int64(numRows)
+	return nil
+}
+
+func (c *columnSchema) encodePlain(buf []byte, values []interface{}) []byte {
+	var bits []byte
+	var nbits int
+	for _, v := range values {
+		switch v := v.(type) {
+		case nil:
+			continue
+		case bool:
+			if nbits%8 == 0 {
+				bits = append(bits, 0)
+			}
+			if v {
+				bits[nbits/8] |= 1 << (nbits % 8)
+			}
+			nbits++
+		case int32:
+			buf = appendUint32(buf, uint32(v))
+		case int64:
+			buf = appendUint64(buf, uint64(v))
+		case float32:
+			buf = appendUint32(buf, math.Float32bits(v))
+		case float64:
+			buf = appendUint64(buf, math.Float64bits(v))
+		case []byte:
+			buf = appendUint32(buf, uint32(len(v)))
+			buf = append(buf, v...)
+		}
+	}
+	return append(buf, bits...)
+}
+
+func appendUint32(buf []byte, n uint32) []byte {
+	return append(buf, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+}
+
+func appendUint64(buf []byte, n uint64) []byte {
+	return appendUint32(appendUint32(buf, uint32(n)), uint32(n>>32))
+}
+
+// physicalValue converts a value to c's physical type, or returns nil for a
+// null.
+func (c *columnSchema) physicalValue(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch c.Kind {
+	case Bool:
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, err := strconv.ParseBool(v)
+			return b, errors.EnsureStack(err)
+		}
+	case Int32, Int64:
+		n, err := toInt64(v)
+		if err != nil {
+			return nil, err
+		}
+		if c.Kind == Int64 {
+			return n, nil
+		}
+		if n < math.MinInt32 || n > math.MaxInt32 {
+			return nil, errors.Errorf("%d overflows int32", n)
+		}
+		return int32(n), nil
+	case Float, Double:
+		f, err := toFloat64(v)
+		if err != nil {
+			return nil, err
+		}
+		if c.Kind == Double {
+			return f, nil
+		}
+		return float32(f), nil
+	case String, Bytes:
+		switch v := v.(type) {
+		case string:
+			return []byte(v), nil
+		case []byte:
+			b := make([]byte, len(v))
+			copy(b, v)
+			return b, nil
+		case fmt.Stringer:
+			return []byte(v.String()), nil
+		}
+	case Date, Timestamp:
+		t, ok := v.(time.Time)
+		if !ok {
+			s, isString := v.(string)
+			if !isString {
+				break
+			}
+			var err error
+			if t, err = time.Parse(time.RFC3339Nano, s); err != nil {
+				if t, err = time.Parse("2006-01-02", s); err != nil {
+					return nil, errors.Errorf("cannot parse %q as a time", s)
+				}
+			}
+		}
+		if c.Kind == Timestamp {
+			return t.Unix()*1e6 + int64(t.Nanosecond()/1e3), nil
+		}
+		y, m, d := t.Date()
+		return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400), nil
+	}
+	return nil, errors.Errorf("cannot convert %T to %v", v, c.Kind)
+}
+
+func toInt64(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, errors.EnsureStack(err)
+	case []byte:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		return n, errors.EnsureStack(err)
+	}
+	return 0, errors.Errorf("cannot convert %T to an integer", v)
+}
+
+func toFloat64(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, errors.EnsureStack(err)
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, errors.EnsureStack(err)
+	}
+	n, err := toInt64(v)
+	return float64(n), err
+}
+
+func encodePageHeader(uncompressedSize, compressedSize, numValues int) []byte {
+	e := &thriftEncoder{}
+	e.begin()
+	e.i32(1, pageData)
+	e.i32(2, int32(uncompressedSize))
+	e.i32(3, int32(compressedSize))
+	e.strct(5)
+	e.i32(1, int32(numValues))
+	e.i32(2, encodingPlain)
+	e.i32(3, encodingRLE)
+	e.i32(4, encodingRLE)
+	e.end()
+	e.end()
+	return e.buf
+}
+
+func (w *Writer) encodeFileMetadata() []byte {
+	e := &thriftEncoder{}
+	e.begin()
+	e.i32(1, 1)
+	e.list(2, tStruct, len(w.columns)+1)
+	e.begin()
+	e.string(4, "schema")
+	e.i32(5, int32(len(w.columns)))
+	e.end()
+	for _, c := range w.columns {
+		e.begin()
+		e.i32(1, c.physical)
+		if c.Required {
+			e.i32(3, repetitionRequired)
+		} else {
+			e.i32(3, repetitionOptional)
+		}
+		e.string(4, c.Name)
+		switch c.Kind {
+		case String:
+			e.i32(6, convertedUTF8)
+			e.strct(10)
+			e.strct(1)
+			e.end()
+			e.end()
+		case Date:
+			e.i32(6, convertedDate)
+			e.strct(10)
+			e.strct(6)
+			e.end()
+			e.end()
+		case Timestamp:
+			e.i32(6, convertedTimestampMicros)
+			e.strct(10)
+			e.strct(8)
+			e.bool(1, true)
+			e.strct(2)
+			e.strct(2)
+			e.end()
+			e.end()
+			e.end()
+			e.end()
+		}
+		e.end()
+	}
+	e.i64(3, w.numRows)
+	e.list(4, tStruct, len(w.rowGroups))
+	for _, g := range w.rowGroups {
+		e.begin()
+		e.list(1, tStruct, len(g.chunks))
+		for i, chunk := range g.chunks {
+			c := w.columns[i]
+			e.begin()
+			e.i64(2, chunk.offset)
+			e.strct(3)
+			e.i32(1, c.physical)
+			e.list(2, tI32, 2)
+			e.varint(int64(encodingPlain))
+			e.varint(int64(encodingRLE))
+			e.list(3, tBinary, 1)
+			e.uvarint(uint64(len(c.Name)))
+			e.buf = append(e.buf, c.Name...)
+			e.i32(4, codecSnappy)
+			e.i64(5, g.numRows)
+			e.i64(6, chunk.uncompressedSize)
+			e.i64(7, chunk.compressedSize)
+			e.i64(9, chunk.offset)
+			e.end()
+			e.end()
+		}
+		e.i64(2, g.size)
+		e.i64(3, g.numRows)
+		e.end()
+	}
+	e.string(6, createdBy)
+	e.end()
+	return e.buf
+}
@@ -0,0 +1,281 @@
+// Package split cuts a stream of records into files of a target size, so that
+// one large input can be processed in parallel. Files are cut on record
+// boundaries only, and records of formats with a header, such as CSV, are
+// preceded by the header in every file.
+package split
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// Format identifies how the records of a stream are delimited.
+type Format int
+
+const (
+	// Line records are lines, terminated by '\n'.
+	Line Format = iota
+	// JSON records are consecutive JSON values.
+	JSON
+	// SQL records are the rows of the COPY statement in a pg_dump of a
+	// single table. The statements preceding the rows form the header, and
+	// the statements following them are added to the last file.
+	SQL
+	// CSV records are CSV rows, the first of which is the header.
+	CSV
+)
+
+// Options controls how many records go into each file. A file is cut once it
+// holds TargetRecords records or its records total at least TargetBytes
+// bytes, whichever comes first. If neither is set each record gets its own
+// file.
+type Options struct {
+	TargetRecords int64
+	TargetBytes   int64
+}
+
+// Split reads the records of r and calls f with the content of each file, in
+// order, numbering them from 0.
+func Split(r io.Reader, format Format, opts Options, f func(i int, data []byte) error) error {
+	rr, err := newRecordReader(r, format)
+	if err != nil {
+		return err
+	}
+	if opts.TargetRecords <= 0 && opts.TargetBytes <= 0 {
+		opts.TargetRecords = 1
+	}
+	var buf bytes.Buffer
+	var i int
+	var records, size int64
+	// read a record ahead, so that the last file is known when it is cut
+	record, err := rr.next()
+	for err == nil {
+		buf.Write(record)
+		records++
+		size += int64(len(record))
+		record, err = rr.next()
+		if err != nil && err != io.EOF {
+			return err
+		}
+		last := err == io.EOF
+		if !last && (opts.TargetRecords <= 0 || records < opts.TargetRecords) && (opts.TargetBytes <= 0 || size < opts.TargetBytes) {
+			continue
+		}
+		data := append(append([]byte{}, rr.header()...), buf.Bytes()...)
+		if last {
+			data = append(data, rr.footer()...)
+		} else {
+			data = append(data, rr.terminator()...)
+		}
+		if err := f(i, data); err != nil {
+			return err
+		}
+		buf.Reset()
+		records, size = 0, 0
+		i++
+	}
+	if err != io.EOF {
+		return err
+	}
+	return nil
+}
+
+// recordReader reads the records of a stream one at a time.
+type recordReader interface {
+	// next returns the next record, or io.EOF once there are no more.
+	next() ([]byte, error)
+	// header returns what precedes the records in every file.
+	header() []byte
+	// terminator returns what follows the records in every file but the
+	// last.
+	terminator() []byte
+	// footer returns what follows the records in the last file. It is only
+	// valid once next has returned io.EOF.
+	footer() []byte
+}
+
+func newRecordReader(r io.Reader, format Format) (recordReader, error) {
+	switch format {
+	case Line:
+		return &lineReader{r: bufio.NewReader(r)}, nil
+	case JSON:
+		return &jsonReader{dec: json.NewDecoder(r)}, nil
+	case SQL:
+		return newSQLReader(r)
+	case CSV:
+		return newCSVReader(r)
+	default:
+		return nil, errors.Errorf("unknown split format %d", format)
+	}
+}
+
+type lineReader struct {
+	r *bufio.Reader
+}
+
+func (r *lineReader) next() ([]byte, error) {
+	line, err := r.r.ReadBytes('\n')
+	if err == io.EOF && len(line) > 0 {
+		return append(line, '\n'), nil
+	}
+	return line, errors.EnsureStack(err)
+}
+
+func (r *lineReader) header() []byte     { return nil }
+func (r *lineReader) terminator() []byte { return nil }
+func (r *lineReader) footer() []byte     { return nil }
+
+type jsonReader struct {
+	dec *json.Decoder
+}
+
+func (r *jsonReader) next() ([]byte, error) {
+	var value json.RawMessage
+	if err := r.dec.Decode(&value); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrapf(err, "invalid JSON record")
+	}
+	return append(value, '\n'), nil
+}
+
+func (r *jsonReader) header() []byte     { return nil }
+func (r *jsonReader) terminator() []byte { return nil }
+func (r *jsonReader) footer() []byte     { return nil }
+
+// sqlEndOfData ends the rows of a COPY ... FROM stdin statement.
+const sqlEndOfData = "\\.\n"
+
+type sqlReader struct {
+	r    *bufio.Reader
+	head []byte
+	foot []byte
+	done bool
+}
+
+func newSQLReader(r io.Reader) (*sqlReader, error) {
+	sr := &sqlReader{r: bufio.NewReader(r)}
+	for {
+		line, err := sr.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, errors.EnsureStack(err)
+		}
+		sr.head = append(sr.head, line...)
+		if isCopyFromStdin(line) {
+			return sr, nil
+		}
+		if err == io.EOF {
+			return nil, errors.New("invalid SQL dump: no COPY ... FROM stdin statement")
+		}
+	}
+}
+
+func isCopyFromStdin(line []byte) bool {
+	s := strings.TrimSpace(string(line))
+	return strings.HasPrefix(s, "COPY ") && strings.HasSuffix(s, "FROM stdin;")
+}
+
+func (r *sqlReader) next() ([]byte, error) {
+	if r.done {
+		return nil, io.EOF
+	}
+	line, err := r.r.ReadBytes('\n')
+	if err != nil && err != io.EOF {
+		return nil, errors.EnsureStack(err)
+	}
+	if string(line) == sqlEndOfData || string(line) == `\.` {
+		r.done = true
+		return nil, r.readFooter()
+	}
+	if err == io.EOF {
+		return nil, errors.New(`invalid SQL dump: COPY data is not terminated by "\."`)
+	}
+	return line, nil
+}
+
+// readFooter reads the statements following the rows, returning io.EOF once
+// they have all been read.
+func (r *sqlReader) readFooter() error {
+	r.foot = []byte(sqlEndOfData)
+	for {
+		line, err := r.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return errors.EnsureStack(err)
+		}
+		if isCopyFromStdin(line) {
+			return errors.New("invalid SQL dump: only dumps of a single table can be split")
+		}
+		r.foot = append(r.foot, line...)
+		if err == io.EOF {
+			return io.EOF
+		}
+	}
+}
+
+func (r *sqlReader) header() []byte     { return r.head }
+func (r *sqlReader) terminator() []byte { return []byte(sqlEndOfData) }
+func (r *sqlReader) footer() []byte     { return r.foot }
+
+type csvReader struct {
+	r    *csv.Reader
+	head []byte
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	cr := &csvReader{r: csv.NewReader(r)}
+	cr.r.FieldsPerRecord = -1
+	head, err := cr.next()
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	cr.head = head
+	return cr, nil
+}
+
+func (r *csvReader) next() ([]byte, error) {
+	fields, err := r.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		return nil, errors.Wrapf(err, "invalid CSV record")
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(fields); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	w.Flush()
+	return buf.Bytes(), errors.EnsureStack(w.Error())
+}
+
+func (r *csvReader) header() []byte     { return r.head }
+func (r *csvReader) terminator() []byte { return nil }
+func (r *csvReader) footer() []byte     { return nil }
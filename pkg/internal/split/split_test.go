@@ -0,0 +1,85 @@
+package split
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func split(t *testing.T, input string, format Format, opts Options) []string {
+	var files []string
+	require.NoError(t, Split(strings.NewReader(input), format, opts, func(i int, data []byte) error {
+		require.Equal(t, len(files), i)
+		files = append(files, string(data))
+		return nil
+	}))
+	return files
+}
+
+func TestSplitLines(t *testing.T) {
+	input := "a\nb\nc\nd\ne"
+	require.Equal(t, []string{"a\n", "b\n", "c\n", "d\n", "e\n"}, split(t, input, Line, Options{}))
+	require.Equal(t, []string{"a\nb\n", "c\nd\n", "e\n"}, split(t, input, Line, Options{TargetRecords: 2}))
+	require.Equal(t, []string{"a\nb\nc\n", "d\ne\n"}, split(t, input, Line, Options{TargetBytes: 5}))
+	require.Equal(t, []string{"a\nb\n", "c\nd\n", "e\n"}, split(t, input, Line, Options{TargetRecords: 2, TargetBytes: 100}))
+	require.Equal(t, 0, len(split(t, "", Line, Options{})))
+}
+
+func TestSplitJSON(t *testing.T) {
+	input := `{"a": 1} {"b": [1, 2]}
+	"c" 4`
+	require.Equal(t, []string{"{\"a\": 1}\n{\"b\": [1, 2]}\n", "\"c\"\n4\n"}, split(t, input, JSON, Options{TargetRecords: 2}))
+	err := Split(strings.NewReader(`{"a": 1} {"b"`), JSON, Options{}, func(int, []byte) error { return nil })
+	require.YesError(t, err)
+}
+
+func TestSplitCSV(t *testing.T) {
+	input := "name,note\nalice,\"multi\nline\"\nbob,x\ncarol,y\n"
+	require.Equal(t, []string{
+		"name,note\nalice,\"multi\nline\"\nbob,x\n",
+		"name,note\ncarol,y\n",
+	}, split(t, input, CSV, Options{TargetRecords: 2}))
+	require.Equal(t, 0, len(split(t, "name,note\n", CSV, Options{})))
+}
+
+func TestSplitSQL(t *testing.T) {
+	header := "SET client_encoding = 'UTF8';\nCREATE TABLE public.t (a integer, b text);\nCOPY public.t (a, b) FROM stdin;\n"
+	footer := "ALTER TABLE ONLY public.t ADD CONSTRAINT t_pkey PRIMARY KEY (a);\n"
+	input := header + "1\tone\n2\ttwo\n3\tthree\n\\.\n" + footer
+	require.Equal(t, []string{
+		header + "1\tone\n2\ttwo\n\\.\n",
+		header + "3\tthree\n\\.\n" + footer,
+	}, split(t, input, SQL, Options{TargetRecords: 2}))
+	require.Equal(t, []string{
+		header + "1\tone\n2\ttwo\n3\tthree\n\\.\n" + footer,
+	}, split(t, input, SQL, Options{TargetRecords: 3}))
+
+	for _, input := range []string{
+		"CREATE TABLE t (a integer);\n",
+		header + "1\tone\n",
+		input + "COPY public.u (a) FROM stdin;\n1\n\\.\n",
+	} {
+		require.YesError(t, Split(strings.NewReader(input), SQL, Options{}, func(int, []byte) error { return nil }))
+	}
+}
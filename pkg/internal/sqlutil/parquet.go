@@ -0,0 +1,110 @@
+package sqlutil
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql"
+	"io"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/parquet"
+)
+
+type parquetReader struct {
+	r *parquet.Reader
+	// indices are the indices of the selected columns in the file.
+	indices []int
+}
+
+// NewParquetReader returns a RowReader for the named columns of the Parquet
+// file of the given size in r. Columns are matched by name, so the file may
+// have its columns in any order, as well as columns that are not selected.
+func NewParquetReader(r io.ReaderAt, size int64, columns []string) (RowReader, error) {
+	pr, err := parquet.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	indices := make(map[string]int)
+	for i, c := range pr.Columns() {
+		indices[c.Name] = i
+	}
+	selected := make([]int, len(columns))
+	for i, name := range columns {
+		idx, ok := indices[name]
+		if !ok {
+			return nil, errors.Errorf("parquet file has no column %s", name)
+		}
+		selected[i] = idx
+	}
+	return &parquetReader{r: pr, indices: selected}, nil
+}
+
+func (r *parquetReader) Read() ([]interface{}, error) {
+	values, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	row := make([]interface{}, len(r.indices))
+	for i, idx := range r.indices {
+		row[i] = values[idx]
+	}
+	return row, nil
+}
+
+// NewParquetWriter returns a RowWriter that writes rows with the given
+// columns of a query result to w as a Parquet file.
+func NewParquetWriter(w io.Writer, columns []*sql.ColumnType) (RowWriter, error) {
+	return parquet.NewWriter(w, ParquetColumns(columns))
+}
+
+// ParquetColumns returns the Parquet columns that hold the values of the
+// given columns of a query result. Postgres types without an exact Parquet
+// counterpart, such as NUMERIC, are written as strings so that no precision
+// is lost.
+func ParquetColumns(columns []*sql.ColumnType) []parquet.Column {
+	pcs := make([]parquet.Column, len(columns))
+	for i, c := range columns {
+		pc := parquet.Column{Name: c.Name(), Kind: parquet.String}
+		if nullable, ok := c.Nullable(); ok && !nullable {
+			pc.Required = true
+		}
+		switch c.DatabaseTypeName() {
+		case "BOOL":
+			pc.Kind = parquet.Bool
+		case "INT2", "INT4":
+			pc.Kind = parquet.Int32
+		case "INT8":
+			pc.Kind = parquet.Int64
+		case "FLOAT4":
+			pc.Kind = parquet.Float
+		case "FLOAT8":
+			pc.Kind = parquet.Double
+		case "BYTEA":
+			pc.Kind = parquet.Bytes
+		case "DATE":
+			pc.Kind = parquet.Date
+		case "TIMESTAMP", "TIMESTAMPTZ":
+			pc.Kind = parquet.Timestamp
+		}
+		pcs[i] = pc
+	}
+	return pcs
+}
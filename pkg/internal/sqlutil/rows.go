@@ -0,0 +1,283 @@
+package sqlutil
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// RowReader reads the rows of a file. Every row has one value per column;
+// a nil value is NULL.
+type RowReader interface {
+	// Read returns the next row, or io.EOF once there are no rows left.
+	Read() ([]interface{}, error)
+}
+
+// RowWriter writes rows to a file. Close must be called once all rows have
+// been written, but it does not close the underlying writer.
+type RowWriter interface {
+	Write(row []interface{}) error
+	Close() error
+}
+
+// WriteRows writes every row of rows to w, and returns the number of rows
+// written. It closes neither rows nor w.
+func WriteRows(rows *sql.Rows, w RowWriter) (int64, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	dest := make([]interface{}, len(columns))
+	var n int64
+	for rows.Next() {
+		row := make([]interface{}, len(columns))
+		for i := range row {
+			dest[i] = &row[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		if err := w.Write(row); err != nil {
+			return 0, err
+		}
+		n++
+	}
+	return n, errors.EnsureStack(rows.Err())
+}
+
+type csvReader struct {
+	r       *csv.Reader
+	columns []string
+	started bool
+}
+
+// NewCSVReader returns a RowReader for CSV records whose fields are the given
+// columns, in order. A first record that names the columns, as written by
+// NewCSVWriter, is a header and is skipped. As in Postgres' CSV format, an
+// empty field is NULL; empty strings cannot be told apart from NULL, and
+// read as NULL.
+func NewCSVReader(r io.Reader, columns []string) RowReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(columns)
+	cr.ReuseRecord = true
+	return &csvReader{r: cr, columns: columns}
+}
+
+func (r *csvReader) Read() ([]interface{}, error) {
+	record, err := r.r.Read()
+	if err == nil && !r.started {
+		r.started = true
+		if r.isHeader(record) {
+			record, err = r.r.Read()
+		}
+	}
+	if err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, errors.EnsureStack(err)
+	}
+	row := make([]interface{}, len(record))
+	for i, field := range record {
+		if field != "" {
+			row[i] = field
+		}
+	}
+	return row, nil
+}
+
+// isHeader returns true if record names the reader's columns. Column names
+// are compared as Postgres compares unquoted identifiers, ignoring case.
+func (r *csvReader) isHeader(record []string) bool {
+	for i, field := range record {
+		if !strings.EqualFold(field, r.columns[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type jsonReader struct {
+	dec     *json.Decoder
+	columns []string
+}
+
+// NewJSONReader returns a RowReader for a stream of JSON objects, such as
+// JSON lines. Each row has the values of the object's fields named after
+// the columns. Missing fields and nulls are NULL, and arrays and objects are
+// kept as JSON.
+func NewJSONReader(r io.Reader, columns []string) RowReader {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	return &jsonReader{dec: dec, columns: columns}
+}
+
+func (r *jsonReader) Read() ([]interface{}, error) {
+	var object map[string]json.RawMessage
+	if err := r.dec.Decode(&object); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, errors.Wrapf(err, "invalid JSON object")
+	}
+	row := make([]interface{}, len(r.columns))
+	for i, column := range r.columns {
+		raw, ok := object[column]
+		if !ok {
+			continue
+		}
+		v, err := jsonValue(raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid value for column %s", column)
+		}
+		row[i] = v
+	}
+	return row, nil
+}
+
+func jsonValue(raw json.RawMessage) (interface{}, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	switch raw[0] {
+	case '{', '[':
+		return string(raw), nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if n, ok := v.(json.Number); ok {
+		return n.String(), nil
+	}
+	return v, nil
+}
+
+type csvWriter struct {
+	w      *csv.Writer
+	record []string
+}
+
+// NewCSVWriter returns a RowWriter for CSV records, preceded by a header with
+// the names of the columns. NULL is written as an empty field, so that
+// NewCSVReader reads it back as NULL. So is an empty string, which is read
+// back as NULL too.
+func NewCSVWriter(w io.Writer, columns []string) (RowWriter, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(columns); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &csvWriter{w: cw, record: make([]string, len(columns))}, nil
+}
+
+func (w *csvWriter) Write(row []interface{}) error {
+	if len(row) != len(w.record) {
+		return errors.Errorf("row has %d values, but there are %d columns", len(row), len(w.record))
+	}
+	for i, v := range row {
+		w.record[i] = textValue(v)
+	}
+	return errors.EnsureStack(w.w.Write(w.record))
+}
+
+func (w *csvWriter) Close() error {
+	w.w.Flush()
+	return errors.EnsureStack(w.w.Error())
+}
+
+// textValue formats a value scanned from a query result as text.
+func textValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type jsonWriter struct {
+	w       io.Writer
+	columns [][]byte
+	buf     bytes.Buffer
+}
+
+// NewJSONWriter returns a RowWriter for JSON lines, with one object per row
+// whose fields are named after the columns. Text, and values that have no
+// JSON counterpart, such as NUMERIC, are written as strings.
+func NewJSONWriter(w io.Writer, columns []string) (RowWriter, error) {
+	jw := &jsonWriter{w: w, columns: make([][]byte, len(columns))}
+	for i, c := range columns {
+		name, err := json.Marshal(c)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		jw.columns[i] = name
+	}
+	return jw, nil
+}
+
+func (w *jsonWriter) Write(row []interface{}) error {
+	if len(row) != len(w.columns) {
+		return errors.Errorf("row has %d values, but there are %d columns", len(row), len(w.columns))
+	}
+	w.buf.Reset()
+	w.buf.WriteByte('{')
+	for i, v := range row {
+		if i > 0 {
+			w.buf.WriteByte(',')
+		}
+		w.buf.Write(w.columns[i])
+		w.buf.WriteByte(':')
+		switch v.(type) {
+		case nil, bool, int64, float64:
+		default:
+			v = textValue(v)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		w.buf.Write(value)
+	}
+	w.buf.WriteString("}\n")
+	_, err := w.w.Write(w.buf.Bytes())
+	return errors.EnsureStack(err)
+}
+
+func (w *jsonWriter) Close() error { return nil }
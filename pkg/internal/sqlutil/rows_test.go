@@ -0,0 +1,141 @@
+package sqlutil
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bhojpur/data/pkg/internal/parquet"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func readRows(t *testing.T, r RowReader) [][]interface{} {
+	var rows [][]interface{}
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			return rows
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+}
+
+func TestCSVReader(t *testing.T) {
+	r := NewCSVReader(strings.NewReader("1,alice,\n2,\"bob, jr\",3.5\n"), []string{"id", "name", "score"})
+	require.Equal(t, [][]interface{}{
+		{"1", "alice", nil},
+		{"2", "bob, jr", "3.5"},
+	}, readRows(t, r))
+
+	// a header naming the columns is skipped, but only as the first record
+	r = NewCSVReader(strings.NewReader("ID,name,score\n1,alice,\nid,name,score\n"), []string{"id", "name", "score"})
+	require.Equal(t, [][]interface{}{
+		{"1", "alice", nil},
+		{"id", "name", "score"},
+	}, readRows(t, r))
+
+	r = NewCSVReader(strings.NewReader("1,alice\n"), []string{"id", "name", "score"})
+	_, err := r.Read()
+	require.YesError(t, err)
+}
+
+func TestJSONReader(t *testing.T) {
+	input := `{"id": 1, "name": "alice", "tags": ["a", "b"]}
+{"id": 2.50, "name": null, "ok": true, "extra": "ignored"}
+`
+	r := NewJSONReader(strings.NewReader(input), []string{"id", "name", "tags", "ok"})
+	require.Equal(t, [][]interface{}{
+		{"1", "alice", `["a", "b"]`, nil},
+		{"2.50", nil, nil, true},
+	}, readRows(t, r))
+
+	r = NewJSONReader(strings.NewReader(`[1, 2]`), []string{"id"})
+	_, err := r.Read()
+	require.YesError(t, err)
+}
+
+func TestParquetReader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := parquet.NewWriter(&buf, []parquet.Column{
+		{Name: "name", Kind: parquet.String},
+		{Name: "id", Kind: parquet.Int64, Required: true},
+		{Name: "unused", Kind: parquet.Bool},
+	})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{"alice", int64(1), true}))
+	require.NoError(t, w.Write([]interface{}{nil, int64(2), false}))
+	require.NoError(t, w.Close())
+
+	r, err := NewParquetReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), []string{"id", "name"})
+	require.NoError(t, err)
+	require.Equal(t, [][]interface{}{
+		{int64(1), "alice"},
+		{int64(2), nil},
+	}, readRows(t, r))
+
+	_, err = NewParquetReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()), []string{"missing"})
+	require.YesError(t, err)
+}
+
+func TestCSVWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewCSVWriter(&buf, []string{"id", "name", "joined"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{int64(1), "bob, jr", time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)}))
+	require.NoError(t, w.Write([]interface{}{int64(2), []byte("alice"), nil}))
+	require.YesError(t, w.Write([]interface{}{int64(3)}))
+	require.NoError(t, w.Close())
+	require.Equal(t, "id,name,joined\n1,\"bob, jr\",2021-03-04T05:06:07Z\n2,alice,\n", buf.String())
+
+	// what is written reads back as the same rows, with empty strings as
+	// NULL
+	buf.Reset()
+	w, err = NewCSVWriter(&buf, []string{"id", "name"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{int64(1), ""}))
+	require.NoError(t, w.Close())
+	r := NewCSVReader(&buf, []string{"id", "name"})
+	require.Equal(t, [][]interface{}{{"1", nil}}, readRows(t, r))
+}
+
+func TestJSONWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewJSONWriter(&buf, []string{"id", "name", "score", "ok"})
+	require.NoError(t, err)
+	require.NoError(t, w.Write([]interface{}{int64(1), "alice", []byte("3.50"), true}))
+	require.NoError(t, w.Write([]interface{}{int64(2), nil, 2.5, false}))
+	require.NoError(t, w.Close())
+	require.Equal(t, `{"id":1,"name":"alice","score":"3.50","ok":true}
+{"id":2,"name":null,"score":2.5,"ok":false}
+`, buf.String())
+
+	// what is written reads back as the same rows
+	r := NewJSONReader(&buf, []string{"id", "name", "score", "ok"})
+	require.Equal(t, [][]interface{}{
+		{"1", "alice", "3.50", true},
+		{"2", nil, "2.5", false},
+	}, readRows(t, r))
+}
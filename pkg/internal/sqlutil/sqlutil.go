@@ -0,0 +1,109 @@
+// Package sqlutil moves rows between files and SQL databases. A RowReader
+// decodes the rows of a file in one of the supported formats, and LoadTable
+// bulk loads them into a Postgres table.
+package sqlutil
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"strings"
+
+	"github.com/lib/pq"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// TableColumns returns the columns of table, in the order they were defined.
+// The table name may be qualified with a schema, as in "schema.table";
+// otherwise it is looked up in the current schema.
+func TableColumns(ctx context.Context, tx *sql.Tx, table string) ([]string, error) {
+	schema, name := splitTable(table)
+	rows, err := tx.QueryContext(ctx, `SELECT column_name FROM information_schema.columns
+		WHERE table_schema = COALESCE(NULLIF($1, ''), current_schema()) AND table_name = $2
+		ORDER BY ordinal_position`, schema, name)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	defer rows.Close()
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		columns = append(columns, column)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if len(columns) == 0 {
+		return nil, errors.Errorf("table %s does not exist", table)
+	}
+	return columns, nil
+}
+
+// LoadTable copies every row of r into the given columns of table, and
+// returns the number of rows copied. The rows are only visible to others
+// once tx commits.
+func LoadTable(ctx context.Context, tx *sql.Tx, table string, columns []string, r RowReader) (int64, error) {
+	schema, name := splitTable(table)
+	query := pq.CopyIn(name, columns...)
+	if schema != "" {
+		query = pq.CopyInSchema(schema, name, columns...)
+	}
+	stmt, err := tx.PrepareContext(ctx, query)
+	if err != nil {
+		return 0, errors.Wrapf(err, "cannot load table %s", table)
+	}
+	defer stmt.Close()
+	var n int64
+	for {
+		row, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		if len(row) != len(columns) {
+			return 0, errors.Errorf("row %d of table %s has %d values, expected %d", n+1, table, len(row), len(columns))
+		}
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return 0, errors.Wrapf(err, "cannot load row %d of table %s", n+1, table)
+		}
+		n++
+	}
+	// flushes the copied rows
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return 0, errors.Wrapf(err, "cannot load table %s", table)
+	}
+	return n, nil
+}
+
+func splitTable(table string) (schema, name string) {
+	if i := strings.Index(table, "."); i >= 0 {
+		return table[:i], table[i+1:]
+	}
+	return "", table
+}
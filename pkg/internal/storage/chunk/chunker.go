@@ -0,0 +1,94 @@
+package chunk
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"io"
+	"math/bits"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+const (
+	// DefaultMinSize is the default minimum size of a chunk, other than the
+	// last chunk of a stream.
+	DefaultMinSize = 256 * 1024
+	// DefaultAverageBits is the default log2 of the average chunk size.
+	DefaultAverageBits = 20
+	// DefaultMaxSize is the default maximum size of a chunk.
+	DefaultMaxSize = 8 * 1024 * 1024
+
+	windowSize = 64
+)
+
+// buzTable maps every byte to a pseudo-random value for the rolling hash. It
+// is generated from a fixed seed because chunk boundaries, and therefore
+// deduplication, depend on it.
+var buzTable = func() [256]uint32 {
+	var t [256]uint32
+	x := uint64(0x9e3779b97f4a7c15)
+	for i := range t {
+		// splitmix64
+		x += 0x9e3779b97f4a7c15
+		z := x
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		t[i] = uint32(z ^ (z >> 31))
+	}
+	return t
+}()
+
+// split reads r to the end and calls cb with each content-defined chunk of
+// it. A boundary is placed wherever the rolling hash of the last windowSize
+// bytes matches the average size mask, so inserting or removing bytes only
+// changes the chunks near the edit. The slice passed to cb is only valid
+// until cb returns.
+func split(r io.Reader, opts Options, cb func([]byte) error) error {
+	br := bufio.NewReaderSize(r, 64*1024)
+	mask := uint32(1)<<uint(opts.AverageBits) - 1
+	buf := make([]byte, 0, opts.MinSize)
+	var h uint32
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return errors.EnsureStack(err)
+		}
+		buf = append(buf, b)
+		h = bits.RotateLeft32(h, 1) ^ buzTable[b]
+		if len(buf) > windowSize {
+			h ^= bits.RotateLeft32(buzTable[buf[len(buf)-1-windowSize]], windowSize%32)
+		}
+		if (len(buf) >= opts.MinSize && h&mask == mask) || len(buf) >= opts.MaxSize {
+			if err := cb(buf); err != nil {
+				return err
+			}
+			buf, h = buf[:0], 0
+		}
+	}
+	if len(buf) > 0 {
+		return cb(buf)
+	}
+	return nil
+}
@@ -0,0 +1,347 @@
+package chunk
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/datahash"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+// prefix is the object name prefix under which chunks are stored.
+const prefix = "chunk/"
+
+// ID identifies a chunk by the datahash of its content.
+type ID []byte
+
+// ParseID parses the hex encoding of an ID.
+func ParseID(x string) (ID, error) {
+	if len(x) != 2*datahash.OutputSize {
+		return nil, errors.Errorf("invalid chunk id %q", x)
+	}
+	o, err := datahash.ParseHex([]byte(x))
+	if err != nil {
+		return nil, err
+	}
+	return ID(o[:]), nil
+}
+
+// HexString returns the hex encoding of id.
+func (id ID) HexString() string {
+	return datahash.EncodeHash(id)
+}
+
+func (id ID) objectName() string {
+	return prefix + id.HexString()
+}
+
+// DataRef refers to a range of bytes within a chunk.
+type DataRef struct {
+	ID          ID
+	OffsetBytes int64
+	SizeBytes   int64
+}
+
+// Options configures how a Storage splits data into chunks. Zero values are
+// replaced by the defaults.
+type Options struct {
+	MinSize     int
+	AverageBits int
+	MaxSize     int
+}
+
+// Storage stores data as content-addressed chunks in an object store. Chunks
+// are immutable and named by their hash, so identical content written from
+// any repo or commit is stored once.
+type Storage struct {
+	objC obj.Client
+	opts Options
+
+	// mu guards the state that protects chunks which are being uploaded, but
+	// may not be referenced yet, from garbage collection.
+	mu sync.Mutex
+	// touched is when each recently uploaded chunk was last written or
+	// reused by an upload.
+	touched map[string]time.Time
+	// uploads holds the start time of every running upload.
+	uploads map[*struct{}]time.Time
+}
+
+// NewStorage creates a Storage that keeps its chunks in objC.
+func NewStorage(objC obj.Client, opts Options) *Storage {
+	if opts.MinSize == 0 {
+		opts.MinSize = DefaultMinSize
+	}
+	if opts.AverageBits == 0 {
+		opts.AverageBits = DefaultAverageBits
+	}
+	if opts.MaxSize == 0 {
+		opts.MaxSize = DefaultMaxSize
+	}
+	return &Storage{
+		objC:    objC,
+		opts:    opts,
+		touched: make(map[string]time.Time),
+		uploads: make(map[*struct{}]time.Time),
+	}
+}
+
+// Upload splits the content of r into chunks, stores the chunks that are not
+// already present, and returns references to the content in order.
+func (s *Storage) Upload(ctx context.Context, r io.Reader) ([]DataRef, error) {
+	upload := &struct{}{}
+	s.mu.Lock()
+	s.uploads[upload] = time.Now()
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.uploads, upload)
+	}()
+	var refs []DataRef
+	if err := split(r, s.opts, func(data []byte) error {
+		sum := datahash.Sum(data)
+		id := ID(sum[:])
+		ref := DataRef{ID: id, SizeBytes: int64(len(data))}
+		// touch the chunk before checking whether it exists, so that garbage
+		// collection either sees the touch or has already deleted the chunk
+		// and it is written again
+		s.touch(ref)
+		if err := s.put(ctx, id, data); err != nil {
+			return err
+		}
+		refs = append(refs, ref)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	// the caller has yet to reference the chunks, so they are protected from
+	// garbage collection for a while longer
+	s.touch(refs...)
+	return refs, nil
+}
+
+func (s *Storage) touch(refs ...DataRef) {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ref := range refs {
+		s.touched[string(ref.ID)] = now
+	}
+}
+
+func (s *Storage) put(ctx context.Context, id ID, data []byte) error {
+	exists, err := s.objC.Exists(ctx, id.objectName())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.objC.Put(ctx, id.objectName(), bytes.NewReader(data))
+}
+
+// Get returns the content of the chunk id, verifying that it matches id.
+func (s *Storage) Get(ctx context.Context, id ID) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := s.objC.Get(ctx, id.objectName(), buf); err != nil {
+		if obj.IsNotExist(err) {
+			return nil, dataerr.NewNotExist("chunks", id.HexString())
+		}
+		return nil, err
+	}
+	if err := verify(id, buf.Bytes()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func verify(id ID, data []byte) error {
+	sum := datahash.Sum(data)
+	if !bytes.Equal(id, sum[:]) {
+		return errors.Errorf("chunk %s is corrupt: its content hashes to %s", id.HexString(), datahash.EncodeHash(sum[:]))
+	}
+	return nil
+}
+
+// Exists reports whether the chunk id exists.
+func (s *Storage) Exists(ctx context.Context, id ID) (bool, error) {
+	return s.objC.Exists(ctx, id.objectName())
+}
+
+// Delete deletes the chunk id. Callers are responsible for making sure that
+// nothing still refers to it.
+func (s *Storage) Delete(ctx context.Context, id ID) error {
+	return s.objC.Delete(ctx, id.objectName())
+}
+
+// GarbageCollect deletes the chunks that are not referenced, and returns how
+// many it deleted. mark must call live with the ID of every chunk that is
+// referenced. Chunks written or reused by an upload are not deleted until
+// grace has passed since the upload returned, which gives the caller time to
+// reference them, nor while any upload that started before then is still
+// running.
+func (s *Storage) GarbageCollect(ctx context.Context, grace time.Duration, mark func(live func(ID)) error) (int64, error) {
+	s.mu.Lock()
+	cutoff := time.Now()
+	for _, start := range s.uploads {
+		if start.Before(cutoff) {
+			cutoff = start
+		}
+	}
+	cutoff = cutoff.Add(-grace)
+	s.mu.Unlock()
+	referenced := make(map[string]bool)
+	if err := mark(func(id ID) { referenced[string(id)] = true }); err != nil {
+		return 0, err
+	}
+	var garbage []ID
+	if err := s.List(ctx, nil, nil, func(id ID) error {
+		if !referenced[string(id)] {
+			garbage = append(garbage, id)
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var n int64
+	for _, id := range garbage {
+		if t, ok := s.touched[string(id)]; ok && !t.Before(cutoff) {
+			continue
+		}
+		if err := s.Delete(ctx, id); err != nil {
+			return n, err
+		}
+		n++
+	}
+	for id, t := range s.touched {
+		if t.Before(cutoff) {
+			delete(s.touched, id)
+		}
+	}
+	return n, nil
+}
+
+// List calls cb with the ID of every chunk in [begin, end), in ID order. An
+// empty begin or end leaves that side of the range unbounded.
+func (s *Storage) List(ctx context.Context, begin, end ID, cb func(ID) error) error {
+	return s.objC.Walk(ctx, prefix, func(name string) error {
+		id, err := ParseID(strings.TrimPrefix(name, prefix))
+		if err != nil {
+			// not a chunk
+			return nil
+		}
+		if bytes.Compare(id, begin) < 0 {
+			return nil
+		}
+		if len(end) > 0 && bytes.Compare(id, end) >= 0 {
+			return dataerr.ErrBreak
+		}
+		return cb(id)
+	})
+}
+
+// Check counts the chunks in [begin, end). If readChunkData is set, every
+// chunk is also read back and verified against its ID.
+func (s *Storage) Check(ctx context.Context, begin, end ID, readChunkData bool) (int64, error) {
+	var n int64
+	if err := s.List(ctx, begin, end, func(id ID) error {
+		if readChunkData {
+			if _, err := s.Get(ctx, id); err != nil {
+				return err
+			}
+		}
+		n++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// NewReader returns a reader for the content referred to by refs.
+func (s *Storage) NewReader(ctx context.Context, refs []DataRef) io.Reader {
+	return &reader{ctx: ctx, s: s, refs: refs}
+}
+
+type reader struct {
+	ctx  context.Context
+	s    *Storage
+	refs []DataRef
+	buf  []byte
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if len(r.refs) == 0 {
+			return 0, io.EOF
+		}
+		ref := r.refs[0]
+		r.refs = r.refs[1:]
+		data, err := r.s.Get(r.ctx, ref.ID)
+		if err != nil {
+			return 0, err
+		}
+		if ref.OffsetBytes+ref.SizeBytes > int64(len(data)) {
+			return 0, errors.Errorf("chunk %s is shorter than the range [%d, %d) referring to it", ref.ID.HexString(), ref.OffsetBytes, ref.OffsetBytes+ref.SizeBytes)
+		}
+		r.buf = data[ref.OffsetBytes : ref.OffsetBytes+ref.SizeBytes]
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// Slice returns references to the size bytes of the content referred to by
+// refs that start at offset. A negative size extends to the end.
+func Slice(refs []DataRef, offset, size int64) []DataRef {
+	var out []DataRef
+	for _, ref := range refs {
+		if size == 0 {
+			break
+		}
+		if offset >= ref.SizeBytes {
+			offset -= ref.SizeBytes
+			continue
+		}
+		ref.OffsetBytes += offset
+		ref.SizeBytes -= offset
+		offset = 0
+		if size > 0 && ref.SizeBytes > size {
+			ref.SizeBytes = size
+		}
+		if size > 0 {
+			size -= ref.SizeBytes
+		}
+		out = append(out, ref)
+	}
+	return out
+}
@@ -0,0 +1,186 @@
+package chunk
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+var testOptions = Options{MinSize: 1024, AverageBits: 12, MaxSize: 16 * 1024}
+
+func newTestStorage(t *testing.T) (*Storage, string) {
+	root := t.TempDir()
+	objC, err := obj.NewLocalClient(root)
+	require.NoError(t, err)
+	return NewStorage(objC, testOptions), root
+}
+
+func randomData(seed int64, n int) []byte {
+	data := make([]byte, n)
+	rand.New(rand.NewSource(seed)).Read(data)
+	return data
+}
+
+func readAll(t *testing.T, s *Storage, refs []DataRef) []byte {
+	data, err := io.ReadAll(s.NewReader(context.Background(), refs))
+	require.NoError(t, err)
+	return data
+}
+
+func TestUploadRoundTrip(t *testing.T) {
+	s, _ := newTestStorage(t)
+	data := randomData(1, 200*1024)
+	refs, err := s.Upload(context.Background(), bytes.NewReader(data))
+	require.NoError(t, err)
+	require.True(t, len(refs) > 1)
+	for i, ref := range refs {
+		require.True(t, ref.SizeBytes >= int64(testOptions.MinSize) || i == len(refs)-1)
+		require.True(t, ref.SizeBytes <= int64(testOptions.MaxSize))
+	}
+	require.Equal(t, data, readAll(t, s, refs))
+
+	refs, err = s.Upload(context.Background(), bytes.NewReader(nil))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(refs))
+}
+
+func TestDeduplication(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	data := randomData(2, 200*1024)
+	refs, err := s.Upload(ctx, bytes.NewReader(data))
+	require.NoError(t, err)
+	n, err := s.Check(ctx, nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(refs)), n)
+
+	// uploading the same content again stores nothing new
+	_, err = s.Upload(ctx, bytes.NewReader(data))
+	require.NoError(t, err)
+	n2, err := s.Check(ctx, nil, nil, false)
+	require.NoError(t, err)
+	require.Equal(t, n, n2)
+
+	// inserting bytes near the start only changes the chunks around the edit
+	edited := append(append(append([]byte{}, data[:100]...), []byte("inserted")...), data[100:]...)
+	editedRefs, err := s.Upload(ctx, bytes.NewReader(edited))
+	require.NoError(t, err)
+	require.Equal(t, edited, readAll(t, s, editedRefs))
+	n3, err := s.Check(ctx, nil, nil, false)
+	require.NoError(t, err)
+	require.True(t, n3-n2 <= 2)
+}
+
+func TestCheckRange(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	_, err := s.Upload(ctx, bytes.NewReader(randomData(3, 200*1024)))
+	require.NoError(t, err)
+	var ids []ID
+	require.NoError(t, s.List(ctx, nil, nil, func(id ID) error {
+		ids = append(ids, id)
+		return nil
+	}))
+	for i := 1; i < len(ids); i++ {
+		require.True(t, bytes.Compare(ids[i-1], ids[i]) < 0)
+	}
+	mid := len(ids) / 2
+	n, err := s.Check(ctx, ids[mid], nil, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(ids)-mid), n)
+	n, err = s.Check(ctx, nil, ids[mid], true)
+	require.NoError(t, err)
+	require.Equal(t, int64(mid), n)
+}
+
+func TestCheckDetectsCorruption(t *testing.T) {
+	s, root := newTestStorage(t)
+	ctx := context.Background()
+	refs, err := s.Upload(ctx, bytes.NewReader([]byte("some data")))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(refs))
+	p := filepath.Join(root, filepath.FromSlash(refs[0].ID.objectName()))
+	require.NoError(t, os.WriteFile(p, []byte("other data"), 0644))
+
+	// counting chunks doesn't read them
+	_, err = s.Check(ctx, nil, nil, false)
+	require.NoError(t, err)
+	_, err = s.Check(ctx, nil, nil, true)
+	require.YesError(t, err)
+	_, err = io.ReadAll(s.NewReader(ctx, refs))
+	require.YesError(t, err)
+}
+
+func TestSlice(t *testing.T) {
+	s, _ := newTestStorage(t)
+	data := randomData(4, 100*1024)
+	refs, err := s.Upload(context.Background(), bytes.NewReader(data))
+	require.NoError(t, err)
+	for _, c := range []struct{ offset, size int64 }{
+		{0, -1}, {0, 10}, {5000, -1}, {5000, 20000}, {int64(len(data)) - 1, -1}, {int64(len(data)), -1},
+	} {
+		end := int64(len(data))
+		if c.size >= 0 {
+			end = c.offset + c.size
+		}
+		require.Equal(t, data[c.offset:end], readAll(t, s, Slice(refs, c.offset, c.size)))
+	}
+}
+
+func TestGarbageCollect(t *testing.T) {
+	s, _ := newTestStorage(t)
+	ctx := context.Background()
+	live, err := s.Upload(ctx, bytes.NewReader(randomData(5, 100*1024)))
+	require.NoError(t, err)
+	_, err = s.Upload(ctx, bytes.NewReader(randomData(6, 100*1024)))
+	require.NoError(t, err)
+	total, err := s.Check(ctx, nil, nil, false)
+	require.NoError(t, err)
+	mark := func(f func(ID)) error {
+		for _, ref := range live {
+			f(ref.ID)
+		}
+		return nil
+	}
+
+	// recently uploaded chunks are kept
+	n, err := s.GarbageCollect(ctx, time.Hour, mark)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	n, err = s.GarbageCollect(ctx, 0, mark)
+	require.NoError(t, err)
+	require.Equal(t, total-int64(len(live)), n)
+	require.Equal(t, randomData(5, 100*1024), readAll(t, s, live))
+	remaining, err := s.Check(ctx, nil, nil, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(len(live)), remaining)
+}
@@ -0,0 +1,123 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// httpClient is a read-only Client for the objects of an HTTP(S) server.
+// Objects are named by their URL path, and carry the query string of the URL
+// the client was opened with.
+type httpClient struct {
+	scheme   string
+	host     string
+	rawQuery string
+	client   *http.Client
+}
+
+func openHTTP(ctx context.Context, u *URL) (Client, error) {
+	return &httpClient{
+		scheme:   u.Scheme,
+		host:     u.Bucket,
+		rawQuery: u.rawQuery,
+		client:   http.DefaultClient,
+	}, nil
+}
+
+func (c *httpClient) url(name string) string {
+	return (&url.URL{Scheme: c.scheme, Host: c.host, Path: "/" + name, RawQuery: c.rawQuery}).String()
+}
+
+func (c *httpClient) do(ctx context.Context, method, name string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url(name), nil)
+	if err != nil {
+		return nil, permanent(errors.EnsureStack(err))
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		resp.Body.Close()
+		return nil, dataerr.NewNotExist(c.scheme+"://"+c.host, name)
+	case resp.StatusCode >= 300:
+		resp.Body.Close()
+		err := errors.Errorf("cannot %s %s: %s", method, c.url(name), resp.Status)
+		if resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+			err = permanent(err)
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *httpClient) Put(ctx context.Context, name string, r io.Reader) error {
+	return permanent(errors.Errorf("cannot write %s: HTTP objects are read-only", c.url(name)))
+}
+
+// Get writes the content of the object name to w. If the server sends a
+// Content-MD5 header, the content is checked against it.
+func (c *httpClient) Get(ctx context.Context, name string, w io.Writer) error {
+	resp, err := c.do(ctx, http.MethodGet, name)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	var expected []byte
+	if h := resp.Header.Get("Content-MD5"); h != "" {
+		if expected, err = base64.StdEncoding.DecodeString(h); err != nil {
+			return errors.Wrapf(err, "invalid Content-MD5 header for %s", c.url(name))
+		}
+	}
+	return getVerified(c.url(name), w, expected, func(w io.Writer) error {
+		_, err := io.Copy(w, resp.Body)
+		return errors.EnsureStack(err)
+	})
+}
+
+func (c *httpClient) Delete(ctx context.Context, name string) error {
+	return permanent(errors.Errorf("cannot delete %s: HTTP objects are read-only", c.url(name)))
+}
+
+func (c *httpClient) Exists(ctx context.Context, name string) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, name)
+	if err != nil {
+		if IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	resp.Body.Close()
+	return true, nil
+}
+
+func (c *httpClient) Walk(ctx context.Context, prefix string, cb func(name string) error) error {
+	return permanent(errors.Errorf("cannot list %s: HTTP objects cannot be listed", c.url(prefix)))
+}
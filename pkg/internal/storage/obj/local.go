@@ -0,0 +1,167 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+type localClient struct {
+	root string
+}
+
+// NewLocalClient returns a Client that stores objects as files beneath the
+// directory root, creating it if necessary.
+func NewLocalClient(root string) (Client, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return &localClient{root: root}, nil
+}
+
+func (c *localClient) path(name string) (string, error) {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains("/"+name+"/", "/../") {
+		return "", permanent(errors.Errorf("invalid object name %q", name))
+	}
+	return filepath.Join(c.root, filepath.FromSlash(name)), nil
+}
+
+func (c *localClient) Put(ctx context.Context, name string, r io.Reader) (retErr error) {
+	p, err := c.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return errors.EnsureStack(err)
+	}
+	// write to a temporary file and rename it into place so that readers
+	// never see a partial object
+	f, err := os.CreateTemp(filepath.Dir(p), ".tmp-*")
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if retErr != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.EnsureStack(err)
+	}
+	if err := f.Close(); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(os.Rename(f.Name(), p))
+}
+
+func (c *localClient) Get(ctx context.Context, name string, w io.Writer) error {
+	p, err := c.path(name)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return dataerr.NewNotExist(c.root, name)
+		}
+		return errors.EnsureStack(err)
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return errors.EnsureStack(err)
+}
+
+func (c *localClient) Delete(ctx context.Context, name string) error {
+	p, err := c.path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+func (c *localClient) Exists(ctx context.Context, name string) (bool, error) {
+	p, err := c.path(name)
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.EnsureStack(err)
+	}
+	return true, nil
+}
+
+func (c *localClient) Walk(ctx context.Context, prefix string, cb func(name string) error) error {
+	// filepath.WalkDir visits entries in lexical order, which matches object
+	// name order as long as we start from the deepest directory that covers
+	// the whole prefix
+	if strings.Contains("/"+prefix+"/", "/../") {
+		return permanent(errors.Errorf("invalid object prefix %q", prefix))
+	}
+	dir := c.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		dir = filepath.Join(c.root, filepath.FromSlash(prefix[:i]))
+	}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return errors.EnsureStack(err)
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		rel, err := filepath.Rel(c.root, p)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		name := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if p != dir && !strings.HasPrefix(name+"/", prefix) && !strings.HasPrefix(prefix, name+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".tmp-") || !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		return cb(name)
+	})
+	if errors.Is(err, dataerr.ErrBreak) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,68 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestLocalClient(t *testing.T) {
+	ctx := context.Background()
+	c, err := NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	for _, name := range []string{"b/2", "a/1", "b/1", "c"} {
+		require.NoError(t, c.Put(ctx, name, strings.NewReader(name)))
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Get(ctx, "b/1", buf))
+	require.Equal(t, "b/1", buf.String())
+
+	exists, err := c.Exists(ctx, "a/1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	require.NoError(t, c.Delete(ctx, "a/1"))
+	require.NoError(t, c.Delete(ctx, "a/1"))
+	exists, err = c.Exists(ctx, "a/1")
+	require.NoError(t, err)
+	require.False(t, exists)
+	require.True(t, IsNotExist(c.Get(ctx, "a/1", buf)))
+
+	walk := func(prefix string) []string {
+		var names []string
+		require.NoError(t, c.Walk(ctx, prefix, func(name string) error {
+			names = append(names, name)
+			return nil
+		}))
+		return names
+	}
+	require.Equal(t, []string{"b/1", "b/2", "c"}, walk(""))
+	require.Equal(t, []string{"b/1", "b/2"}, walk("b/"))
+	require.Equal(t, []string{"b/2"}, walk("b/2"))
+
+	require.YesError(t, c.Put(ctx, "../escape", strings.NewReader("")))
+	require.YesError(t, c.Walk(ctx, "../", func(string) error { return nil }))
+	require.YesError(t, c.Walk(ctx, "b/../../x", func(string) error { return nil }))
+}
@@ -0,0 +1,92 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"io"
+
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// Client is the interface to an object store. Object names are slash
+// separated paths. Implementations must make Put atomic: readers either see
+// the complete object or nothing at all.
+type Client interface {
+	// Put writes the object name with the content of r, replacing any
+	// existing object.
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Get writes the content of the object name to w.
+	Get(ctx context.Context, name string, w io.Writer) error
+	// Delete deletes the object name. Deleting an object that does not exist
+	// is not an error.
+	Delete(ctx context.Context, name string) error
+	// Exists reports whether the object name exists.
+	Exists(ctx context.Context, name string) (bool, error)
+	// Walk calls cb with the name of every object beginning with prefix,
+	// ordered by name one path element at a time.
+	Walk(ctx context.Context, prefix string, cb func(name string) error) error
+}
+
+// IsNotExist returns true if err indicates that an object does not exist.
+func IsNotExist(err error) bool {
+	return dataerr.IsNotExist(err)
+}
+
+// IsRetriable returns true if err may be transient, so that the operation
+// that returned it could succeed if it is retried.
+func IsRetriable(err error) bool {
+	if err == nil || IsNotExist(err) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var pe permanentError
+	return !errors.As(err, &pe)
+}
+
+// permanentError marks an error that retrying won't fix.
+type permanentError struct {
+	error
+}
+
+func (e permanentError) Unwrap() error {
+	return e.error
+}
+
+func permanent(err error) error {
+	return permanentError{err}
+}
+
+// getVerified calls get with a writer that passes everything through to w,
+// and fails if the MD5 hash of what was written doesn't match expected,
+// unless expected is nil.
+func getVerified(name string, w io.Writer, expected []byte, get func(w io.Writer) error) error {
+	h := md5.New()
+	if err := get(io.MultiWriter(w, h)); err != nil {
+		return err
+	}
+	if actual := h.Sum(nil); expected != nil && !bytes.Equal(actual, expected) {
+		return errors.Errorf("content of %s is corrupt: expected MD5 %x, got %x", name, expected, actual)
+	}
+	return nil
+}
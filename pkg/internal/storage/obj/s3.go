@@ -0,0 +1,166 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// defaultS3Endpoint is the endpoint of s3 URLs that don't set one.
+const defaultS3Endpoint = "s3.amazonaws.com"
+
+type s3Client struct {
+	client *minio.Client
+	bucket string
+}
+
+// openS3 opens the bucket of an s3 URL. The endpoint defaults to AWS S3, but
+// any S3-compatible store can be used by setting the "endpoint" parameter,
+// and "disableSSL=true" connects to it over plain HTTP. Credentials are taken
+// from the AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY or MINIO_ACCESS_KEY and
+// MINIO_SECRET_KEY environment variables; requests are anonymous without them.
+func openS3(ctx context.Context, u *URL) (Client, error) {
+	if u.Bucket == "" {
+		return nil, errors.Errorf("invalid URL %v: a bucket is required", u)
+	}
+	endpoint := u.Params.Get("endpoint")
+	if endpoint == "" {
+		endpoint = defaultS3Endpoint
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds: credentials.NewChainCredentials([]credentials.Provider{
+			&credentials.EnvAWS{},
+			&credentials.EnvMinio{},
+		}),
+		Secure: u.Params.Get("disableSSL") != "true",
+		Region: u.Params.Get("region"),
+	})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return NewS3Client(client, u.Bucket), nil
+}
+
+// NewS3Client returns a Client for a bucket of an S3-compatible store.
+func NewS3Client(client *minio.Client, bucket string) Client {
+	return &s3Client{client: client, bucket: bucket}
+}
+
+// s3Error converts an error from the store, distinguishing missing objects
+// and errors that retrying won't fix.
+func (c *s3Client) s3Error(err error, name string) error {
+	resp := minio.ToErrorResponse(err)
+	switch {
+	case resp.Code == "NoSuchKey" || resp.Code == "NoSuchBucket":
+		return dataerr.NewNotExist(c.bucket, name)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		return permanent(errors.EnsureStack(err))
+	}
+	return errors.EnsureStack(err)
+}
+
+func (c *s3Client) Put(ctx context.Context, name string, r io.Reader) error {
+	if _, err := c.client.PutObject(ctx, c.bucket, name, r, -1, minio.PutObjectOptions{}); err != nil {
+		return c.s3Error(err, name)
+	}
+	return nil
+}
+
+// Get writes the content of the object name to w. Unless the object was
+// uploaded in parts or encrypted, its ETag is its MD5 hash, and the content is
+// checked against it.
+func (c *s3Client) Get(ctx context.Context, name string, w io.Writer) error {
+	obj, err := c.client.GetObject(ctx, c.bucket, name, minio.GetObjectOptions{})
+	if err != nil {
+		return c.s3Error(err, name)
+	}
+	defer obj.Close()
+	info, err := obj.Stat()
+	if err != nil {
+		return c.s3Error(err, name)
+	}
+	var expected []byte
+	if info.Metadata.Get("X-Amz-Server-Side-Encryption") == "" {
+		if etag, err := hex.DecodeString(strings.Trim(info.ETag, `"`)); err == nil && len(etag) == 16 {
+			expected = etag
+		}
+	}
+	return getVerified(c.bucket+"/"+name, w, expected, func(w io.Writer) error {
+		if _, err := io.Copy(w, obj); err != nil {
+			return c.s3Error(err, name)
+		}
+		return nil
+	})
+}
+
+func (c *s3Client) Delete(ctx context.Context, name string) error {
+	if err := c.client.RemoveObject(ctx, c.bucket, name, minio.RemoveObjectOptions{}); err != nil {
+		if err := c.s3Error(err, name); !IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *s3Client) Exists(ctx context.Context, name string) (bool, error) {
+	if _, err := c.client.StatObject(ctx, c.bucket, name, minio.StatObjectOptions{}); err != nil {
+		if err := c.s3Error(err, name); !IsNotExist(err) {
+			return false, err
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+func (c *s3Client) Walk(ctx context.Context, prefix string, cb func(name string) error) error {
+	// S3 lists keys in byte order, which differs from path element order
+	// when a name contains a character that sorts before '/'
+	var names []string
+	for info := range c.client.ListObjects(ctx, c.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if info.Err != nil {
+			return c.s3Error(info.Err, prefix)
+		}
+		names = append(names, info.Key)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return strings.ReplaceAll(names[i], "/", "\x00") < strings.ReplaceAll(names[j], "/", "\x00")
+	})
+	for _, name := range names {
+		if err := cb(name); err != nil {
+			if errors.Is(err, dataerr.ErrBreak) {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
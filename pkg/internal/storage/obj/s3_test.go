@@ -0,0 +1,70 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// TestS3Client runs against the bucket of DATA_TEST_S3_URL, for example
+// s3://test?endpoint=localhost:9000&disableSSL=true for a local MinIO, and is
+// skipped if it is not set.
+func TestS3Client(t *testing.T) {
+	s := os.Getenv("DATA_TEST_S3_URL")
+	if s == "" {
+		t.Skip("DATA_TEST_S3_URL is not set")
+	}
+	ctx := context.Background()
+	u, err := ParseURL(s)
+	require.NoError(t, err)
+	c, err := NewClientFromURL(ctx, u)
+	require.NoError(t, err)
+	prefix := "test-" + uuid.NewWithoutDashes() + "/"
+	names := []string{prefix + "a-b", prefix + "a/b", prefix + "c"}
+	for _, name := range names {
+		require.NoError(t, c.Put(ctx, name, strings.NewReader(name)))
+		defer c.Delete(ctx, name)
+	}
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Get(ctx, names[1], buf))
+	require.Equal(t, names[1], buf.String())
+	require.True(t, IsNotExist(c.Get(ctx, prefix+"missing", buf)))
+
+	var walked []string
+	require.NoError(t, c.Walk(ctx, prefix, func(name string) error {
+		walked = append(walked, name)
+		return nil
+	}))
+	require.Equal(t, []string{prefix + "a/b", prefix + "a-b", prefix + "c"}, walked)
+
+	require.NoError(t, c.Delete(ctx, names[2]))
+	require.NoError(t, c.Delete(ctx, names[2]))
+	exists, err := c.Exists(ctx, names[2])
+	require.NoError(t, err)
+	require.False(t, exists)
+}
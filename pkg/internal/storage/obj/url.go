@@ -0,0 +1,125 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// URL is an object storage URL, such as s3://bucket/path/to/object. Bucket is
+// the part of the URL that selects a Client, and Object is the name of an
+// object, or a prefix of names, within it.
+type URL struct {
+	Scheme string
+	Bucket string
+	Object string
+	// Params holds the query parameters of the URL, which schemes may use to
+	// configure their Client.
+	Params url.Values
+
+	rawQuery string
+}
+
+// ParseURL parses an object storage URL. The scheme must have been
+// registered.
+func ParseURL(s string) (*URL, error) {
+	u, err := url.Parse(s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid URL %q", s)
+	}
+	if _, err := lookupScheme(u.Scheme); err != nil {
+		return nil, err
+	}
+	return &URL{
+		Scheme: u.Scheme,
+		Bucket: u.Host,
+		Object: strings.TrimPrefix(u.Path, "/"),
+		Params: u.Query(),
+
+		rawQuery: u.RawQuery,
+	}, nil
+}
+
+func (u *URL) String() string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Bucket, Path: "/" + u.Object, RawQuery: u.rawQuery}).String()
+}
+
+// Opener returns the Client for the bucket of a URL.
+type Opener func(ctx context.Context, u *URL) (Client, error)
+
+var schemes = struct {
+	sync.RWMutex
+	openers map[string]Opener
+}{openers: make(map[string]Opener)}
+
+// RegisterScheme makes the objects of URLs with scheme accessible through
+// NewClientFromURL, replacing any Opener already registered for it.
+func RegisterScheme(scheme string, o Opener) {
+	schemes.Lock()
+	defer schemes.Unlock()
+	schemes.openers[scheme] = o
+}
+
+func lookupScheme(scheme string) (Opener, error) {
+	schemes.RLock()
+	defer schemes.RUnlock()
+	o, ok := schemes.openers[scheme]
+	if !ok {
+		return nil, errors.Errorf("unsupported URL scheme %q", scheme)
+	}
+	return o, nil
+}
+
+// NewClientFromURL returns a Client for the bucket of u.
+func NewClientFromURL(ctx context.Context, u *URL) (Client, error) {
+	o, err := lookupScheme(u.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	return o(ctx, u)
+}
+
+// FileOpener returns an Opener for file URLs that confines them to the
+// directory root, so that file:///a/b names the object a/b beneath it. The
+// file scheme is registered with an Opener that refuses every URL; callers
+// that accept file URLs open them with a FileOpener of their own.
+func FileOpener(root string) Opener {
+	return func(ctx context.Context, u *URL) (Client, error) {
+		if u.Bucket != "" && u.Bucket != "localhost" {
+			return nil, errors.Errorf("invalid URL %v: file URLs cannot name a remote host", u)
+		}
+		return NewLocalClient(root)
+	}
+}
+
+func init() {
+	RegisterScheme("file", func(ctx context.Context, u *URL) (Client, error) {
+		return nil, errors.Errorf("invalid URL %v: file URLs are disabled", u)
+	})
+	RegisterScheme("http", openHTTP)
+	RegisterScheme("https", openHTTP)
+	RegisterScheme("s3", openS3)
+}
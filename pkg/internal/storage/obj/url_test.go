@@ -0,0 +1,142 @@
+package obj
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestParseURL(t *testing.T) {
+	u, err := ParseURL("s3://bucket/dir/obj?endpoint=localhost:9000&disableSSL=true")
+	require.NoError(t, err)
+	require.Equal(t, "s3", u.Scheme)
+	require.Equal(t, "bucket", u.Bucket)
+	require.Equal(t, "dir/obj", u.Object)
+	require.Equal(t, "localhost:9000", u.Params.Get("endpoint"))
+	require.Equal(t, "s3://bucket/dir/obj?endpoint=localhost:9000&disableSSL=true", u.String())
+
+	_, err = ParseURL("gopher://host/obj")
+	require.YesError(t, err)
+}
+
+func TestFileURL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("foo"), 0644))
+	u, err := ParseURL("file:///a")
+	require.NoError(t, err)
+	// file URLs are refused unless they are opened beneath a root
+	_, err = NewClientFromURL(ctx, u)
+	require.YesError(t, err)
+	c, err := FileOpener(dir)(ctx, u)
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	require.NoError(t, c.Get(ctx, u.Object, buf))
+	require.Equal(t, "foo", buf.String())
+
+	u, err = ParseURL("file:///../a")
+	require.NoError(t, err)
+	err = c.Get(ctx, u.Object, buf)
+	require.True(t, err != nil && !IsRetriable(err))
+
+	u, err = ParseURL("file://remote/a")
+	require.NoError(t, err)
+	_, err = FileOpener(dir)(ctx, u)
+	require.YesError(t, err)
+}
+
+func TestHTTPClient(t *testing.T) {
+	ctx := context.Background()
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "sig=x", r.URL.RawQuery)
+		switch r.URL.Path {
+		case "/ok":
+			sum := md5.Sum([]byte("foo"))
+			w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+			w.Write([]byte("foo"))
+		case "/corrupt":
+			sum := md5.Sum([]byte("bar"))
+			w.Header().Set("Content-MD5", base64.StdEncoding.EncodeToString(sum[:]))
+			w.Write([]byte("foo"))
+		case "/flaky":
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("bar"))
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	u, err := ParseURL(srv.URL + "/ok?sig=x")
+	require.NoError(t, err)
+	c, err := NewClientFromURL(ctx, u)
+	require.NoError(t, err)
+
+	get := func(name string) (string, error) {
+		buf := &bytes.Buffer{}
+		err := c.Get(ctx, name, buf)
+		return buf.String(), err
+	}
+	data, err := get("ok")
+	require.NoError(t, err)
+	require.Equal(t, "foo", data)
+
+	_, err = get("corrupt")
+	require.True(t, err != nil && strings.Contains(err.Error(), "corrupt") && IsRetriable(err))
+	_, err = get("flaky")
+	require.True(t, IsRetriable(err))
+	data, err = get("flaky")
+	require.NoError(t, err)
+	require.Equal(t, "bar", data)
+	_, err = get("forbidden")
+	require.True(t, err != nil && !IsRetriable(err))
+	_, err = get("missing")
+	require.True(t, IsNotExist(err) && !IsRetriable(err))
+
+	exists, err := c.Exists(ctx, "ok")
+	require.NoError(t, err)
+	require.True(t, exists)
+	exists, err = c.Exists(ctx, "missing")
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	err = c.Walk(ctx, "", func(string) error { return nil })
+	require.True(t, err != nil && !IsRetriable(err))
+	require.False(t, IsRetriable(errors.EnsureStack(context.Canceled)))
+}
@@ -0,0 +1,439 @@
+// Package task is a queue of tasks that coordinators create in groups and
+// workers process. A worker claims a task with a lease, which it keeps by
+// heartbeating while it processes the task, so that the task goes to
+// another worker if its worker dies or stalls. Tasks that fail are retried
+// with backoff until their retry policy gives up, at which point they fail
+// for good and their coordinator is told why.
+package task
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/backoff"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// DefaultLeaseTTL is how long a claim lasts without a heartbeat unless
+// Options say otherwise.
+const DefaultLeaseTTL = 30 * time.Second
+
+// ErrLeaseLost is returned when heartbeating or completing a claim whose
+// lease has expired, or whose task no longer exists.
+var ErrLeaseLost = errors.New("the task's lease has been lost")
+
+// Options configure a Service.
+type Options struct {
+	// LeaseTTL is how long a claim lasts without a heartbeat. It defaults
+	// to DefaultLeaseTTL.
+	LeaseTTL time.Duration
+	// BackOff returns the retry policy of a new task. It defaults to
+	// backoff.NewExponentialBackOff, which gives up after 15 minutes.
+	BackOff func() backoff.BackOff
+}
+
+// Service holds the tasks of every group, in memory. It is safe for
+// concurrent use by any number of coordinators and workers.
+type Service struct {
+	leaseTTL time.Duration
+	backOff  func() backoff.BackOff
+
+	mu    sync.Mutex
+	tasks map[string]*entry
+	// seq orders the tasks by creation, so that they are claimed in order.
+	seq int64
+	// changed is closed and replaced every time a task changes state.
+	changed chan struct{}
+}
+
+// entry is a task and its scheduling state.
+type entry struct {
+	info    *taskapi.TaskInfo
+	seq     int64
+	input   *anypb.Any
+	output  *anypb.Any
+	backOff backoff.BackOff
+	// notBefore is when a task that failed may be claimed again.
+	notBefore time.Time
+	// token identifies the current claim of a CLAIMED task, which expires
+	// at expires unless it is heartbeated.
+	token   string
+	expires time.Time
+}
+
+// NewService returns an empty Service.
+func NewService(opts Options) *Service {
+	if opts.LeaseTTL <= 0 {
+		opts.LeaseTTL = DefaultLeaseTTL
+	}
+	if opts.BackOff == nil {
+		opts.BackOff = func() backoff.BackOff { return backoff.NewExponentialBackOff() }
+	}
+	return &Service{
+		leaseTTL: opts.LeaseTTL,
+		backOff:  opts.BackOff,
+		tasks:    make(map[string]*entry),
+		changed:  make(chan struct{}),
+	}
+}
+
+// notify wakes everyone waiting for tasks to change. The caller must hold
+// the lock.
+func (s *Service) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// wait calls f, with the lock held, every time a task changes state or a
+// lease or retry is due, until f returns true or ctx is done.
+func (s *Service) wait(ctx context.Context, f func(now time.Time) bool) error {
+	for {
+		s.mu.Lock()
+		now := time.Now()
+		s.expireLeases(now)
+		done := f(now)
+		changed, deadline := s.changed, s.nextDeadline(now)
+		s.mu.Unlock()
+		if done {
+			return nil
+		}
+		var timer *time.Timer
+		var due <-chan time.Time
+		if !deadline.IsZero() {
+			timer = time.NewTimer(deadline.Sub(now))
+			due = timer.C
+		}
+		select {
+		case <-changed:
+		case <-due:
+		case <-ctx.Done():
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if err := ctx.Err(); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+}
+
+// expireLeases fails the claimed tasks whose leases have expired. The
+// caller must hold the lock.
+func (s *Service) expireLeases(now time.Time) {
+	for _, e := range s.tasks {
+		if e.info.State == taskapi.State_CLAIMED && !now.Before(e.expires) {
+			s.fail(e, now, "the worker's lease expired")
+		}
+	}
+}
+
+// nextDeadline returns the next time a lease expires or a retry is due, or
+// the zero time if there is none. The caller must hold the lock.
+func (s *Service) nextDeadline(now time.Time) time.Time {
+	var next time.Time
+	for _, e := range s.tasks {
+		var t time.Time
+		switch {
+		case e.info.State == taskapi.State_CLAIMED:
+			t = e.expires
+		case e.info.State == taskapi.State_RUNNING && now.Before(e.notBefore):
+			t = e.notBefore
+		default:
+			continue
+		}
+		if next.IsZero() || t.Before(next) {
+			next = t
+		}
+	}
+	return next
+}
+
+// fail retries a task once its backoff has passed, or fails it for good if
+// its retry policy gives up. The caller must hold the lock.
+func (s *Service) fail(e *entry, now time.Time, reason string) {
+	e.token = ""
+	if d := e.backOff.NextBackOff(); d != backoff.Stop {
+		e.info.State = taskapi.State_RUNNING
+		e.info.Reason = "retrying: " + reason
+		e.notBefore = now.Add(d)
+	} else {
+		e.info.State = taskapi.State_FAILURE
+		e.info.Reason = reason
+	}
+	s.notify()
+}
+
+// CollectFunc is called with the index, and either the output or the
+// error, of each task of a Do call as it finishes.
+type CollectFunc func(i int, output *anypb.Any, err error) error
+
+// Do creates a task in group for each of inputs, waits for them all to
+// finish and calls cb with the result of each, in the order they finish.
+// If cb returns an error, Do returns it straight away. The tasks are deleted
+// once Do returns, whether or not they finished.
+func (s *Service) Do(ctx context.Context, group *taskapi.Group, inputs []*anypb.Any, cb CollectFunc) error {
+	if group == nil {
+		return errors.New("the task group cannot be nil")
+	}
+	ids := make([]string, len(inputs))
+	s.mu.Lock()
+	for i, input := range inputs {
+		ids[i] = uuid.NewWithoutDashes()
+		s.seq++
+		info := &taskapi.TaskInfo{
+			Id:        ids[i],
+			Group:     group,
+			State:     taskapi.State_RUNNING,
+			InputType: input.GetTypeUrl(),
+		}
+		if data, err := protojson.Marshal(input); err == nil {
+			info.InputData = string(data)
+		}
+		s.tasks[ids[i]] = &entry{info: info, seq: s.seq, input: input, backOff: s.backOff()}
+	}
+	s.notify()
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, id := range ids {
+			delete(s.tasks, id)
+		}
+		s.notify()
+	}()
+
+	type result struct {
+		i      int
+		output *anypb.Any
+		err    error
+	}
+	collected := make([]bool, len(ids))
+	for remaining := len(ids); remaining > 0; {
+		var results []result
+		if err := s.wait(ctx, func(time.Time) bool {
+			for i, id := range ids {
+				e := s.tasks[id]
+				if collected[i] {
+					continue
+				}
+				switch e.info.State {
+				case taskapi.State_SUCCESS:
+					results = append(results, result{i: i, output: e.output})
+				case taskapi.State_FAILURE:
+					results = append(results, result{i: i, err: errors.New(e.info.Reason)})
+				default:
+					continue
+				}
+				collected[i] = true
+			}
+			return len(results) > 0
+		}); err != nil {
+			return err
+		}
+		for _, r := range results {
+			if err := cb(r.i, r.output, r.err); err != nil {
+				return err
+			}
+			remaining--
+		}
+	}
+	return nil
+}
+
+// Claim is a worker's claim on a task.
+type Claim struct {
+	ID    string
+	Input *anypb.Any
+	token string
+}
+
+// Claim waits for a task in namespace to be due, and claims the task that
+// was created first. The claim must be heartbeated at least once per lease
+// TTL until it is completed.
+func (s *Service) Claim(ctx context.Context, namespace string) (*Claim, error) {
+	var c *Claim
+	if err := s.wait(ctx, func(now time.Time) bool {
+		var next *entry
+		for _, e := range s.tasks {
+			if e.info.Group.Namespace == namespace && e.info.State == taskapi.State_RUNNING && !now.Before(e.notBefore) &&
+				(next == nil || e.seq < next.seq) {
+				next = e
+			}
+		}
+		if next == nil {
+			return false
+		}
+		next.info.State = taskapi.State_CLAIMED
+		next.token = uuid.NewWithoutDashes()
+		next.expires = now.Add(s.leaseTTL)
+		c = &Claim{ID: next.info.Id, Input: next.input, token: next.token}
+		return true
+	}); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// claimed returns the task of c, or ErrLeaseLost if c no longer holds it.
+// The caller must hold the lock.
+func (s *Service) claimed(c *Claim, now time.Time) (*entry, error) {
+	s.expireLeases(now)
+	e, ok := s.tasks[c.ID]
+	if !ok || e.info.State != taskapi.State_CLAIMED || e.token != c.token {
+		return nil, ErrLeaseLost
+	}
+	return e, nil
+}
+
+// Heartbeat renews the lease of a claim.
+func (s *Service) Heartbeat(c *Claim) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	e, err := s.claimed(c, now)
+	if err != nil {
+		return err
+	}
+	e.expires = now.Add(s.leaseTTL)
+	return nil
+}
+
+// Complete finishes a claimed task with output, or, if err is set, fails
+// it, in which case it may be retried.
+func (s *Service) Complete(c *Claim, output *anypb.Any, err error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	e, lerr := s.claimed(c, now)
+	if lerr != nil {
+		return lerr
+	}
+	if err != nil {
+		s.fail(e, now, err.Error())
+		return nil
+	}
+	e.token = ""
+	e.info.State = taskapi.State_SUCCESS
+	e.info.Reason = ""
+	e.output = output
+	s.notify()
+	return nil
+}
+
+// release gives up a claim without failing its task, which can be claimed
+// again straight away.
+func (s *Service) release(c *Claim) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, err := s.claimed(c, time.Now()); err == nil {
+		e.token = ""
+		e.info.State = taskapi.State_RUNNING
+		s.notify()
+	}
+}
+
+// ProcessFunc processes the input of a task, returning its output.
+type ProcessFunc func(ctx context.Context, input *anypb.Any) (*anypb.Any, error)
+
+// Work processes the tasks of namespace with f, one at a time, until ctx is
+// done. The claim on each task is heartbeated while f runs, and the context
+// f runs in is canceled if the claim is lost anyway. A task whose
+// processing is interrupted by ctx is released for another worker, without
+// counting as a failure.
+func (s *Service) Work(ctx context.Context, namespace string, f ProcessFunc) error {
+	for {
+		c, err := s.Claim(ctx, namespace)
+		if err != nil {
+			return err
+		}
+		output, err := s.process(ctx, c, f)
+		if ctx.Err() != nil {
+			s.release(c)
+			return errors.EnsureStack(ctx.Err())
+		}
+		if err := s.Complete(c, output, err); err != nil && !errors.Is(err, ErrLeaseLost) {
+			return err
+		}
+	}
+}
+
+// process runs f on the input of c, heartbeating c until f returns.
+func (s *Service) process(ctx context.Context, c *Claim, f ProcessFunc) (*anypb.Any, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(s.leaseTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := s.Heartbeat(c); err != nil {
+					cancel()
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return f(ctx, c.Input)
+}
+
+// List calls cb with every task of group, in the order they were created.
+// An empty namespace or group name matches every namespace or group.
+func (s *Service) List(group *taskapi.Group, cb func(*taskapi.TaskInfo) error) error {
+	var infos []*taskapi.TaskInfo
+	s.mu.Lock()
+	s.expireLeases(time.Now())
+	entries := make([]*entry, 0, len(s.tasks))
+	for _, e := range s.tasks {
+		if group.GetNamespace() != "" && e.info.Group.Namespace != group.Namespace {
+			continue
+		}
+		if group.GetGroup() != "" && e.info.Group.Group != group.Group {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].seq < entries[j].seq })
+	for _, e := range entries {
+		infos = append(infos, proto.Clone(e.info).(*taskapi.TaskInfo))
+	}
+	s.mu.Unlock()
+	for _, info := range infos {
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,227 @@
+package task
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/backoff"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func newInput(t testing.TB, s string) *anypb.Any {
+	input, err := anypb.New(wrapperspb.String(s))
+	require.NoError(t, err)
+	return input
+}
+
+func inputString(t testing.TB, input *anypb.Any) string {
+	var s wrapperspb.StringValue
+	require.NoError(t, input.UnmarshalTo(&s))
+	return s.Value
+}
+
+// startWorkers runs n workers of namespace until the test ends.
+func startWorkers(t testing.TB, s *Service, namespace string, n int, f ProcessFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Work(ctx, namespace, f)
+		}()
+	}
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+}
+
+func TestDo(t *testing.T) {
+	s := NewService(Options{BackOff: func() backoff.BackOff { return &backoff.ZeroBackOff{} }})
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	startWorkers(t, s, "test", 3, func(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+		in := inputString(t, input)
+		mu.Lock()
+		attempts[in]++
+		n := attempts[in]
+		mu.Unlock()
+		// every other task fails on its first attempt
+		if in[0]%2 == 0 && n == 1 {
+			return nil, errors.Errorf("transient failure of %s", in)
+		}
+		return anypb.New(wrapperspb.String(in + " done"))
+	})
+
+	var inputs []*anypb.Any
+	for _, in := range []string{"a", "b", "c", "d", "e", "f"} {
+		inputs = append(inputs, newInput(t, in))
+	}
+	outputs := make([]string, len(inputs))
+	require.NoError(t, s.Do(context.Background(), &taskapi.Group{Namespace: "test", Group: "letters"}, inputs, func(i int, output *anypb.Any, err error) error {
+		require.NoError(t, err)
+		outputs[i] = inputString(t, output)
+		return nil
+	}))
+	require.Equal(t, []string{"a done", "b done", "c done", "d done", "e done", "f done"}, outputs)
+	require.Equal(t, 2, attempts["b"])
+	require.Equal(t, 1, attempts["c"])
+	// tasks are deleted once their group is done
+	require.NoError(t, s.List(nil, func(info *taskapi.TaskInfo) error {
+		return errors.Errorf("unexpected task %s", info.Id)
+	}))
+}
+
+// maxRetries retries straight away, n times.
+type maxRetries struct {
+	n int
+}
+
+func (b *maxRetries) Reset() {}
+
+func (b *maxRetries) NextBackOff() time.Duration {
+	if b.n == 0 {
+		return backoff.Stop
+	}
+	b.n--
+	return 0
+}
+
+func TestDoFailure(t *testing.T) {
+	retries := 2
+	s := NewService(Options{BackOff: func() backoff.BackOff {
+		return &maxRetries{n: retries}
+	}})
+	var mu sync.Mutex
+	var attempts int
+	startWorkers(t, s, "test", 1, func(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		attempts++
+		return nil, errors.New("permanent failure")
+	})
+	var failures []error
+	require.NoError(t, s.Do(context.Background(), &taskapi.Group{Namespace: "test"}, []*anypb.Any{newInput(t, "x")}, func(i int, output *anypb.Any, err error) error {
+		failures = append(failures, err)
+		return nil
+	}))
+	require.Equal(t, 1, len(failures))
+	require.Equal(t, "permanent failure", failures[0].Error())
+	require.Equal(t, retries+1, attempts)
+
+	// an error from the callback is returned straight away
+	err := s.Do(context.Background(), &taskapi.Group{Namespace: "test"}, []*anypb.Any{newInput(t, "x"), newInput(t, "y")}, func(int, *anypb.Any, error) error {
+		return errors.New("stop")
+	})
+	require.Equal(t, "stop", err.Error())
+}
+
+func TestLeases(t *testing.T) {
+	ttl := 50 * time.Millisecond
+	s := NewService(Options{LeaseTTL: ttl, BackOff: func() backoff.BackOff { return &backoff.ZeroBackOff{} }})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	group := &taskapi.Group{Namespace: "test", Group: "lease"}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Do(ctx, group, []*anypb.Any{newInput(t, "x")}, func(i int, output *anypb.Any, err error) error {
+			if err != nil {
+				return err
+			}
+			if got := inputString(t, output); got != "second" {
+				return errors.Errorf("output %q is not from the second claim", got)
+			}
+			return nil
+		})
+	}()
+
+	list := func() []*taskapi.TaskInfo {
+		var infos []*taskapi.TaskInfo
+		require.NoError(t, s.List(&taskapi.Group{Namespace: "test"}, func(info *taskapi.TaskInfo) error {
+			infos = append(infos, info)
+			return nil
+		}))
+		return infos
+	}
+	first, err := s.Claim(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, "x", inputString(t, first.Input))
+	infos := list()
+	require.Equal(t, 1, len(infos))
+	require.Equal(t, taskapi.State_CLAIMED, infos[0].State)
+	require.Equal(t, "lease", infos[0].Group.Group)
+	require.Equal(t, "type.googleapis.com/google.protobuf.StringValue", infos[0].InputType)
+
+	// heartbeats keep the lease
+	for i := 0; i < 4; i++ {
+		time.Sleep(ttl / 2)
+		require.NoError(t, s.Heartbeat(first))
+	}
+	// without them, the task goes to another worker, and the first claim
+	// can no longer complete it
+	second, err := s.Claim(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, first.ID, second.ID)
+	require.True(t, errors.Is(s.Heartbeat(first), ErrLeaseLost))
+	require.True(t, errors.Is(s.Complete(first, newInput(t, "first"), nil), ErrLeaseLost))
+	infos = list()
+	require.Equal(t, "retrying: the worker's lease expired", infos[0].Reason)
+	require.NoError(t, s.Complete(second, newInput(t, "second"), nil))
+	require.NoError(t, <-done)
+}
+
+func TestWorkRelease(t *testing.T) {
+	s := NewService(Options{BackOff: func() backoff.BackOff { return &backoff.StopBackOff{} }})
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	workDone := make(chan error, 1)
+	go func() {
+		workDone <- s.Work(ctx, "test", func(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		})
+	}()
+	doDone := make(chan error, 1)
+	go func() {
+		doDone <- s.Do(context.Background(), &taskapi.Group{Namespace: "test"}, []*anypb.Any{newInput(t, "x")}, func(i int, output *anypb.Any, err error) error {
+			return err
+		})
+	}()
+	<-started
+	cancel()
+	require.YesError(t, <-workDone)
+	// the interrupted task is not failed, so another worker completes it
+	startWorkers(t, s, "test", 1, func(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+		return input, nil
+	})
+	require.NoError(t, <-doDone)
+}
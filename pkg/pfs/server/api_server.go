@@ -0,0 +1,695 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+type apiServer struct {
+	pfs.UnimplementedAPIServer
+	driver *driver
+	// loopback is a client for the server itself, which load tests use.
+	loopbackOnce sync.Once
+	loopback     pfs.APIClient
+	loopbackErr  error
+}
+
+// Env is the environment a PFS APIServer runs in.
+type Env struct {
+	// StorageRoot is the directory file content is stored under.
+	StorageRoot string
+	// PostgresURL is the connection URL of the Postgres database metadata is
+	// stored in. If it is empty, metadata is only kept in memory.
+	PostgresURL string
+	// Secrets resolves the secrets that egress targets and SQL ingests refer
+	// to for their credentials. Those with a secret fail if it is nil.
+	Secrets SecretGetter
+	// CacheSizeBytes is the size budget of the PutCache cache, which counts
+	// the content of the file sets its entries pin. If it is 0, the cache
+	// holds up to 64 MiB.
+	CacheSizeBytes int64
+	// FileURLRoot is the directory that file:// URLs in GetFile, AddFile and
+	// Egress requests are confined to, so that file:///a/b names the file
+	// a/b beneath it. If it is empty, file URLs are refused.
+	FileURLRoot string
+}
+
+// NewAPIServer creates a PFS APIServer. File content is stored as
+// content-addressed chunks beneath env.StorageRoot, and metadata in Postgres
+// if env.PostgresURL is set. Without Postgres, metadata is kept in memory and
+// does not survive a restart. Garbage collection runs in the background until
+// ctx is done.
+func NewAPIServer(ctx context.Context, env Env) (pfs.APIServer, error) {
+	return newAPIServer(ctx, env)
+}
+
+func newAPIServer(ctx context.Context, env Env) (*apiServer, error) {
+	objC, err := obj.NewLocalClient(env.StorageRoot)
+	if err != nil {
+		return nil, err
+	}
+	var store metadataStore
+	if env.PostgresURL != "" {
+		db, err := dbutil.NewDB(ctx, env.PostgresURL)
+		if err != nil {
+			return nil, err
+		}
+		if store, err = newPostgresStore(ctx, db); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	d := newDriver(chunk.NewStorage(objC, chunk.Options{}), store)
+	if err := d.load(ctx); err != nil {
+		return nil, err
+	}
+	d.secrets = env.Secrets
+	d.cache = newCache(env.CacheSizeBytes)
+	d.fileURLRoot = env.FileURLRoot
+	go d.runGC(ctx, defaultGCPeriod)
+	d.resumeFinishing()
+	return &apiServer{driver: d}, nil
+}
+
+// CreateRepo implements the protobuf pfs.CreateRepo RPC
+func (a *apiServer) CreateRepo(ctx context.Context, request *pfs.CreateRepoRequest) (*emptypb.Empty, error) {
+	if err := validateRepo(request.Repo); err != nil {
+		return nil, err
+	}
+	if err := a.driver.createRepo(request.Repo, request.Description, request.Update); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// InspectRepo implements the protobuf pfs.InspectRepo RPC
+func (a *apiServer) InspectRepo(ctx context.Context, request *pfs.InspectRepoRequest) (*pfs.RepoInfo, error) {
+	if err := validateRepo(request.Repo); err != nil {
+		return nil, err
+	}
+	return a.driver.inspectRepo(request.Repo)
+}
+
+// ListRepo implements the protobuf pfs.ListRepo RPC
+func (a *apiServer) ListRepo(request *pfs.ListRepoRequest, srv pfs.API_ListRepoServer) error {
+	return a.driver.listRepo(request.Type, srv.Send)
+}
+
+// DeleteRepo implements the protobuf pfs.DeleteRepo RPC
+func (a *apiServer) DeleteRepo(ctx context.Context, request *pfs.DeleteRepoRequest) (*emptypb.Empty, error) {
+	if err := validateRepo(request.Repo); err != nil {
+		return nil, err
+	}
+	if err := a.driver.deleteRepo(request.Repo, request.Force); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// StartCommit implements the protobuf pfs.StartCommit RPC
+func (a *apiServer) StartCommit(ctx context.Context, request *pfs.StartCommitRequest) (*pfs.Commit, error) {
+	if err := validateBranch(request.Branch); err != nil {
+		return nil, err
+	}
+	if request.Parent != nil {
+		if err := validateCommit(request.Parent); err != nil {
+			return nil, err
+		}
+	}
+	return a.driver.startCommit(request.Branch, request.Parent, request.Description)
+}
+
+// FinishCommit implements the protobuf pfs.FinishCommit RPC
+func (a *apiServer) FinishCommit(ctx context.Context, request *pfs.FinishCommitRequest) (*emptypb.Empty, error) {
+	if err := validateCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	if err := a.driver.finishCommit(ctx, request.Commit, request.Description, request.Error); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ClearCommit implements the protobuf pfs.ClearCommit RPC
+func (a *apiServer) ClearCommit(ctx context.Context, request *pfs.ClearCommitRequest) (*emptypb.Empty, error) {
+	if err := validateCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	if err := a.driver.clearCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// InspectCommit implements the protobuf pfs.InspectCommit RPC
+func (a *apiServer) InspectCommit(ctx context.Context, request *pfs.InspectCommitRequest) (*pfs.CommitInfo, error) {
+	if err := validateCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	return a.driver.inspectCommit(ctx, request.Commit, request.Wait)
+}
+
+// ListCommit implements the protobuf pfs.ListCommit RPC
+func (a *apiServer) ListCommit(request *pfs.ListCommitRequest, srv pfs.API_ListCommitServer) error {
+	if err := validateRepo(request.Repo); err != nil {
+		return err
+	}
+	if request.All && request.OriginKind != pfs.OriginKind_ORIGIN_KIND_UNKNOWN {
+		return errors.New("cannot specify both 'all' and 'origin_kind'")
+	}
+	normalizeCommit(request.From)
+	normalizeCommit(request.To)
+	return a.driver.listCommit(request.Repo, request.To, request.From, request.Number, request.Reverse, request.All, request.OriginKind, srv.Send)
+}
+
+// SubscribeCommit implements the protobuf pfs.SubscribeCommit RPC
+func (a *apiServer) SubscribeCommit(request *pfs.SubscribeCommitRequest, srv pfs.API_SubscribeCommitServer) error {
+	if err := validateRepo(request.Repo); err != nil {
+		return err
+	}
+	if request.All && request.OriginKind != pfs.OriginKind_ORIGIN_KIND_UNKNOWN {
+		return errors.New("cannot specify both 'all' and 'origin_kind'")
+	}
+	if request.From != nil {
+		if request.From.Branch == nil {
+			request.From.Branch = request.Repo.NewBranch(request.Branch)
+		}
+		if err := validateCommit(request.From); err != nil {
+			return err
+		}
+	}
+	return a.driver.subscribeCommit(srv.Context(), request.Repo, request.Branch, request.From, request.State, request.All, request.OriginKind, srv.Send)
+}
+
+// InspectCommitSet implements the protobuf pfs.InspectCommitSet RPC
+func (a *apiServer) InspectCommitSet(request *pfs.InspectCommitSetRequest, srv pfs.API_InspectCommitSetServer) error {
+	if request.CommitSet == nil {
+		return errors.New("commit set cannot be nil")
+	}
+	return a.driver.inspectCommitSet(srv.Context(), request.CommitSet, request.Wait, srv.Send)
+}
+
+// ListCommitSet implements the protobuf pfs.ListCommitSet RPC
+func (a *apiServer) ListCommitSet(request *pfs.ListCommitSetRequest, srv pfs.API_ListCommitSetServer) error {
+	return a.driver.listCommitSet(srv.Send)
+}
+
+// SquashCommitSet implements the protobuf pfs.SquashCommitSet RPC
+func (a *apiServer) SquashCommitSet(ctx context.Context, request *pfs.SquashCommitSetRequest) (*emptypb.Empty, error) {
+	if request.CommitSet == nil {
+		return nil, errors.New("commit set cannot be nil")
+	}
+	if err := a.driver.squashCommitSet(request.CommitSet); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DropCommitSet implements the protobuf pfs.DropCommitSet RPC
+func (a *apiServer) DropCommitSet(ctx context.Context, request *pfs.DropCommitSetRequest) (*emptypb.Empty, error) {
+	if request.CommitSet == nil {
+		return nil, errors.New("commit set cannot be nil")
+	}
+	if err := a.driver.dropCommitSet(request.CommitSet); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CreateBranch implements the protobuf pfs.CreateBranch RPC
+func (a *apiServer) CreateBranch(ctx context.Context, request *pfs.CreateBranchRequest) (*emptypb.Empty, error) {
+	if err := validateBranch(request.Branch); err != nil {
+		return nil, err
+	}
+	if request.Head != nil {
+		if err := validateCommit(request.Head); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range request.Provenance {
+		if err := validateBranch(b); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.driver.createBranch(request.Branch, request.Head, request.Provenance, request.Trigger); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// InspectBranch implements the protobuf pfs.InspectBranch RPC
+func (a *apiServer) InspectBranch(ctx context.Context, request *pfs.InspectBranchRequest) (*pfs.BranchInfo, error) {
+	if err := validateBranch(request.Branch); err != nil {
+		return nil, err
+	}
+	return a.driver.inspectBranch(request.Branch)
+}
+
+// ListBranch implements the protobuf pfs.ListBranch RPC
+func (a *apiServer) ListBranch(request *pfs.ListBranchRequest, srv pfs.API_ListBranchServer) error {
+	normalizeRepo(request.Repo)
+	return a.driver.listBranch(request.Repo, request.Reverse, srv.Send)
+}
+
+// DeleteBranch implements the protobuf pfs.DeleteBranch RPC
+func (a *apiServer) DeleteBranch(ctx context.Context, request *pfs.DeleteBranchRequest) (*emptypb.Empty, error) {
+	if err := validateBranch(request.Branch); err != nil {
+		return nil, err
+	}
+	if err := a.driver.deleteBranch(request.Branch, request.Force); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ModifyFile implements the protobuf pfs.ModifyFile RPC
+func (a *apiServer) ModifyFile(srv pfs.API_ModifyFileServer) error {
+	var commit *pfs.Commit
+	for {
+		req, err := srv.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return srv.SendAndClose(&emptypb.Empty{})
+			}
+			return errors.EnsureStack(err)
+		}
+		if sc, ok := req.Body.(*pfs.ModifyFileRequest_SetCommit); ok {
+			if err := validateCommit(sc.SetCommit); err != nil {
+				return err
+			}
+			commit = sc.SetCommit
+			continue
+		}
+		if commit == nil {
+			return errors.New("commit must be set before modifying files")
+		}
+		if err := a.modifyFile(srv.Context(), commitTarget{commit}, req); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *apiServer) modifyFile(ctx context.Context, target fileTarget, req *pfs.ModifyFileRequest) error {
+	switch body := req.Body.(type) {
+	case *pfs.ModifyFileRequest_AddFile:
+		switch src := body.AddFile.Source.(type) {
+		case *pfs.AddFile_Raw:
+			return a.driver.addFile(ctx, target, body.AddFile.Path, body.AddFile.Datum, bytes.NewReader(src.Raw.GetValue()))
+		case *pfs.AddFile_Url:
+			return a.driver.addFileURL(ctx, target, body.AddFile.Path, body.AddFile.Datum, src.Url)
+		default:
+			return errors.Errorf("cannot add %s: no source", body.AddFile.Path)
+		}
+	case *pfs.ModifyFileRequest_DeleteFile:
+		return a.driver.deleteFile(target, body.DeleteFile.Path, body.DeleteFile.Datum)
+	case *pfs.ModifyFileRequest_CopyFile:
+		if err := validateFile(body.CopyFile.Src); err != nil {
+			return err
+		}
+		return a.driver.copyFile(target, body.CopyFile.Dst, body.CopyFile.Datum, body.CopyFile.Src, body.CopyFile.Append)
+	default:
+		return errors.Errorf("unrecognized modify file request: %v", req)
+	}
+}
+
+// GetFile implements the protobuf pfs.GetFile RPC
+func (a *apiServer) GetFile(request *pfs.GetFileRequest, srv pfs.API_GetFileServer) error {
+	if err := validateFile(request.File); err != nil {
+		return err
+	}
+	ranges, err := fileRanges(request)
+	if err != nil {
+		return err
+	}
+	if request.URL != "" {
+		return a.driver.getFileURL(srv.Context(), request.File, ranges, request.URL)
+	}
+	return a.driver.getFile(srv.Context(), request.File, ranges, grpcutil.NewStreamingBytesWriter(srv))
+}
+
+// fileRanges returns the byte ranges selected by a GetFile request.
+func fileRanges(request *pfs.GetFileRequest) ([]*pfs.ByteRange, error) {
+	ranges := request.Ranges
+	if len(ranges) == 0 {
+		ranges = []*pfs.ByteRange{{Offset: request.Offset, SizeBytes: request.SizeBytes}}
+	} else if request.Offset != 0 || request.SizeBytes != 0 {
+		return nil, errors.New("offset and size cannot be used together with ranges")
+	}
+	for _, r := range ranges {
+		if r.Offset < 0 || r.SizeBytes < 0 {
+			return nil, errors.Errorf("invalid range: offset %d, size %d", r.Offset, r.SizeBytes)
+		}
+	}
+	return ranges, nil
+}
+
+// GetFileTAR implements the protobuf pfs.GetFileTAR RPC
+func (a *apiServer) GetFileTAR(request *pfs.GetFileRequest, srv pfs.API_GetFileTARServer) error {
+	if err := validateFile(request.File); err != nil {
+		return err
+	}
+	if request.URL != "" {
+		return errors.New("writing files to a URL is not supported")
+	}
+	if request.Offset != 0 || request.SizeBytes != 0 || len(request.Ranges) > 0 {
+		return errors.New("a range cannot be used when getting a tar archive")
+	}
+	// tar writes many small headers, so batch them into full messages
+	w := bufio.NewWriterSize(grpcutil.NewStreamingBytesWriter(srv), grpcutil.ChunkSize)
+	if err := a.driver.getFileTAR(srv.Context(), request.File, w); err != nil {
+		return err
+	}
+	return errors.EnsureStack(w.Flush())
+}
+
+// InspectFile implements the protobuf pfs.InspectFile RPC
+func (a *apiServer) InspectFile(ctx context.Context, request *pfs.InspectFileRequest) (*pfs.FileInfo, error) {
+	if err := validateFile(request.File); err != nil {
+		return nil, err
+	}
+	return a.driver.inspectFile(request.File)
+}
+
+// ListFile implements the protobuf pfs.ListFile RPC
+func (a *apiServer) ListFile(request *pfs.ListFileRequest, srv pfs.API_ListFileServer) error {
+	if err := validateFile(request.File); err != nil {
+		return err
+	}
+	return a.driver.listFile(request.File, srv.Send)
+}
+
+// WalkFile implements the protobuf pfs.WalkFile RPC
+func (a *apiServer) WalkFile(request *pfs.WalkFileRequest, srv pfs.API_WalkFileServer) error {
+	if err := validateFile(request.File); err != nil {
+		return err
+	}
+	return a.driver.walkFile(request.File, srv.Send)
+}
+
+// GlobFile implements the protobuf pfs.GlobFile RPC
+func (a *apiServer) GlobFile(request *pfs.GlobFileRequest, srv pfs.API_GlobFileServer) error {
+	if err := validateCommit(request.Commit); err != nil {
+		return err
+	}
+	return a.driver.globFile(request.Commit, request.Pattern, srv.Send)
+}
+
+// DiffFile implements the protobuf pfs.DiffFile RPC
+func (a *apiServer) DiffFile(request *pfs.DiffFileRequest, srv pfs.API_DiffFileServer) error {
+	if err := validateFile(request.NewFile); err != nil {
+		return err
+	}
+	if request.OldFile != nil {
+		if err := validateFile(request.OldFile); err != nil {
+			return err
+		}
+	}
+	return a.driver.diffFile(request.NewFile, request.OldFile, request.Shallow, srv.Send)
+}
+
+// StartUpload implements the protobuf pfs.StartUpload RPC
+func (a *apiServer) StartUpload(ctx context.Context, request *pfs.StartUploadRequest) (*pfs.UploadInfo, error) {
+	if err := validateFile(request.File); err != nil {
+		return nil, err
+	}
+	return a.driver.startUpload(request.File, request.Datum, request.SizeBytes)
+}
+
+// PutUploadPart implements the protobuf pfs.PutUploadPart RPC
+func (a *apiServer) PutUploadPart(srv pfs.API_PutUploadPartServer) error {
+	req, err := srv.Recv()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	header := req.GetHeader()
+	if header == nil {
+		return errors.New("the first message of an upload part must be a header")
+	}
+	info, err := a.driver.putUploadPart(srv.Context(), header, &uploadPartReader{srv: srv})
+	if err != nil {
+		return err
+	}
+	return errors.EnsureStack(srv.SendAndClose(info))
+}
+
+// uploadPartReader reads the content of an upload part from the data
+// messages that follow its header.
+type uploadPartReader struct {
+	srv pfs.API_PutUploadPartServer
+	buf []byte
+}
+
+func (r *uploadPartReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.srv.Recv()
+		if err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		data, ok := req.Body.(*pfs.PutUploadPartRequest_Data)
+		if !ok {
+			return 0, errors.New("an upload part must have a single header")
+		}
+		r.buf = data.Data.GetValue()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// InspectUpload implements the protobuf pfs.InspectUpload RPC
+func (a *apiServer) InspectUpload(ctx context.Context, request *pfs.InspectUploadRequest) (*pfs.UploadInfo, error) {
+	return a.driver.inspectUpload(request.UploadId)
+}
+
+// FinishUpload implements the protobuf pfs.FinishUpload RPC
+func (a *apiServer) FinishUpload(ctx context.Context, request *pfs.FinishUploadRequest) (*emptypb.Empty, error) {
+	if err := a.driver.finishUpload(request.UploadId); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// AbortUpload implements the protobuf pfs.AbortUpload RPC
+func (a *apiServer) AbortUpload(ctx context.Context, request *pfs.AbortUploadRequest) (*emptypb.Empty, error) {
+	if err := a.driver.abortUpload(request.UploadId); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CreateFileSet implements the protobuf pfs.CreateFileSet RPC
+func (a *apiServer) CreateFileSet(srv pfs.API_CreateFileSetServer) error {
+	w := a.driver.newFileSetWriter()
+	for {
+		req, err := srv.Recv()
+		if err != nil {
+			if err == io.EOF {
+				return errors.EnsureStack(srv.SendAndClose(&pfs.CreateFileSetResponse{FileSetId: w.id}))
+			}
+			return errors.EnsureStack(err)
+		}
+		if _, ok := req.Body.(*pfs.ModifyFileRequest_SetCommit); ok {
+			return errors.New("a commit cannot be set when creating a file set")
+		}
+		if err := a.modifyFile(srv.Context(), w, req); err != nil {
+			return err
+		}
+	}
+}
+
+// GetFileSet implements the protobuf pfs.GetFileSet RPC
+func (a *apiServer) GetFileSet(ctx context.Context, request *pfs.GetFileSetRequest) (*pfs.CreateFileSetResponse, error) {
+	if err := validateCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	id, err := a.driver.getFileSet(request.Commit)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.CreateFileSetResponse{FileSetId: id}, nil
+}
+
+// AddFileSet implements the protobuf pfs.AddFileSet RPC
+func (a *apiServer) AddFileSet(ctx context.Context, request *pfs.AddFileSetRequest) (*emptypb.Empty, error) {
+	if err := validateCommit(request.Commit); err != nil {
+		return nil, err
+	}
+	if err := a.driver.addFileSet(request.Commit, request.FileSetId); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RenewFileSet implements the protobuf pfs.RenewFileSet RPC
+func (a *apiServer) RenewFileSet(ctx context.Context, request *pfs.RenewFileSetRequest) (*emptypb.Empty, error) {
+	if request.TtlSeconds <= 0 {
+		return nil, errors.Errorf("invalid file set TTL %ds: it must be positive", request.TtlSeconds)
+	}
+	if err := a.driver.renewFileSet(request.FileSetId, time.Duration(request.TtlSeconds)*time.Second); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ComposeFileSet implements the protobuf pfs.ComposeFileSet RPC
+func (a *apiServer) ComposeFileSet(ctx context.Context, request *pfs.ComposeFileSetRequest) (*pfs.CreateFileSetResponse, error) {
+	ttl, err := fileSetTTL(request.TtlSeconds)
+	if err != nil {
+		return nil, err
+	}
+	id, err := a.driver.composeFileSet(request.FileSetIds, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.CreateFileSetResponse{FileSetId: id}, nil
+}
+
+// Fsck implements the protobuf pfs.Fsck RPC
+func (a *apiServer) Fsck(request *pfs.FsckRequest, srv pfs.API_FsckServer) error {
+	return a.driver.fsck(srv.Context(), request.Fix, srv.Send)
+}
+
+// Egress implements the protobuf pfs.Egress RPC
+func (a *apiServer) Egress(ctx context.Context, request *pfs.EgressRequest) (*pfs.EgressResponse, error) {
+	if request.Commit == nil {
+		return nil, errors.New("commit cannot be nil")
+	}
+	switch target := request.Target.(type) {
+	case *pfs.EgressRequest_ObjectStorage:
+		n, err := a.driver.egressObjectStorage(ctx, request.Commit, target.ObjectStorage)
+		if err != nil {
+			return nil, err
+		}
+		return &pfs.EgressResponse{Result: &pfs.EgressResponse_ObjectStorage{
+			ObjectStorage: &pfs.EgressResponse_ObjectStorageResult{BytesWritten: n},
+		}}, nil
+	case *pfs.EgressRequest_SqlDatabase:
+		rows, err := a.driver.egressSQLDatabase(ctx, request.Commit, target.SqlDatabase)
+		if err != nil {
+			return nil, err
+		}
+		return &pfs.EgressResponse{Result: &pfs.EgressResponse_SqlDatabase{
+			SqlDatabase: &pfs.EgressResponse_SQLDatabaseResult{RowsWritten: rows},
+		}}, nil
+	}
+	return nil, errors.New("egress target cannot be nil")
+}
+
+// IngestSQL implements the protobuf pfs.IngestSQL RPC
+func (a *apiServer) IngestSQL(ctx context.Context, request *pfs.IngestSQLRequest) (*pfs.IngestSQLResponse, error) {
+	if err := validateBranch(request.Branch); err != nil {
+		return nil, err
+	}
+	if request.Sql == nil {
+		return nil, errors.New("sql cannot be nil")
+	}
+	commit, rows, err := a.driver.ingestSQL(ctx, request.Branch, request.Sql)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.IngestSQLResponse{Commit: commit, RowsRead: rows}, nil
+}
+
+// PutCache implements the protobuf pfs.PutCache RPC
+func (a *apiServer) PutCache(ctx context.Context, request *pfs.PutCacheRequest) (*emptypb.Empty, error) {
+	if request.Key == "" {
+		return nil, errors.New("cache key cannot be empty")
+	}
+	if request.Value == nil {
+		return nil, errors.New("cache value cannot be nil")
+	}
+	if err := a.driver.putCache(request.Key, request.Value, request.FileSetIds, request.Tag); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetCache implements the protobuf pfs.GetCache RPC. The value is nil if
+// nothing is cached under the key.
+func (a *apiServer) GetCache(ctx context.Context, request *pfs.GetCacheRequest) (*pfs.GetCacheResponse, error) {
+	return &pfs.GetCacheResponse{Value: a.driver.getCache(request.Key)}, nil
+}
+
+// ClearCache implements the protobuf pfs.ClearCache RPC
+func (a *apiServer) ClearCache(ctx context.Context, request *pfs.ClearCacheRequest) (*emptypb.Empty, error) {
+	a.driver.clearCache(request.TagPrefix)
+	return &emptypb.Empty{}, nil
+}
+
+// RunLoadTest implements the protobuf pfs.RunLoadTest RPC
+func (a *apiServer) RunLoadTest(ctx context.Context, request *pfs.RunLoadTestRequest) (*pfs.RunLoadTestResponse, error) {
+	branch := request.Branch
+	if branch == nil {
+		branch = defaultLoadTestBranch
+	}
+	if err := validateBranch(branch); err != nil {
+		return nil, err
+	}
+	c, err := a.loopbackClient()
+	if err != nil {
+		return nil, err
+	}
+	return RunLoadTest(ctx, c, branch, request.Spec, request.Seed)
+}
+
+// RunLoadTestDefault implements the protobuf pfs.RunLoadTestDefault RPC. It
+// runs DefaultLoadTestSpec with a random seed, which the response reports.
+func (a *apiServer) RunLoadTestDefault(ctx context.Context, _ *emptypb.Empty) (*pfs.RunLoadTestResponse, error) {
+	return a.RunLoadTest(ctx, &pfs.RunLoadTestRequest{Spec: DefaultLoadTestSpec, Seed: time.Now().UnixNano()})
+}
+
+// ListTask implements the protobuf pfs.ListTask RPC
+func (a *apiServer) ListTask(request *taskapi.ListTaskRequest, srv pfs.API_ListTaskServer) error {
+	return a.driver.tasks.List(request.Group, srv.Send)
+}
+
+// DeleteAll implements the protobuf pfs.DeleteAll RPC
+func (a *apiServer) DeleteAll(ctx context.Context, request *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := a.driver.deleteAll(); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// CheckStorage implements the protobuf pfs.CheckStorage RPC
+func (a *apiServer) CheckStorage(ctx context.Context, request *pfs.CheckStorageRequest) (*pfs.CheckStorageResponse, error) {
+	n, err := a.driver.storage.Check(ctx, request.ChunkBegin, request.ChunkEnd, request.ReadChunkData)
+	if err != nil {
+		return nil, err
+	}
+	return &pfs.CheckStorageResponse{ChunkObjectCount: n}, nil
+}
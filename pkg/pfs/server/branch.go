@@ -0,0 +1,303 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+func (d *driver) createBranch(branch *pfs.Branch, head *pfs.Commit, provenance []*pfs.Branch, trigger *pfs.Trigger) error {
+	if err := ValidateBranchName(branch.Name); err != nil {
+		return err
+	}
+	if trigger != nil {
+		if err := validateTrigger(branch, trigger); err != nil {
+			return err
+		}
+	}
+	return d.write(func(tx *txn) error {
+		return tx.createBranch(branch, head, provenance, trigger)
+	})
+}
+
+// createBranch creates branch, or updates it if it already exists. The
+// branch's direct provenance and trigger are replaced with provenance and
+// trigger, creating any branches they refer to that don't exist yet. If head
+// is set the branch is moved to it; otherwise a branch whose provenance
+// changed gets a new open AUTO commit, a new triggered branch starts at the
+// head of the branch it watches, and any other new branch starts at an empty
+// commit. Either way, every branch downstream gets a new
+// AUTO commit in the same CommitSet, and triggers are re-evaluated.
+func (tx *txn) createBranch(branch *pfs.Branch, head *pfs.Commit, provenance []*pfs.Branch, trigger *pfs.Trigger) error {
+	if trigger != nil && len(provenance) > 0 {
+		return errors.Errorf("branch %v cannot have both provenance and a trigger", branch)
+	}
+	repoInfo, err := tx.getRepoInfo(branch.Repo)
+	if err != nil {
+		return err
+	}
+	bi, err := tx.getBranchInfo(branch)
+	exists := err == nil
+	if err != nil && !IsBranchNotFoundErr(err) {
+		return err
+	}
+	if !exists {
+		bi = &pfs.BranchInfo{Branch: branch}
+		tx.putBranchInfo(bi)
+		tx.addRepoBranch(repoInfo, branch)
+	}
+	provenance = dedupBranches(provenance)
+	for _, p := range provenance {
+		if p.Key() == branch.Key() {
+			return ErrProvenanceCycle{Branch: branch, Provenance: p}
+		}
+		if err := tx.ensureBranch(p); err != nil {
+			return err
+		}
+	}
+	if trigger != nil {
+		if err := tx.checkTriggerCycle(branch, trigger); err != nil {
+			return err
+		}
+		if err := tx.ensureBranch(branch.Repo.NewBranch(trigger.Branch)); err != nil {
+			return err
+		}
+	}
+	provenanceChanged := !sameBranches(bi.DirectProvenance, provenance)
+	if provenanceChanged {
+		if err := tx.setDirectProvenance(bi, provenance); err != nil {
+			return err
+		}
+		// pick up the provenance and subvenance computed for bi
+		if bi, err = tx.getBranchInfo(branch); err != nil {
+			return err
+		}
+	}
+	if !proto.Equal(bi.Trigger, trigger) {
+		bi.Trigger = trigger
+		tx.putBranchInfo(bi)
+	}
+	if head == nil && bi.Head == nil && trigger != nil {
+		// a new triggered branch starts where the branch it watches is
+		tbi, err := tx.getBranchInfo(branch.Repo.NewBranch(trigger.Branch))
+		if err != nil {
+			return err
+		}
+		head = tbi.Head
+	}
+	id := newCommitSetID()
+	moved := true
+	switch {
+	case head != nil:
+		ci, err := tx.resolveCommit(head)
+		if err != nil {
+			return err
+		}
+		if ci.Commit.Branch.Repo.Key() != branch.Repo.Key() {
+			return errors.Errorf("branch %v and its head %v must belong to the same repo", branch, ci.Commit)
+		}
+		if bi.Head != nil && bi.Head.Key() == ci.Commit.Key() {
+			moved = false
+			break
+		}
+		bi.Head = ci.Commit
+		tx.putBranchInfo(bi)
+	case len(bi.DirectProvenance) > 0 && (provenanceChanged || bi.Head == nil):
+		tx.newAutoCommit(bi, id)
+	case bi.Head == nil:
+		// every branch has a head, new branches start at an empty commit
+		ci := tx.newCommitInSet(branch, nil, id, pfs.OriginKind_AUTO, "")
+		tx.finishCommit(ci)
+		bi.Head = ci.Commit
+		tx.putBranchInfo(bi)
+	default:
+		moved = false
+	}
+	if moved || provenanceChanged {
+		if err := tx.propagate([]*pfs.Branch{branch}, id); err != nil {
+			return err
+		}
+	}
+	if moved {
+		if err := tx.fireTriggers(branch); err != nil {
+			return err
+		}
+	}
+	if trigger == nil {
+		return nil
+	}
+	// the trigger may already be satisfied by what's on the watched branch
+	if bi, err = tx.getBranchInfo(branch); err != nil {
+		return err
+	}
+	tbi, err := tx.getBranchInfo(branch.Repo.NewBranch(trigger.Branch))
+	if err != nil {
+		return err
+	}
+	if newHead, ok := tx.getCommitInfoByKey(tbi.Head.Key()); ok && newHead.Finished != nil {
+		return tx.fireTrigger(bi, newHead)
+	}
+	return nil
+}
+
+// ensureBranch creates branch, with an empty head, if it doesn't exist.
+func (tx *txn) ensureBranch(branch *pfs.Branch) error {
+	_, err := tx.getBranchInfo(branch)
+	if IsBranchNotFoundErr(err) {
+		return tx.createBranch(branch, nil, nil, nil)
+	}
+	return err
+}
+
+// dedupBranches returns branches sorted, without duplicates.
+func dedupBranches(branches []*pfs.Branch) []*pfs.Branch {
+	m := make(map[string]*pfs.Branch)
+	for _, b := range branches {
+		m[b.Key()] = b
+	}
+	return sortedBranches(m)
+}
+
+// addRepoBranch records a new branch in its RepoInfo.
+func (tx *txn) addRepoBranch(repoInfo *pfs.RepoInfo, branch *pfs.Branch) {
+	repoInfo.Branches = append(repoInfo.Branches, branch)
+	sort.Slice(repoInfo.Branches, func(i, j int) bool { return repoInfo.Branches[i].Name < repoInfo.Branches[j].Name })
+	tx.putRepoInfo(repoInfo)
+}
+
+func (d *driver) inspectBranch(branch *pfs.Branch) (*pfs.BranchInfo, error) {
+	var info *pfs.BranchInfo
+	if err := d.read(func(tx *txn) error {
+		if _, err := tx.getRepoInfo(branch.Repo); err != nil {
+			return err
+		}
+		var err error
+		if info, err = tx.getBranchInfo(branch); err != nil {
+			return err
+		}
+		if info.Head != nil {
+			tx.addBranchSizes(info)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// listBranch lists the branches of repo, or of all repos if repo is nil,
+// ordered by the start time of their heads, newest first.
+func (d *driver) listBranch(repo *pfs.Repo, reverse bool, cb func(*pfs.BranchInfo) error) error {
+	var infos []*pfs.BranchInfo
+	if err := d.read(func(tx *txn) error {
+		if repo != nil {
+			if _, err := tx.getRepoInfo(repo); err != nil {
+				return err
+			}
+		}
+		infos = tx.listBranchInfos(repo)
+		started := make(map[string]int64)
+		for _, bi := range infos {
+			if bi.Head == nil {
+				continue
+			}
+			tx.addBranchSizes(bi)
+			if ci, ok := tx.getCommitInfoByKey(bi.Head.Key()); ok {
+				started[bi.Branch.Key()] = ci.Started.AsTime().UnixNano()
+			}
+		}
+		sort.SliceStable(infos, func(i, j int) bool {
+			ti, tj := started[infos[i].Branch.Key()], started[infos[j].Branch.Key()]
+			if reverse {
+				return ti < tj
+			}
+			return ti > tj
+		})
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *driver) deleteBranch(branch *pfs.Branch, force bool) error {
+	return d.write(func(tx *txn) error {
+		if _, err := tx.getRepoInfo(branch.Repo); err != nil {
+			return err
+		}
+		return tx.deleteBranch(branch, force)
+	})
+}
+
+// deleteBranch deletes branch. A branch that other branches are provenant on
+// can only be deleted with force, in which case it is removed from their
+// provenance.
+func (tx *txn) deleteBranch(branch *pfs.Branch, force bool) error {
+	bi, err := tx.getBranchInfo(branch)
+	if err != nil {
+		return err
+	}
+	if len(bi.Subvenance) > 0 {
+		if !force {
+			return errors.Errorf("branch %v has subvenance %v; use force to delete it", branch, bi.Subvenance)
+		}
+		for _, sb := range bi.Subvenance {
+			sbi, err := tx.getBranchInfo(sb)
+			if err != nil {
+				return err
+			}
+			var prov []*pfs.Branch
+			for _, p := range sbi.DirectProvenance {
+				if p.Key() != branch.Key() {
+					prov = append(prov, p)
+				}
+			}
+			if len(prov) != len(sbi.DirectProvenance) {
+				sbi.DirectProvenance = prov
+				tx.putBranchInfo(sbi)
+			}
+		}
+	}
+	tx.delete(branchesCollection, branch.Key())
+	repoInfo, err := tx.getRepoInfo(branch.Repo)
+	if err != nil {
+		return err
+	}
+	for i, b := range repoInfo.Branches {
+		if b.Name == branch.Name {
+			repoInfo.Branches = append(repoInfo.Branches[:i], repoInfo.Branches[i+1:]...)
+			break
+		}
+	}
+	tx.putRepoInfo(repoInfo)
+	tx.updateProvenance()
+	return nil
+}
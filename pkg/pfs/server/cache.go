@@ -0,0 +1,173 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// defaultCacheSize is the default size budget of the cache, in bytes.
+const defaultCacheSize = 64 << 20
+
+// cache is an in-memory key-value cache, for results that are expensive to
+// compute from the content of commits, such as the outputs of datums. Each
+// entry pins the file sets it refers to, so that they outlive their TTL for as
+// long as the entry is cached. An entry's size includes the content of the
+// file sets it pins, so that the budget also limits the storage the cache
+// keeps from garbage collection. Entries are evicted least recently used
+// first once their total size exceeds the budget, and they can be invalidated
+// by the prefix of their tag. Like file sets, the cache does not survive a
+// restart.
+type cache struct {
+	mu      sync.Mutex
+	budget  int64
+	size    int64
+	entries map[string]*list.Element
+	// lru orders the entries from most to least recently used.
+	lru *list.List
+}
+
+type cacheEntry struct {
+	key        string
+	value      *anypb.Any
+	fileSetIDs []string
+	tag        string
+	size       int64
+}
+
+func newCache(budget int64) *cache {
+	if budget <= 0 {
+		budget = defaultCacheSize
+	}
+	return &cache{budget: budget, entries: make(map[string]*list.Element), lru: list.New()}
+}
+
+// putCache caches value under key, replacing any entry that is already
+// cached under it, and pins fileSetIDs until the entry is evicted or
+// cleared. It fails if a file set does not exist, or if the entry alone
+// exceeds the cache's budget.
+func (d *driver) putCache(key string, value *anypb.Any, fileSetIDs []string, tag string) error {
+	e := &cacheEntry{key: key, value: value, fileSetIDs: fileSetIDs, tag: tag}
+	e.size = int64(len(key)+len(tag)) + int64(proto.Size(value))
+	for _, id := range fileSetIDs {
+		e.size += int64(len(id))
+	}
+	c := d.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pinned, err := d.pinFileSets(fileSetIDs)
+	if err != nil {
+		return err
+	}
+	e.size += pinned
+	if e.size > c.budget {
+		d.unpinFileSets(fileSetIDs)
+		return errors.Errorf("cache entry %q is %d bytes, including the file sets it pins, which exceeds the cache size of %d bytes", key, e.size, c.budget)
+	}
+	if el, ok := c.entries[key]; ok {
+		d.removeCacheEntry(el)
+	}
+	c.entries[key] = c.lru.PushFront(e)
+	c.size += e.size
+	for c.size > c.budget {
+		d.removeCacheEntry(c.lru.Back())
+	}
+	return nil
+}
+
+// getCache returns the value cached under key, or nil if there is none.
+func (d *driver) getCache(key string) *anypb.Any {
+	c := d.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return el.Value.(*cacheEntry).value
+}
+
+// clearCache removes every entry whose tag starts with tagPrefix.
+func (d *driver) clearCache(tagPrefix string) {
+	c := d.cache
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for el := c.lru.Front(); el != nil; {
+		next := el.Next()
+		if strings.HasPrefix(el.Value.(*cacheEntry).tag, tagPrefix) {
+			d.removeCacheEntry(el)
+		}
+		el = next
+	}
+}
+
+// removeCacheEntry removes an entry and unpins its file sets. The caller
+// must hold the cache's lock.
+func (d *driver) removeCacheEntry(el *list.Element) {
+	c := d.cache
+	e := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	c.size -= e.size
+	d.unpinFileSets(e.fileSetIDs)
+}
+
+// pinFileSets pins each of the file sets ids, or none of them if one does
+// not exist, and returns the size of their content.
+func (d *driver) pinFileSets(ids []string) (int64, error) {
+	d.fileSetsMu.Lock()
+	defer d.fileSetsMu.Unlock()
+	var size int64
+	counted := make(map[string]bool)
+	for _, id := range ids {
+		fs, err := d.lookupFileSet(id)
+		if err != nil {
+			return 0, err
+		}
+		if !counted[id] {
+			counted[id] = true
+			size += fs.size()
+		}
+	}
+	for _, id := range ids {
+		d.fileSets[id].pins++
+	}
+	return size, nil
+}
+
+// unpinFileSets unpins each of the file sets ids, which expire as usual
+// once they are no longer pinned.
+func (d *driver) unpinFileSets(ids []string) {
+	d.fileSetsMu.Lock()
+	defer d.fileSetsMu.Unlock()
+	for _, id := range ids {
+		if fs, ok := d.fileSets[id]; ok {
+			fs.pins--
+		}
+	}
+}
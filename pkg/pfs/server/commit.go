@@ -0,0 +1,891 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// resolveCommit returns the CommitInfo a client supplied commit refers to.
+// The commit may be given by ID, in which case its branch is ignored, or by
+// branch, in which case it refers to the branch's head. A branch name that
+// does not name a branch is tried as a commit ID, so that "repo@<id>" works
+// as well as "repo=<id>". Either form may carry an ancestry suffix: "master^"
+// or "master~1" is the parent of master's head, "master^^" or "master~2" its
+// grandparent, and so on.
+func (tx *txn) resolveCommit(commit *pfs.Commit) (*pfs.CommitInfo, error) {
+	repo := commit.Branch.Repo
+	if _, err := tx.getRepoInfo(repo); err != nil {
+		return nil, err
+	}
+	id, ancestry, err := parseAncestry(commit.Id)
+	if err != nil {
+		return nil, err
+	}
+	var ci *pfs.CommitInfo
+	if id != "" {
+		// a CommitSet may have commits on several branches of the same repo,
+		// so prefer the commit on the given branch
+		var ok bool
+		if commit.Branch.Name != "" {
+			ci, ok = tx.getCommitInfoByKey(commit.Branch.NewCommit(id).Key())
+		}
+		if !ok {
+			if ci, ok = tx.getCommitInfoByID(repo, id); !ok {
+				return nil, ErrCommitNotFound{Commit: commit}
+			}
+		}
+	} else {
+		var name string
+		if name, ancestry, err = parseAncestry(commit.Branch.Name); err != nil {
+			return nil, err
+		}
+		if name == "" {
+			return nil, errors.Errorf("commit %v must specify a branch or an ID", commit)
+		}
+		bi, err := tx.getBranchInfo(repo.NewBranch(name))
+		switch {
+		case err == nil:
+			ci, _ = tx.getCommitInfoByKey(bi.Head.Key())
+		case IsBranchNotFoundErr(err):
+			var ok bool
+			if ci, ok = tx.getCommitInfoByID(repo, name); !ok {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+	}
+	for i := 0; i < ancestry; i++ {
+		if ci.ParentCommit == nil {
+			return nil, ErrCommitNotFound{Commit: commit}
+		}
+		ci, _ = tx.getCommitInfoByKey(ci.ParentCommit.Key())
+	}
+	return ci, nil
+}
+
+// parseAncestry splits a commit ID or branch name like "master^2" into the
+// base ("master") and the number of generations to go back (2).
+func parseAncestry(name string) (string, int, error) {
+	i := strings.IndexAny(name, "^~")
+	if i < 0 {
+		return name, 0, nil
+	}
+	base, suffix := name[:i], name[i:]
+	if n, err := strconv.Atoi(suffix[1:]); err == nil {
+		return base, n, nil
+	}
+	if strings.Trim(suffix, "^") != "" {
+		return "", 0, errors.Errorf("invalid ancestry syntax %q", name)
+	}
+	return base, len(suffix), nil
+}
+
+// newCommit stages a new open commit on branch and returns its CommitInfo.
+// The commit gets a fresh ID and therefore a new CommitSet.
+func (tx *txn) newCommit(branch *pfs.Branch, parent *pfs.CommitInfo, origin pfs.OriginKind, description string) *pfs.CommitInfo {
+	return tx.newCommitInSet(branch, parent, newCommitSetID(), origin, description)
+}
+
+// newCommitInSet is like newCommit, but adds the commit to an existing
+// CommitSet.
+func (tx *txn) newCommitInSet(branch *pfs.Branch, parent *pfs.CommitInfo, id string, origin pfs.OriginKind, description string) *pfs.CommitInfo {
+	ci := &pfs.CommitInfo{
+		Commit:      branch.NewCommit(id),
+		Origin:      &pfs.CommitOrigin{Kind: origin},
+		Description: description,
+		Started:     timestamppb.Now(),
+	}
+	var parentCommit *pfs.Commit
+	if parent != nil {
+		parentCommit = parent.Commit
+		ci.ParentCommit = parent.Commit
+		parent.ChildCommits = append(parent.ChildCommits, ci.Commit)
+		tx.putCommitInfo(parent)
+	}
+	tx.putCommitInfo(ci)
+	tx.afterApply(func() { tx.d.startFiles(ci.Commit, parentCommit) })
+	return ci
+}
+
+// finishCommit stages ci as finished straight away. Commits that clients
+// finish go through driver.finishCommit instead, which compacts their
+// content as a task.
+func (tx *txn) finishCommit(ci *pfs.CommitInfo) {
+	now := timestamppb.Now()
+	if ci.Finishing == nil {
+		ci.Finishing = now
+	}
+	ci.Finished = now
+	tx.putCommitInfo(ci)
+	commit, finished := ci.Commit, ci.Finished
+	tx.afterApply(func() { tx.d.finishFiles(commit, finished) })
+}
+
+func (d *driver) startCommit(branch *pfs.Branch, parent *pfs.Commit, description string) (*pfs.Commit, error) {
+	if err := ValidateBranchName(branch.Name); err != nil {
+		return nil, err
+	}
+	var commit *pfs.Commit
+	if err := d.write(func(tx *txn) error {
+		repoInfo, err := tx.getRepoInfo(branch.Repo)
+		if err != nil {
+			return err
+		}
+		bi, err := tx.getBranchInfo(branch)
+		exists := err == nil
+		if err != nil && !IsBranchNotFoundErr(err) {
+			return err
+		}
+		if exists && len(bi.DirectProvenance) > 0 {
+			return ErrCommitOnOutputBranch{Branch: branch}
+		}
+		var parentInfo *pfs.CommitInfo
+		switch {
+		case parent != nil:
+			if parentInfo, err = tx.resolveCommit(parent); err != nil {
+				return err
+			}
+			if parentInfo.Commit.Branch.Repo.Key() != branch.Repo.Key() {
+				return errors.Errorf("parent commit %v must belong to repo %v", parentInfo.Commit, branch.Repo)
+			}
+		case exists:
+			parentInfo, _ = tx.getCommitInfoByKey(bi.Head.Key())
+		}
+		if parentInfo != nil && parentInfo.Finishing == nil {
+			return ErrCommitNotFinished{Commit: parentInfo.Commit}
+		}
+		ci := tx.newCommit(branch, parentInfo, pfs.OriginKind_USER, description)
+		if !exists {
+			bi = &pfs.BranchInfo{Branch: branch}
+			tx.addRepoBranch(repoInfo, branch)
+		}
+		bi.Head = ci.Commit
+		tx.putBranchInfo(bi)
+		commit = ci.Commit
+		return tx.propagate([]*pfs.Branch{branch}, ci.Commit.Id)
+	}); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+// finishCommit marks commit as finishing, then finishes it through a task and
+// waits for that to be done, or for ctx to be done. The task carries on if
+// ctx is done first.
+func (d *driver) finishCommit(ctx context.Context, commit *pfs.Commit, description, commitError string) error {
+	var finishing *pfs.Commit
+	if err := d.write(func(tx *txn) error {
+		ci, err := tx.resolveCommit(commit)
+		if err != nil {
+			return err
+		}
+		if ci.Finishing != nil {
+			return ErrCommitFinished{Commit: ci.Commit}
+		}
+		if description != "" {
+			ci.Description = description
+		}
+		ci.Error = commitError
+		ci.Finishing = timestamppb.Now()
+		tx.putCommitInfo(ci)
+		finishing = ci.Commit
+		return nil
+	}); err != nil {
+		return err
+	}
+	done := make(chan error, 1)
+	go func() { done <- d.runFinish(finishing) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return errors.EnsureStack(ctx.Err())
+	}
+}
+
+// runFinish runs the task that finishes commit, and waits for it. If the
+// task fails for good, the commit is finished straight away, with the
+// failure as its error, so that it is not left finishing. Should that fail
+// too, the commit is left finishing until the driver restarts and tries
+// again.
+func (d *driver) runFinish(commit *pfs.Commit) error {
+	d.startWorkers()
+	input, err := anypb.New(commit)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	group := &taskapi.Group{Namespace: taskNamespace, Group: compactionTaskGroup}
+	var failure error
+	if err := d.tasks.Do(context.Background(), group, []*anypb.Any{input}, func(_ int, _ *anypb.Any, err error) error {
+		failure = err
+		return nil
+	}); err != nil {
+		return err
+	}
+	if failure == nil {
+		return nil
+	}
+	failure = errors.Wrapf(failure, "could not finish %v", commit)
+	if err := d.write(func(tx *txn) error {
+		ci, ok := tx.getCommitInfoByKey(commit.Key())
+		if !ok || ci.Finished != nil {
+			return nil
+		}
+		ci.Error = failure.Error()
+		tx.finishCommit(ci)
+		return nil
+	}); err != nil {
+		log.WithError(err).WithField("commit", commit).Error("could not finish commit after its task failed")
+	}
+	return failure
+}
+
+// processFinish is the task that finishes a commit: it compacts the
+// commit's diff into its final content and validates that content, marks
+// the commit finished and fires the triggers of its branch. Commits that
+// have been deleted or have already finished are left alone.
+func (d *driver) processFinish(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+	var commit pfs.Commit
+	if err := input.UnmarshalTo(&commit); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if err := d.write(func(tx *txn) error {
+		ci, ok := tx.getCommitInfoByKey(commit.Key())
+		if !ok || ci.Finished != nil {
+			return nil
+		}
+		tx.finishCommit(ci)
+		return tx.fireTriggers(ci.Commit.Branch)
+	}); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// resumeFinishing finishes the commits that were left finishing when the
+// driver last stopped.
+func (d *driver) resumeFinishing() {
+	var commits []*pfs.Commit
+	d.read(func(tx *txn) error {
+		for _, ci := range tx.listCommitInfos(nil) {
+			if ci.Finishing != nil && ci.Finished == nil {
+				commits = append(commits, ci.Commit)
+			}
+		}
+		return nil
+	})
+	for _, commit := range commits {
+		go func(commit *pfs.Commit) {
+			if err := d.runFinish(commit); err != nil {
+				log.WithError(err).WithField("commit", commit).Error("could not resume finishing commit")
+			}
+		}(commit)
+	}
+}
+
+func (d *driver) clearCommit(commit *pfs.Commit) error {
+	return d.write(func(tx *txn) error {
+		ci, err := tx.resolveCommit(commit)
+		if err != nil {
+			return err
+		}
+		if ci.Finishing != nil {
+			return ErrCommitFinished{Commit: ci.Commit}
+		}
+		*tx.diffChange(ci.Commit) = diffChange{reset: true}
+		tx.afterApply(func() { tx.d.startFiles(ci.Commit, ci.ParentCommit) })
+		return nil
+	})
+}
+
+// inspectCommit returns the CommitInfo of commit, first waiting until it has
+// reached state, if state is set.
+func (d *driver) inspectCommit(ctx context.Context, commit *pfs.Commit, state pfs.CommitState) (*pfs.CommitInfo, error) {
+	var info *pfs.CommitInfo
+	if err := d.wait(ctx, func(tx *txn) (bool, error) {
+		ci, err := tx.resolveCommit(commit)
+		if err != nil {
+			return false, err
+		}
+		tx.addCommitSizes(ci)
+		info = ci
+		return tx.commitReached(ci, state), nil
+	}); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// commitReached returns true if ci is in at least the given state.
+func (tx *txn) commitReached(ci *pfs.CommitInfo, state pfs.CommitState) bool {
+	switch state {
+	case pfs.CommitState_READY:
+		return tx.commitReady(ci)
+	case pfs.CommitState_FINISHING:
+		return ci.Finishing != nil
+	case pfs.CommitState_FINISHED:
+		return ci.Finished != nil
+	default:
+		return true
+	}
+}
+
+// commitReady returns true if every commit ci is directly provenant on has
+// finished. Only provenant branches that have a commit in ci's CommitSet are
+// considered; the heads of the others were already finished when the set was
+// created.
+func (tx *txn) commitReady(ci *pfs.CommitInfo) bool {
+	for _, b := range ci.DirectProvenance {
+		if pci, ok := tx.getCommitInfoByKey(b.NewCommit(ci.Commit.Id).Key()); ok && pci.Finished == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *driver) listCommit(repo *pfs.Repo, to, from *pfs.Commit, number int64, reverse, all bool, originKind pfs.OriginKind, cb func(*pfs.CommitInfo) error) error {
+	var infos []*pfs.CommitInfo
+	if err := d.read(func(tx *txn) error {
+		if _, err := tx.getRepoInfo(repo); err != nil {
+			return err
+		}
+		var fromKey string
+		if from != nil {
+			fci, err := tx.resolveCommit(from)
+			if err != nil {
+				return err
+			}
+			fromKey = fci.Commit.Key()
+		}
+		var candidates []*pfs.CommitInfo
+		if to != nil {
+			ci, err := tx.resolveCommit(to)
+			if err != nil {
+				return err
+			}
+			for ci != nil && ci.Commit.Key() != fromKey {
+				candidates = append(candidates, ci)
+				if ci.ParentCommit == nil {
+					break
+				}
+				ci, _ = tx.getCommitInfoByKey(ci.ParentCommit.Key())
+			}
+		} else {
+			for _, ci := range tx.listCommitInfos(repo) {
+				if fromKey == "" || tx.isStrictAncestor(fromKey, ci) {
+					candidates = append(candidates, ci)
+				}
+			}
+			sortCommitInfos(candidates)
+		}
+		for _, ci := range candidates {
+			if !matchOrigin(ci, all, originKind) {
+				continue
+			}
+			tx.addCommitSizes(ci)
+			infos = append(infos, ci)
+			if number > 0 && int64(len(infos)) == number {
+				break
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	if reverse {
+		for i, j := 0, len(infos)-1; i < j; i, j = i+1, j-1 {
+			infos[i], infos[j] = infos[j], infos[i]
+		}
+	}
+	for _, info := range infos {
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// subscribeCommit calls cb with every commit on branch in repo, or on any of
+// its branches if branch is empty, in the order they were started. Commits
+// started up to and including from are skipped, so a subscriber can resume
+// from the last commit it saw, even across server restarts. Each commit is
+// only passed to cb once it has reached state, and commits after it are held
+// back until then. subscribeCommit returns when ctx is done or cb fails.
+func (d *driver) subscribeCommit(ctx context.Context, repo *pfs.Repo, branch string, from *pfs.Commit, state pfs.CommitState, all bool, originKind pfs.OriginKind, cb func(*pfs.CommitInfo) error) error {
+	var cursor *pfs.CommitInfo
+	if from != nil {
+		if err := d.read(func(tx *txn) error {
+			var err error
+			cursor, err = tx.resolveCommit(from)
+			return err
+		}); err != nil {
+			return err
+		}
+	}
+	for {
+		var next *pfs.CommitInfo
+		if err := d.wait(ctx, func(tx *txn) (bool, error) {
+			if _, err := tx.getRepoInfo(repo); err != nil {
+				return false, err
+			}
+			next = nil
+			for _, ci := range tx.listCommitInfos(repo) {
+				if branch != "" && ci.Commit.Branch.Name != branch {
+					continue
+				}
+				if !matchOrigin(ci, all, originKind) {
+					continue
+				}
+				if cursor != nil && !commitBefore(cursor, ci) {
+					continue
+				}
+				if next == nil || commitBefore(ci, next) {
+					next = ci
+				}
+			}
+			if next == nil {
+				return false, nil
+			}
+			tx.addCommitSizes(next)
+			return tx.commitReached(next, state), nil
+		}); err != nil {
+			return err
+		}
+		if err := cb(next); err != nil {
+			return err
+		}
+		cursor = next
+	}
+}
+
+// commitBefore returns true if a was started before b. Commits started at the
+// same time are ordered by key, so that the order is total.
+func commitBefore(a, b *pfs.CommitInfo) bool {
+	ta, tb := a.Started.AsTime(), b.Started.AsTime()
+	if ta.Equal(tb) {
+		return a.Commit.Key() < b.Commit.Key()
+	}
+	return ta.Before(tb)
+}
+
+// isStrictAncestor returns true if the commit with key ancestor is a parent,
+// grandparent, etc. of ci.
+func (tx *txn) isStrictAncestor(ancestor string, ci *pfs.CommitInfo) bool {
+	for ci.ParentCommit != nil {
+		if ci.ParentCommit.Key() == ancestor {
+			return true
+		}
+		ci, _ = tx.getCommitInfoByKey(ci.ParentCommit.Key())
+	}
+	return false
+}
+
+// matchOrigin implements the 'all' and 'origin_kind' filters shared by
+// ListCommit and SubscribeCommit.
+func matchOrigin(ci *pfs.CommitInfo, all bool, originKind pfs.OriginKind) bool {
+	if originKind != pfs.OriginKind_ORIGIN_KIND_UNKNOWN {
+		return ci.Origin.Kind == originKind
+	}
+	return all || ci.Origin.Kind != pfs.OriginKind_ALIAS
+}
+
+// sortCommitInfos sorts commits by start time, newest first.
+func sortCommitInfos(infos []*pfs.CommitInfo) {
+	sort.SliceStable(infos, func(i, j int) bool {
+		ti, tj := infos[i].Started.AsTime(), infos[j].Started.AsTime()
+		if ti.Equal(tj) {
+			return infos[i].Commit.Key() > infos[j].Commit.Key()
+		}
+		return ti.After(tj)
+	})
+}
+
+// commitSetInfos returns the commits in the CommitSet with the given ID,
+// oldest first.
+func (tx *txn) commitSetInfos(id string) []*pfs.CommitInfo {
+	var infos []*pfs.CommitInfo
+	for _, ci := range tx.listCommitInfos(nil) {
+		if ci.Commit.Id == id {
+			infos = append(infos, ci)
+		}
+	}
+	sortCommitInfos(infos)
+	for i, j := 0, len(infos)-1; i < j; i, j = i+1, j-1 {
+		infos[i], infos[j] = infos[j], infos[i]
+	}
+	return infos
+}
+
+func (d *driver) inspectCommitSet(ctx context.Context, commitSet *pfs.CommitSet, wait bool, cb func(*pfs.CommitInfo) error) error {
+	var infos []*pfs.CommitInfo
+	if err := d.wait(ctx, func(tx *txn) (bool, error) {
+		infos = tx.commitSetInfos(commitSet.Id)
+		if len(infos) == 0 {
+			return false, ErrCommitSetNotFound{CommitSet: commitSet}
+		}
+		for _, ci := range infos {
+			tx.addCommitSizes(ci)
+		}
+		if !wait {
+			return true, nil
+		}
+		for _, ci := range infos {
+			if ci.Finished == nil {
+				return false, nil
+			}
+		}
+		return true, nil
+	}); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listCommitSet lists every CommitSet, newest first.
+func (d *driver) listCommitSet(cb func(*pfs.CommitSetInfo) error) error {
+	var csis []*pfs.CommitSetInfo
+	if err := d.read(func(tx *txn) error {
+		all := tx.listCommitInfos(nil)
+		sortCommitInfos(all)
+		seen := make(map[string]bool)
+		for _, ci := range all {
+			if seen[ci.Commit.Id] {
+				continue
+			}
+			seen[ci.Commit.Id] = true
+			csi := &pfs.CommitSetInfo{
+				CommitSet: &pfs.CommitSet{Id: ci.Commit.Id},
+				Commits:   tx.commitSetInfos(ci.Commit.Id),
+			}
+			for _, ci := range csi.Commits {
+				tx.addCommitSizes(ci)
+			}
+			csis = append(csis, csi)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, csi := range csis {
+		if err := cb(csi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// squashCommitSet merges the changes of every commit in a CommitSet into the
+// commit's children, which take its place in the commit graph. The CommitSet
+// no longer exists afterwards, but the content of every other commit is
+// unchanged.
+func (d *driver) squashCommitSet(commitSet *pfs.CommitSet) error {
+	return d.write(func(tx *txn) error {
+		infos, err := tx.removableCommitSet(commitSet)
+		if err != nil {
+			return err
+		}
+		for _, ci := range infos {
+			if len(ci.ChildCommits) == 0 {
+				return ErrSquashWithoutChildren{Commit: ci.Commit}
+			}
+			for _, bi := range tx.listBranchInfos(nil) {
+				if bi.Head != nil && bi.Head.Key() == ci.Commit.Key() {
+					return ErrOrphanedHead{Commit: ci.Commit, Branch: bi.Branch}
+				}
+			}
+		}
+		for _, info := range infos {
+			// earlier commits in the set may have been relinked to this one
+			ci, _ := tx.getCommitInfoByKey(info.Commit.Key())
+			diff := tx.commitFiles(ci.Commit).diff
+			for _, child := range ci.ChildCommits {
+				cci, ok := tx.getCommitInfoByKey(child.Key())
+				if !ok {
+					continue
+				}
+				ops := append(append([]fileOp(nil), diff...), tx.commitFiles(child).diff...)
+				*tx.diffChange(child) = diffChange{reset: true, ops: ops}
+				key := child.Key()
+				tx.afterApply(func() { tx.d.files[key].diff = ops })
+				cci.ParentCommit = ci.ParentCommit
+				tx.putCommitInfo(cci)
+			}
+			tx.removeCommit(ci)
+		}
+		return nil
+	})
+}
+
+// dropCommitSet deletes every commit in a CommitSet along with its changes.
+// The children of a dropped commit are reparented onto its parent, and their
+// content, and that of their descendants, no longer includes the dropped
+// changes. Branch heads in the CommitSet move back to their parents. Chunks
+// only referenced by the dropped commits are reclaimed by the next garbage
+// collection.
+func (d *driver) dropCommitSet(commitSet *pfs.CommitSet) error {
+	return d.write(func(tx *txn) error {
+		infos, err := tx.removableCommitSet(commitSet)
+		if err != nil {
+			return err
+		}
+		inSet := make(map[string]bool)
+		for _, ci := range infos {
+			inSet[ci.Commit.Key()] = true
+		}
+		for _, bi := range tx.listBranchInfos(nil) {
+			if bi.Head == nil || !inSet[bi.Head.Key()] {
+				continue
+			}
+			head := bi.Head
+			for head != nil && inSet[head.Key()] {
+				ci, _ := tx.getCommitInfoByKey(head.Key())
+				head = ci.ParentCommit
+			}
+			if head == nil {
+				return ErrOrphanedHead{Commit: bi.Head, Branch: bi.Branch}
+			}
+			bi.Head = head
+			tx.putBranchInfo(bi)
+		}
+		var rebased []*pfs.Commit
+		for _, info := range infos {
+			ci, _ := tx.getCommitInfoByKey(info.Commit.Key())
+			base := newTree()
+			if ci.ParentCommit != nil {
+				base = tx.commitFiles(ci.ParentCommit).tree
+			}
+			for _, child := range ci.ChildCommits {
+				cci, ok := tx.getCommitInfoByKey(child.Key())
+				if !ok {
+					continue
+				}
+				if err := tx.checkRebase(cci, base); err != nil {
+					return errors.Errorf("cannot drop commit set %s: the changes of %v would no longer apply: %v", commitSet.Id, child, err)
+				}
+				cci.ParentCommit = ci.ParentCommit
+				tx.putCommitInfo(cci)
+				rebased = append(rebased, child)
+			}
+			tx.removeCommit(ci)
+		}
+		tx.afterApply(func() {
+			for _, commit := range rebased {
+				tx.d.rebaseFiles(commit)
+			}
+		})
+		return nil
+	})
+}
+
+// removableCommitSet returns the commits of a CommitSet, failing if any of
+// them is still open, or if a commit outside the CommitSet is provenant on
+// one of them.
+func (tx *txn) removableCommitSet(commitSet *pfs.CommitSet) ([]*pfs.CommitInfo, error) {
+	infos := tx.commitSetInfos(commitSet.Id)
+	if len(infos) == 0 {
+		return nil, ErrCommitSetNotFound{CommitSet: commitSet}
+	}
+	for _, ci := range infos {
+		if ci.Finished == nil {
+			return nil, ErrCommitNotFinished{Commit: ci.Commit}
+		}
+	}
+	for _, ci := range tx.listCommitInfos(nil) {
+		if ci.Commit.Id == commitSet.Id {
+			continue
+		}
+		for _, p := range ci.DirectProvenance {
+			if pci := tx.provenantCommit(ci, p); pci != nil && pci.Commit.Id == commitSet.Id {
+				return nil, ErrCommitSetDependency{CommitSet: commitSet, Commit: ci.Commit, Provenance: pci.Commit}
+			}
+		}
+	}
+	return infos, nil
+}
+
+// provenantCommit returns the commit on branch that ci was computed from: the
+// commit in ci's CommitSet if there is one, and otherwise the latest commit on
+// branch started before ci.
+func (tx *txn) provenantCommit(ci *pfs.CommitInfo, branch *pfs.Branch) *pfs.CommitInfo {
+	if pci, ok := tx.getCommitInfoByKey(branch.NewCommit(ci.Commit.Id).Key()); ok {
+		return pci
+	}
+	var latest *pfs.CommitInfo
+	for _, pci := range tx.listCommitInfos(branch.Repo) {
+		if pci.Commit.Branch.Name != branch.Name || pci.Started.AsTime().After(ci.Started.AsTime()) {
+			continue
+		}
+		if latest == nil || pci.Started.AsTime().After(latest.Started.AsTime()) {
+			latest = pci
+		}
+	}
+	return latest
+}
+
+// removeCommit stages the deletion of ci, replacing it with its children in
+// its parent's children. The children must already have been reparented.
+func (tx *txn) removeCommit(ci *pfs.CommitInfo) {
+	if ci.ParentCommit != nil {
+		if parent, ok := tx.getCommitInfoByKey(ci.ParentCommit.Key()); ok {
+			var children []*pfs.Commit
+			for _, child := range parent.ChildCommits {
+				if child.Key() == ci.Commit.Key() {
+					children = append(children, ci.ChildCommits...)
+					continue
+				}
+				children = append(children, child)
+			}
+			parent.ChildCommits = children
+			tx.putCommitInfo(parent)
+		}
+	}
+	commit := ci.Commit
+	tx.deleteCommitInfo(commit)
+	tx.afterApply(func() { tx.d.dropFiles(commit) })
+}
+
+// checkRebase returns an error if the diff of ci, or that of one of its
+// descendants, cannot be applied once ci's content is rebuilt on top of base.
+func (tx *txn) checkRebase(ci *pfs.CommitInfo, base *tree) error {
+	t := base.clone()
+	for _, op := range tx.commitFiles(ci.Commit).diff {
+		if err := checkOps(t, []fileOp{op}); err != nil {
+			return err
+		}
+		t.apply(op)
+	}
+	for _, child := range ci.ChildCommits {
+		cci, ok := tx.getCommitInfoByKey(child.Key())
+		if !ok {
+			continue
+		}
+		if err := tx.checkRebase(cci, t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// startFiles (re)initializes a commit's file data from its parent.
+func (d *driver) startFiles(commit, parent *pfs.Commit) {
+	t := newTree()
+	if parent != nil {
+		if pf, ok := d.files[parent.Key()]; ok {
+			t = pf.tree.clone()
+		}
+	}
+	d.dropFiles(commit)
+	d.files[commit.Key()] = &commitFiles{tree: t}
+}
+
+// dropFiles deletes a commit's file data, and removes its content from its
+// repo's size.
+func (d *driver) dropFiles(commit *pfs.Commit) {
+	if cf, ok := d.files[commit.Key()]; ok && cf.counted {
+		d.countFiles(commit, cf, -1)
+	}
+	delete(d.files, commit.Key())
+}
+
+// finishFiles stamps the files modified in a commit with its finish time,
+// checks the sizes of the commit's final content and adds it to its repo's
+// size, and rebases any open children onto that content. Sizes that have
+// drifted are logged, and left for fsck to correct.
+func (d *driver) finishFiles(commit *pfs.Commit, finished *timestamppb.Timestamp) {
+	cf, ok := d.files[commit.Key()]
+	if !ok {
+		return
+	}
+	start := time.Now()
+	for _, op := range cf.diff {
+		if f, ok := cf.tree.files[op.path]; ok && f.committed == nil {
+			cf.tree.files[op.path] = &file{parts: f.parts, committed: finished}
+		}
+	}
+	compacted := time.Now()
+	if err := cf.tree.checkSizes(); err != nil {
+		log.WithError(err).WithField("commit", commit).Error("commit sizes drifted; run fsck to correct them")
+	}
+	cf.compacting, cf.validating = compacted.Sub(start), time.Since(compacted)
+	if !cf.counted {
+		d.countFiles(commit, cf, 1)
+	}
+	ci, ok := d.meta[commitsCollection][commit.Key()]
+	if !ok {
+		return
+	}
+	for _, child := range ci.(*pfs.CommitInfo).ChildCommits {
+		if cci, ok := d.meta[commitsCollection][child.Key()]; !ok || cci.(*pfs.CommitInfo).Finishing != nil {
+			continue
+		}
+		if ccf, ok := d.files[child.Key()]; ok {
+			ccf.tree = cf.tree.clone()
+			for _, op := range ccf.diff {
+				ccf.tree.apply(op)
+			}
+		}
+	}
+}
+
+// rebaseFiles rebuilds the file trees of commit and its descendants by
+// replaying their diffs on top of their parents' content.
+func (d *driver) rebaseFiles(commit *pfs.Commit) {
+	cf, ok := d.files[commit.Key()]
+	if !ok {
+		return
+	}
+	m, ok := d.meta[commitsCollection][commit.Key()]
+	if !ok {
+		return
+	}
+	ci := m.(*pfs.CommitInfo)
+	d.startFiles(ci.Commit, ci.ParentCommit)
+	rebased := d.files[commit.Key()]
+	for _, op := range cf.diff {
+		rebased.diff = append(rebased.diff, op)
+		rebased.tree.apply(op)
+	}
+	if ci.Finished != nil {
+		d.finishFiles(ci.Commit, ci.Finished)
+	}
+	for _, child := range ci.ChildCommits {
+		d.rebaseFiles(child)
+	}
+}
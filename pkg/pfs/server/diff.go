@@ -0,0 +1,124 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+)
+
+// diffEntry is a file or directory being compared by diffFile, keyed by its
+// display path relative to the root of the comparison.
+type diffEntry struct {
+	key  string
+	info *pfs.FileInfo
+}
+
+// diffFile compares newFile with oldFile, which defaults to the same path in
+// the parent of newFile's commit, and calls cb with every entry that was
+// added (OldFile unset), removed (NewFile unset) or modified. Recursive diffs
+// compare the files at or beneath the two paths; shallow diffs compare the
+// paths themselves or, for directories, the entries directly beneath them.
+func (d *driver) diffFile(newFile, oldFile *pfs.File, shallow bool, cb func(*pfs.DiffFileResponse) error) error {
+	var newCommit, oldCommit *pfs.Commit
+	var newFiles, oldFiles *tree
+	newPath := cleanPath(newFile.Path)
+	oldPath := newPath
+	if err := d.read(func(tx *txn) error {
+		ci, err := tx.resolveCommit(newFile.Commit)
+		if err != nil {
+			return err
+		}
+		newCommit, newFiles = ci.Commit, tx.readTree(ci)
+		if oldFile != nil {
+			if ci, err = tx.resolveCommit(oldFile.Commit); err != nil {
+				return err
+			}
+			oldPath = cleanPath(oldFile.Path)
+		} else if ci.ParentCommit != nil {
+			ci, _ = tx.getCommitInfoByKey(ci.ParentCommit.Key())
+		} else {
+			oldCommit, oldFiles = ci.Commit, newTree()
+			return nil
+		}
+		oldCommit, oldFiles = ci.Commit, tx.readTree(ci)
+		return nil
+	}); err != nil {
+		return err
+	}
+	newEntries := diffEntries(newCommit, newFiles, newPath, shallow)
+	oldEntries := diffEntries(oldCommit, oldFiles, oldPath, shallow)
+	if newEntries == nil && oldEntries == nil {
+		return ErrFileNotFound{File: newCommit.NewFile(newPath)}
+	}
+	for len(newEntries) > 0 || len(oldEntries) > 0 {
+		resp := &pfs.DiffFileResponse{}
+		switch {
+		case len(oldEntries) == 0 || (len(newEntries) > 0 && newEntries[0].key < oldEntries[0].key):
+			resp.NewFile = newEntries[0].info
+			newEntries = newEntries[1:]
+		case len(newEntries) == 0 || oldEntries[0].key < newEntries[0].key:
+			resp.OldFile = oldEntries[0].info
+			oldEntries = oldEntries[1:]
+		default:
+			resp.NewFile, resp.OldFile = newEntries[0].info, oldEntries[0].info
+			newEntries, oldEntries = newEntries[1:], oldEntries[1:]
+			if resp.NewFile.FileType == resp.OldFile.FileType && bytes.Equal(resp.NewFile.Hash, resp.OldFile.Hash) {
+				continue
+			}
+		}
+		if err := cb(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// diffEntries returns the entries of t that diffFile compares for p, sorted
+// by key, or nil if nothing exists at p.
+func diffEntries(commit *pfs.Commit, t *tree, p string, shallow bool) []diffEntry {
+	if _, ok := t.files[p]; ok {
+		return []diffEntry{{info: t.fileInfo(commit, p)}}
+	}
+	if !t.isDir(p) {
+		return nil
+	}
+	prefix := dirPrefix(p)
+	entries := []diffEntry{}
+	add := func(p string) {
+		entries = append(entries, diffEntry{
+			key:  strings.TrimPrefix(displayPath(t, p), prefix),
+			info: t.fileInfo(commit, p),
+		})
+	}
+	if shallow {
+		for _, child := range t.children(p) {
+			add(child)
+		}
+		return entries
+	}
+	for _, fp := range t.under(p) {
+		add(fp)
+	}
+	return entries
+}
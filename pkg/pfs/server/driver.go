@@ -0,0 +1,497 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/backoff"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/task"
+)
+
+// collection names a kind of PFS metadata.
+type collection string
+
+const (
+	reposCollection    collection = "repos"
+	branchesCollection collection = "branches"
+	commitsCollection  collection = "commits"
+)
+
+const (
+	// taskNamespace is the namespace of the driver's tasks.
+	taskNamespace = "pfs"
+	// compactionTaskGroup is the group of the tasks that compact and
+	// validate the content of finishing commits.
+	compactionTaskGroup = "compaction"
+	// taskWorkers is how many of the driver's tasks are processed at once.
+	taskWorkers = 4
+)
+
+// driver holds the PFS metadata and file trees in memory, while file content
+// is kept in chunk storage. If the driver has a store, every write is
+// persisted to it before being applied, and the driver's state is loaded from
+// it on startup. Metadata is keyed by Repo.Key(), Branch.Key() and
+// Commit.Key(), where a commit's key always uses the branch it was created
+// on.
+type driver struct {
+	mu   sync.RWMutex
+	meta map[collection]map[string]proto.Message
+	// commitIDs maps "<repo>=<commit id>" to the commit's key, so that commits
+	// can be found without knowing the branch they were created on. If a
+	// CommitSet has commits on several branches of a repo, it maps to one of
+	// them.
+	commitIDs map[string]string
+	// files holds the file data of every commit, keyed like commits.
+	files map[string]*commitFiles
+	// repoSizes counts the content of the finished commits of every repo,
+	// keyed like repos.
+	repoSizes map[string]*repoSize
+	// changed is closed and replaced every time a write is applied.
+	changed chan struct{}
+	storage *chunk.Storage
+	store   metadataStore
+	// downloadBackOff returns the policy for retrying URL downloads.
+	downloadBackOff func() backoff.BackOff
+	// uploads holds the open resumable upload sessions, keyed by ID.
+	uploadsMu sync.Mutex
+	uploads   map[string]*upload
+	// uploadPartSize is the part size of new uploads.
+	uploadPartSize int64
+	// uploadTTL is how long an upload session lives without being used.
+	uploadTTL time.Duration
+	// fileSets holds the temporary file sets, keyed by ID. When both are
+	// held, mu must be locked before fileSetsMu.
+	fileSetsMu sync.Mutex
+	fileSets   map[string]*fileSet
+	// gcGrace is how long garbage collection spares newly uploaded chunks.
+	gcGrace time.Duration
+	cache   *cache
+	// secrets resolves the secrets egress targets and SQL ingests refer
+	// to, if set.
+	secrets SecretGetter
+	// fileURLRoot is the directory file URLs are confined to. File URLs
+	// are refused if it is empty.
+	fileURLRoot string
+	// tasks is the in-process queue that finishing commits runs through,
+	// which ListTask lists. Its workers are started by the first commit to
+	// finish.
+	tasks       *task.Service
+	workersOnce sync.Once
+}
+
+// commitFiles is the file data of a single commit.
+type commitFiles struct {
+	// diff is every modification made in the commit, in order.
+	diff []fileOp
+	// tree is the full content of the commit: its parent's content with diff
+	// applied on top.
+	tree *tree
+	// compacting and validating are how long finishing the commit took to
+	// stamp the files in its diff with its finish time and to recount the
+	// sizes of its content, once it has finished. The diff is applied to
+	// tree as it is written, so there is nothing else to compact.
+	compacting, validating time.Duration
+	// counted is set while the commit's content is counted in its repo's
+	// size.
+	counted bool
+}
+
+func newDriver(storage *chunk.Storage, store metadataStore) *driver {
+	return &driver{
+		meta: map[collection]map[string]proto.Message{
+			reposCollection:    make(map[string]proto.Message),
+			branchesCollection: make(map[string]proto.Message),
+			commitsCollection:  make(map[string]proto.Message),
+		},
+		commitIDs: make(map[string]string),
+		files:     make(map[string]*commitFiles),
+		repoSizes: make(map[string]*repoSize),
+		changed:   make(chan struct{}),
+		storage:   storage,
+		store:     store,
+
+		downloadBackOff: func() backoff.BackOff { return backoff.NewExponentialBackOff() },
+		uploads:         make(map[string]*upload),
+		uploadPartSize:  pfs.ChunkSize,
+		uploadTTL:       defaultUploadTTL,
+		fileSets:        make(map[string]*fileSet),
+		gcGrace:         defaultGCGrace,
+		cache:           newCache(defaultCacheSize),
+		tasks:           task.NewService(task.Options{}),
+	}
+}
+
+// load replaces the driver's state with the contents of its store.
+func (d *driver) load(ctx context.Context) error {
+	if d.store == nil {
+		return nil
+	}
+	snap, err := d.store.load(ctx)
+	if err != nil {
+		return err
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.meta = snap.meta
+	d.commitIDs = make(map[string]string)
+	d.files = make(map[string]*commitFiles)
+	d.repoSizes = make(map[string]*repoSize)
+	for key, m := range d.meta[commitsCollection] {
+		if idKey := commitIDKey(m.(*pfs.CommitInfo).Commit); d.commitIDs[idKey] == "" || key < d.commitIDs[idKey] {
+			d.commitIDs[idKey] = key
+		}
+	}
+	// rebuild the file trees by replaying each commit's diff on top of its
+	// parent's content, parents first
+	var replay func(key string)
+	replay = func(key string) {
+		if _, ok := d.files[key]; ok {
+			return
+		}
+		ci := d.meta[commitsCollection][key].(*pfs.CommitInfo)
+		if ci.ParentCommit != nil {
+			if _, ok := d.meta[commitsCollection][ci.ParentCommit.Key()]; ok {
+				replay(ci.ParentCommit.Key())
+			}
+		}
+		d.startFiles(ci.Commit, ci.ParentCommit)
+		cf := d.files[key]
+		for _, op := range snap.diffs[key] {
+			cf.diff = append(cf.diff, op)
+			cf.tree.apply(op)
+		}
+		if ci.Finished != nil {
+			d.finishFiles(ci.Commit, ci.Finished)
+		}
+	}
+	for key := range d.meta[commitsCollection] {
+		replay(key)
+	}
+	close(d.changed)
+	d.changed = make(chan struct{})
+	return nil
+}
+
+// startWorkers starts the workers of the driver's tasks, the first time it
+// is called.
+func (d *driver) startWorkers() {
+	d.workersOnce.Do(func() {
+		for i := 0; i < taskWorkers; i++ {
+			go d.tasks.Work(context.Background(), taskNamespace, d.processFinish)
+		}
+	})
+}
+
+// read runs f against a consistent view of the driver's state. Writes staged
+// by f are discarded.
+func (d *driver) read(f func(tx *txn) error) error {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return f(newTxn(d))
+}
+
+// write runs f and, if it succeeds, atomically applies everything it staged.
+func (d *driver) write(f func(tx *txn) error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	tx := newTxn(d)
+	if err := f(tx); err != nil {
+		return err
+	}
+	if d.store != nil {
+		if err := d.store.write(context.Background(), tx); err != nil {
+			return err
+		}
+	}
+	d.apply(tx)
+	return nil
+}
+
+// wait calls f every time the driver's state changes, until f returns true,
+// f returns an error, or ctx is done.
+func (d *driver) wait(ctx context.Context, f func(tx *txn) (bool, error)) error {
+	for {
+		d.mu.RLock()
+		changed := d.changed
+		done, err := f(newTxn(d))
+		d.mu.RUnlock()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return errors.EnsureStack(ctx.Err())
+		}
+	}
+}
+
+func (d *driver) apply(tx *txn) {
+	// reindex is set when a commit ID's index entry is removed, and must be
+	// pointed at another commit with the same ID if there is one
+	var reindex bool
+	for c, staged := range tx.staged {
+		for key, m := range staged {
+			if c == commitsCollection {
+				if old, ok := d.meta[c][key]; ok && m == nil {
+					if idKey := commitIDKey(old.(*pfs.CommitInfo).Commit); d.commitIDs[idKey] == key {
+						delete(d.commitIDs, idKey)
+						reindex = true
+					}
+				}
+				if m != nil {
+					if idKey := commitIDKey(m.(*pfs.CommitInfo).Commit); d.commitIDs[idKey] == "" {
+						d.commitIDs[idKey] = key
+					}
+				}
+			}
+			if m == nil {
+				delete(d.meta[c], key)
+				continue
+			}
+			d.meta[c][key] = m
+		}
+	}
+	if reindex {
+		for key, m := range d.meta[commitsCollection] {
+			if idKey := commitIDKey(m.(*pfs.CommitInfo).Commit); d.commitIDs[idKey] == "" {
+				d.commitIDs[idKey] = key
+			}
+		}
+	}
+	for _, f := range tx.onApply {
+		f()
+	}
+	close(d.changed)
+	d.changed = make(chan struct{})
+}
+
+func commitIDKey(commit *pfs.Commit) string {
+	return commit.Branch.Repo.Key() + "=" + commit.Id
+}
+
+// txn stages metadata changes on top of the driver's state. Reads through a
+// txn observe its own staged writes. All messages going in or out of a txn are
+// cloned, so callers are free to modify them.
+type txn struct {
+	d      *driver
+	staged map[collection]map[string]proto.Message
+	// diffs stages changes to the diffs of commits, keyed like commits.
+	diffs map[string]*diffChange
+	// onApply are run, in order, after the staged metadata has been applied.
+	onApply []func()
+}
+
+// diffChange is a staged change to a commit's diff.
+type diffChange struct {
+	// reset discards the commit's existing diff before ops are appended.
+	reset bool
+	ops   []fileOp
+}
+
+func newTxn(d *driver) *txn {
+	return &txn{
+		d:      d,
+		staged: make(map[collection]map[string]proto.Message),
+		diffs:  make(map[string]*diffChange),
+	}
+}
+
+func (tx *txn) diffChange(commit *pfs.Commit) *diffChange {
+	key := commit.Key()
+	if tx.diffs[key] == nil {
+		tx.diffs[key] = &diffChange{}
+	}
+	return tx.diffs[key]
+}
+
+func (tx *txn) get(c collection, key string) (proto.Message, bool) {
+	m, ok := tx.staged[c][key]
+	if !ok {
+		m, ok = tx.d.meta[c][key]
+	}
+	if !ok || m == nil {
+		return nil, false
+	}
+	return proto.Clone(m), true
+}
+
+func (tx *txn) put(c collection, key string, m proto.Message) {
+	if tx.staged[c] == nil {
+		tx.staged[c] = make(map[string]proto.Message)
+	}
+	tx.staged[c][key] = proto.Clone(m)
+}
+
+func (tx *txn) delete(c collection, key string) {
+	if tx.staged[c] == nil {
+		tx.staged[c] = make(map[string]proto.Message)
+	}
+	tx.staged[c][key] = nil
+}
+
+// keys returns the sorted keys in c that start with prefix.
+func (tx *txn) keys(c collection, prefix string) []string {
+	var keys []string
+	for key := range tx.d.meta[c] {
+		if _, ok := tx.staged[c][key]; !ok && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	for key, m := range tx.staged[c] {
+		if m != nil && strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// afterApply registers f to run once the txn has been applied. It is used for
+// changes to file data, which is not staged.
+func (tx *txn) afterApply(f func()) {
+	tx.onApply = append(tx.onApply, f)
+}
+
+func (tx *txn) getRepoInfo(repo *pfs.Repo) (*pfs.RepoInfo, error) {
+	m, ok := tx.get(reposCollection, repo.Key())
+	if !ok {
+		return nil, ErrRepoNotFound{Repo: repo}
+	}
+	return m.(*pfs.RepoInfo), nil
+}
+
+func (tx *txn) putRepoInfo(info *pfs.RepoInfo) {
+	tx.put(reposCollection, info.Repo.Key(), info)
+}
+
+func (tx *txn) listRepoInfos() []*pfs.RepoInfo {
+	var infos []*pfs.RepoInfo
+	for _, key := range tx.keys(reposCollection, "") {
+		m, _ := tx.get(reposCollection, key)
+		infos = append(infos, m.(*pfs.RepoInfo))
+	}
+	return infos
+}
+
+func (tx *txn) getBranchInfo(branch *pfs.Branch) (*pfs.BranchInfo, error) {
+	m, ok := tx.get(branchesCollection, branch.Key())
+	if !ok {
+		return nil, ErrBranchNotFound{Branch: branch}
+	}
+	return m.(*pfs.BranchInfo), nil
+}
+
+func (tx *txn) putBranchInfo(info *pfs.BranchInfo) {
+	tx.put(branchesCollection, info.Branch.Key(), info)
+}
+
+func (tx *txn) listBranchInfos(repo *pfs.Repo) []*pfs.BranchInfo {
+	var infos []*pfs.BranchInfo
+	prefix := ""
+	if repo != nil {
+		prefix = repo.Key() + "@"
+	}
+	for _, key := range tx.keys(branchesCollection, prefix) {
+		m, _ := tx.get(branchesCollection, key)
+		infos = append(infos, m.(*pfs.BranchInfo))
+	}
+	return infos
+}
+
+// getCommitInfoByKey returns the CommitInfo stored under a canonical commit
+// key.
+func (tx *txn) getCommitInfoByKey(key string) (*pfs.CommitInfo, bool) {
+	m, ok := tx.get(commitsCollection, key)
+	if !ok {
+		return nil, false
+	}
+	return m.(*pfs.CommitInfo), true
+}
+
+// getCommitInfoByID returns the CommitInfo of the commit with the given ID in
+// repo, regardless of the branch it was created on.
+func (tx *txn) getCommitInfoByID(repo *pfs.Repo, id string) (*pfs.CommitInfo, bool) {
+	idKey := repo.Key() + "=" + id
+	for key, m := range tx.staged[commitsCollection] {
+		if m != nil && commitIDKey(m.(*pfs.CommitInfo).Commit) == idKey {
+			return tx.getCommitInfoByKey(key)
+		}
+	}
+	key, ok := tx.d.commitIDs[idKey]
+	if !ok {
+		return nil, false
+	}
+	return tx.getCommitInfoByKey(key)
+}
+
+func (tx *txn) putCommitInfo(info *pfs.CommitInfo) {
+	tx.put(commitsCollection, info.Commit.Key(), info)
+}
+
+func (tx *txn) deleteCommitInfo(commit *pfs.Commit) {
+	tx.delete(commitsCollection, commit.Key())
+}
+
+// listCommitInfos returns the CommitInfos of every commit in repo, or in all
+// repos if repo is nil, sorted by key.
+func (tx *txn) listCommitInfos(repo *pfs.Repo) []*pfs.CommitInfo {
+	var infos []*pfs.CommitInfo
+	prefix := ""
+	if repo != nil {
+		prefix = repo.Key() + "@"
+	}
+	for _, key := range tx.keys(commitsCollection, prefix) {
+		info, _ := tx.getCommitInfoByKey(key)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// commitFiles returns the file data of a commit. Commits created in a txn
+// that has not been applied yet have no file data.
+// readTree returns the content of ci, to be read after the transaction. The
+// trees of finished commits never change again, so they are shared rather
+// than copied; only those of open commits are copied.
+func (tx *txn) readTree(ci *pfs.CommitInfo) *tree {
+	t := tx.commitFiles(ci.Commit).tree
+	if ci.Finished == nil {
+		return t.clone()
+	}
+	return t
+}
+
+func (tx *txn) commitFiles(commit *pfs.Commit) *commitFiles {
+	if cf, ok := tx.d.files[commit.Key()]; ok {
+		return cf
+	}
+	return &commitFiles{tree: newTree()}
+}
@@ -0,0 +1,216 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/sqlutil"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+// SecretGetter returns the value of key in the secret name. It is used to
+// resolve the credentials that egress targets and SQL ingests refer to.
+type SecretGetter func(ctx context.Context, name, key string) ([]byte, error)
+
+// finishedTree returns the content of a finished commit.
+func (d *driver) finishedTree(commit *pfs.Commit) (*tree, error) {
+	var t *tree
+	if err := d.read(func(tx *txn) error {
+		ci, err := tx.resolveCommit(commit)
+		if err != nil {
+			return err
+		}
+		if ci.Finished == nil {
+			return ErrCommitNotFinished{Commit: ci.Commit}
+		}
+		t = tx.readTree(ci)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// egressObjectStorage copies every file in a finished commit to the object
+// beneath url with the same path, and returns the number of bytes written.
+func (d *driver) egressObjectStorage(ctx context.Context, commit *pfs.Commit, target *pfs.ObjectStorageEgress) (int64, error) {
+	u, err := obj.ParseURL(target.Url)
+	if err != nil {
+		return 0, err
+	}
+	c, err := d.openURL(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	t, err := d.finishedTree(commit)
+	if err != nil {
+		return 0, err
+	}
+	var n int64
+	for _, p := range t.under("/") {
+		f := t.files[p]
+		cr := &countReader{r: d.storage.NewReader(ctx, f.dataRefs())}
+		if err := c.Put(ctx, path.Join(u.Object, strings.TrimPrefix(p, "/")), cr); err != nil {
+			return 0, err
+		}
+		n += cr.n
+	}
+	return n, nil
+}
+
+// egressSQLDatabase loads the files of a finished commit into the tables of
+// a Postgres database, in a single transaction. Every file must be beneath a
+// directory named after its table, such as /users/0001.csv. If the target
+// names no columns, the files have a value for every column of the table, in
+// order. CSV files may start with a header naming the columns, as those
+// written by SQL ingest and PutFileSplit do. It returns the number of rows
+// written to each table.
+func (d *driver) egressSQLDatabase(ctx context.Context, commit *pfs.Commit, target *pfs.SQLDatabaseEgress) (map[string]int64, error) {
+	newRowReader, err := d.rowReaderFor(ctx, target.FileFormat)
+	if err != nil {
+		return nil, err
+	}
+	dbURL, err := d.sqlURL(ctx, target.Url, target.Secret)
+	if err != nil {
+		return nil, err
+	}
+	t, err := d.finishedTree(commit)
+	if err != nil {
+		return nil, err
+	}
+	tables := make(map[string][]string)
+	for _, p := range t.under("/") {
+		parts := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 2)
+		if len(parts) < 2 {
+			return nil, errors.Errorf("cannot egress %s: files must be in a directory named after their table", p)
+		}
+		tables[parts[0]] = append(tables[parts[0]], p)
+	}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	db, err := dbutil.NewDB(ctx, dbURL)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+	rowsWritten := make(map[string]int64)
+	if err := dbutil.WithTx(ctx, db, func(tx *sql.Tx) error {
+		for _, table := range names {
+			columns := target.FileFormat.Columns
+			if len(columns) == 0 {
+				if columns, err = sqlutil.TableColumns(ctx, tx, table); err != nil {
+					return err
+				}
+			}
+			for _, p := range tables[table] {
+				r, err := newRowReader(t.files[p], columns)
+				if err != nil {
+					return errors.Wrapf(err, "cannot egress %s", p)
+				}
+				n, err := sqlutil.LoadTable(ctx, tx, table, columns, r)
+				if err != nil {
+					return errors.Wrapf(err, "cannot egress %s", p)
+				}
+				rowsWritten[table] += n
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return rowsWritten, nil
+}
+
+// rowReaderFor returns a function that opens a RowReader for a file in the
+// given format.
+func (d *driver) rowReaderFor(ctx context.Context, format *pfs.SQLDatabaseEgress_FileFormat) (func(f *file, columns []string) (sqlutil.RowReader, error), error) {
+	switch format.GetType() {
+	case pfs.SQLDatabaseEgress_FileFormat_CSV:
+		return func(f *file, columns []string) (sqlutil.RowReader, error) {
+			return sqlutil.NewCSVReader(d.storage.NewReader(ctx, f.dataRefs()), columns), nil
+		}, nil
+	case pfs.SQLDatabaseEgress_FileFormat_JSON:
+		return func(f *file, columns []string) (sqlutil.RowReader, error) {
+			return sqlutil.NewJSONReader(d.storage.NewReader(ctx, f.dataRefs()), columns), nil
+		}, nil
+	case pfs.SQLDatabaseEgress_FileFormat_PARQUET:
+		return func(f *file, columns []string) (sqlutil.RowReader, error) {
+			return sqlutil.NewParquetReader(&fileReaderAt{ctx: ctx, storage: d.storage, refs: f.dataRefs()}, f.size(), columns)
+		}, nil
+	case pfs.SQLDatabaseEgress_FileFormat_UNKNOWN:
+		return nil, errors.New("the file format of an SQL egress must be set")
+	}
+	return nil, errors.Errorf("the %v file format is not supported", format.GetType())
+}
+
+// fileReaderAt reads the content of a file at arbitrary offsets, for formats
+// such as Parquet that cannot be read sequentially.
+type fileReaderAt struct {
+	ctx     context.Context
+	storage *chunk.Storage
+	refs    []chunk.DataRef
+}
+
+func (r *fileReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	n, err := io.ReadFull(r.storage.NewReader(r.ctx, chunk.Slice(r.refs, off, int64(len(p)))), p)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	return n, errors.EnsureStack(err)
+}
+
+// sqlURL returns a database connection URL with the password from secret,
+// if it is set.
+func (d *driver) sqlURL(ctx context.Context, rawURL string, secret *pfs.SQLDatabaseEgress_Secret) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid URL %q", rawURL)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return "", errors.Errorf("unsupported database URL scheme %q", u.Scheme)
+	}
+	if secret == nil {
+		return u.String(), nil
+	}
+	if d.secrets == nil {
+		return "", errors.Errorf("cannot read secret %s: no secrets are configured", secret.Name)
+	}
+	password, err := d.secrets(ctx, secret.Name, secret.Key)
+	if err != nil {
+		return "", err
+	}
+	u.User = url.UserPassword(u.User.Username(), string(password))
+	return u.String(), nil
+}
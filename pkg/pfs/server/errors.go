@@ -0,0 +1,361 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+)
+
+// ErrRepoNotFound is returned when a repo does not exist.
+type ErrRepoNotFound struct {
+	Repo *pfs.Repo
+}
+
+func (e ErrRepoNotFound) Error() string {
+	return fmt.Sprintf("repo %v not found", e.Repo)
+}
+
+func (e ErrRepoNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrRepoExists is returned when creating a repo that already exists.
+type ErrRepoExists struct {
+	Repo *pfs.Repo
+}
+
+func (e ErrRepoExists) Error() string {
+	return fmt.Sprintf("repo %v already exists", e.Repo)
+}
+
+func (e ErrRepoExists) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+// ErrBranchNotFound is returned when a branch does not exist.
+type ErrBranchNotFound struct {
+	Branch *pfs.Branch
+}
+
+func (e ErrBranchNotFound) Error() string {
+	return fmt.Sprintf("branch %v not found", e.Branch)
+}
+
+func (e ErrBranchNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrCommitNotFound is returned when a commit does not exist.
+type ErrCommitNotFound struct {
+	Commit *pfs.Commit
+}
+
+func (e ErrCommitNotFound) Error() string {
+	return fmt.Sprintf("commit %v not found", e.Commit)
+}
+
+func (e ErrCommitNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrCommitSetNotFound is returned when no commit belongs to a CommitSet.
+type ErrCommitSetNotFound struct {
+	CommitSet *pfs.CommitSet
+}
+
+func (e ErrCommitSetNotFound) Error() string {
+	return fmt.Sprintf("no commits found for commit set %v", e.CommitSet.Id)
+}
+
+func (e ErrCommitSetNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrCommitFinished is returned when modifying a commit that is already
+// finished.
+type ErrCommitFinished struct {
+	Commit *pfs.Commit
+}
+
+func (e ErrCommitFinished) Error() string {
+	return fmt.Sprintf("commit %v has already finished", e.Commit)
+}
+
+func (e ErrCommitFinished) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrCommitNotFinished is returned when an operation requires a finished
+// commit.
+type ErrCommitNotFinished struct {
+	Commit *pfs.Commit
+}
+
+func (e ErrCommitNotFinished) Error() string {
+	return fmt.Sprintf("commit %v has not finished", e.Commit)
+}
+
+func (e ErrCommitNotFinished) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrFileNotFound is returned when a file does not exist in a commit.
+type ErrFileNotFound struct {
+	File *pfs.File
+}
+
+func (e ErrFileNotFound) Error() string {
+	return fmt.Sprintf("file %v not found in repo %v at commit %v", e.File.Path, e.File.Commit.Branch.Repo, e.File.Commit.Id)
+}
+
+func (e ErrFileNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrNotADirectory is returned when a path is used as a directory but one of
+// its ancestors is a regular file.
+type ErrNotADirectory struct {
+	Path   string
+	Parent string
+}
+
+func (e ErrNotADirectory) Error() string {
+	return fmt.Sprintf("cannot write %s: %s exists but it's not a directory", e.Path, e.Parent)
+}
+
+func (e ErrNotADirectory) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrCommitOnOutputBranch is returned when starting a commit on a branch with
+// provenance. Commits on such branches are created by propagation.
+type ErrCommitOnOutputBranch struct {
+	Branch *pfs.Branch
+}
+
+func (e ErrCommitOnOutputBranch) Error() string {
+	return fmt.Sprintf("cannot start a commit on an output branch: %v", e.Branch)
+}
+
+func (e ErrCommitOnOutputBranch) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrProvenanceCycle is returned when making a branch provenant on a branch
+// that is itself provenant on it.
+type ErrProvenanceCycle struct {
+	Branch     *pfs.Branch
+	Provenance *pfs.Branch
+}
+
+func (e ErrProvenanceCycle) Error() string {
+	return fmt.Sprintf("branch %v cannot be provenant on %v: that would create a provenance cycle", e.Branch, e.Provenance)
+}
+
+func (e ErrProvenanceCycle) GRPCStatus() *status.Status {
+	return status.New(codes.InvalidArgument, e.Error())
+}
+
+// ErrUploadNotFound is returned when an upload session does not exist, or has
+// already been finished or aborted.
+type ErrUploadNotFound struct {
+	ID string
+}
+
+func (e ErrUploadNotFound) Error() string {
+	return fmt.Sprintf("upload %s not found", e.ID)
+}
+
+func (e ErrUploadNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrFileSetNotFound is returned when a file set does not exist or has
+// expired.
+type ErrFileSetNotFound struct {
+	ID string
+}
+
+func (e ErrFileSetNotFound) Error() string {
+	return fmt.Sprintf("file set %s not found", e.ID)
+}
+
+func (e ErrFileSetNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrSquashWithoutChildren is returned when squashing a CommitSet with a
+// commit that has no children to take its changes.
+type ErrSquashWithoutChildren struct {
+	Commit *pfs.Commit
+}
+
+func (e ErrSquashWithoutChildren) Error() string {
+	return fmt.Sprintf("cannot squash commit %v: it has no children", e.Commit)
+}
+
+func (e ErrSquashWithoutChildren) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrOrphanedHead is returned when squashing or dropping a CommitSet would
+// leave a branch without a head.
+type ErrOrphanedHead struct {
+	Commit *pfs.Commit
+	Branch *pfs.Branch
+}
+
+func (e ErrOrphanedHead) Error() string {
+	return fmt.Sprintf("cannot remove commit %v: it would orphan the head of branch %v", e.Commit, e.Branch)
+}
+
+func (e ErrOrphanedHead) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+// ErrCommitSetDependency is returned when squashing or dropping a CommitSet
+// that a commit outside of it is provenant on.
+type ErrCommitSetDependency struct {
+	CommitSet  *pfs.CommitSet
+	Commit     *pfs.Commit
+	Provenance *pfs.Commit
+}
+
+func (e ErrCommitSetDependency) Error() string {
+	return fmt.Sprintf("cannot remove commit set %s: commit %v is provenant on %v", e.CommitSet.Id, e.Commit, e.Provenance)
+}
+
+func (e ErrCommitSetDependency) GRPCStatus() *status.Status {
+	return status.New(codes.FailedPrecondition, e.Error())
+}
+
+var (
+	repoNotFoundRe      = regexp.MustCompile(`repo [^ ]+ not found`)
+	repoExistsRe        = regexp.MustCompile(`repo [^ ]+ already exists`)
+	branchNotFoundRe    = regexp.MustCompile(`branch [^ ]+ not found`)
+	commitNotFoundRe    = regexp.MustCompile(`commit [^ ]+ not found`)
+	commitFinishedRe    = regexp.MustCompile(`commit [^ ]+ has already finished`)
+	commitNotFinishedRe = regexp.MustCompile(`commit [^ ]+ has not finished`)
+	fileNotFoundRe      = regexp.MustCompile(`file .+ not found in repo [^ ]+ at commit [^ ]+`)
+	uploadNotFoundRe    = regexp.MustCompile(`upload [^ ]+ not found`)
+	fileSetNotFoundRe   = regexp.MustCompile(`file set [^ ]+ not found`)
+	provenanceCycleRe   = regexp.MustCompile(`branch [^ ]+ cannot be provenant on [^ ]+: that would create a provenance cycle`)
+	squashNoChildrenRe  = regexp.MustCompile(`cannot squash commit [^ ]+: it has no children`)
+	orphanedHeadRe      = regexp.MustCompile(`cannot remove commit [^ ]+: it would orphan the head of branch [^ ]+`)
+	commitSetDepRe      = regexp.MustCompile(`cannot remove commit set [^ ]+: commit [^ ]+ is provenant on [^ ]+`)
+)
+
+// IsRepoNotFoundErr returns true if 'err' has an error message that matches
+// ErrRepoNotFound. String matching is used so it works across RPC boundaries.
+func IsRepoNotFoundErr(err error) bool {
+	return err != nil && repoNotFoundRe.MatchString(err.Error())
+}
+
+// IsRepoExistsErr returns true if 'err' has an error message that matches
+// ErrRepoExists.
+func IsRepoExistsErr(err error) bool {
+	return err != nil && repoExistsRe.MatchString(err.Error())
+}
+
+// IsBranchNotFoundErr returns true if 'err' has an error message that matches
+// ErrBranchNotFound.
+func IsBranchNotFoundErr(err error) bool {
+	return err != nil && branchNotFoundRe.MatchString(err.Error())
+}
+
+// IsCommitNotFoundErr returns true if 'err' has an error message that matches
+// ErrCommitNotFound.
+func IsCommitNotFoundErr(err error) bool {
+	return err != nil && commitNotFoundRe.MatchString(err.Error())
+}
+
+// IsCommitFinishedErr returns true if 'err' has an error message that matches
+// ErrCommitFinished.
+func IsCommitFinishedErr(err error) bool {
+	return err != nil && commitFinishedRe.MatchString(err.Error())
+}
+
+// IsCommitNotFinishedErr returns true if 'err' has an error message that
+// matches ErrCommitNotFinished.
+func IsCommitNotFinishedErr(err error) bool {
+	return err != nil && commitNotFinishedRe.MatchString(err.Error())
+}
+
+// IsFileNotFoundErr returns true if 'err' has an error message that matches
+// ErrFileNotFound.
+func IsFileNotFoundErr(err error) bool {
+	return err != nil && fileNotFoundRe.MatchString(err.Error())
+}
+
+// IsNotADirectoryErr returns true if 'err' has an error message that matches
+// ErrNotADirectory.
+func IsNotADirectoryErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "but it's not a directory")
+}
+
+// IsCommitOnOutputBranchErr returns true if 'err' has an error message that
+// matches ErrCommitOnOutputBranch.
+func IsCommitOnOutputBranchErr(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "cannot start a commit on an output branch")
+}
+
+// IsProvenanceCycleErr returns true if 'err' has an error message that matches
+// ErrProvenanceCycle.
+func IsProvenanceCycleErr(err error) bool {
+	return err != nil && provenanceCycleRe.MatchString(err.Error())
+}
+
+// IsUploadNotFoundErr returns true if 'err' has an error message that matches
+// ErrUploadNotFound.
+func IsUploadNotFoundErr(err error) bool {
+	return err != nil && uploadNotFoundRe.MatchString(err.Error())
+}
+
+// IsFileSetNotFoundErr returns true if 'err' has an error message that
+// matches ErrFileSetNotFound.
+func IsFileSetNotFoundErr(err error) bool {
+	return err != nil && fileSetNotFoundRe.MatchString(err.Error())
+}
+
+// IsSquashWithoutChildrenErr returns true if 'err' has an error message that
+// matches ErrSquashWithoutChildren.
+func IsSquashWithoutChildrenErr(err error) bool {
+	return err != nil && squashNoChildrenRe.MatchString(err.Error())
+}
+
+// IsOrphanedHeadErr returns true if 'err' has an error message that matches
+// ErrOrphanedHead.
+func IsOrphanedHeadErr(err error) bool {
+	return err != nil && orphanedHeadRe.MatchString(err.Error())
+}
+
+// IsCommitSetDependencyErr returns true if 'err' has an error message that
+// matches ErrCommitSetDependency.
+func IsCommitSetDependencyErr(err error) bool {
+	return err != nil && commitSetDepRe.MatchString(err.Error())
+}
@@ -0,0 +1,521 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/backoff"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/glob"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+// openCommitFiles resolves commit and returns its file data, failing if the
+// commit can no longer be modified.
+func (tx *txn) openCommitFiles(commit *pfs.Commit) (*pfs.CommitInfo, *commitFiles, error) {
+	ci, err := tx.resolveCommit(commit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ci.Finishing != nil {
+		return nil, nil, ErrCommitFinished{Commit: ci.Commit}
+	}
+	return ci, tx.commitFiles(ci.Commit), nil
+}
+
+// modify stages ops to be applied to a commit's files. The ops must already
+// have been validated against the commit's current content.
+func (tx *txn) modify(commit *pfs.Commit, ops ...fileOp) {
+	key := commit.Key()
+	dc := tx.diffChange(commit)
+	dc.ops = append(dc.ops, ops...)
+	tx.afterApply(func() {
+		cf := tx.d.files[key]
+		for _, op := range ops {
+			cf.diff = append(cf.diff, op)
+			cf.tree.apply(op)
+		}
+	})
+}
+
+// fileTarget is what the modifications of a ModifyFile stream are applied
+// to: an open commit, or a file set that is being created.
+type fileTarget interface {
+	// modify calls f with the target's current content, and applies the ops
+	// that f returns once they have been checked against that content.
+	modify(d *driver, f func(tx *txn, t *tree) ([]fileOp, error)) error
+}
+
+// commitTarget applies modifications to an open commit.
+type commitTarget struct {
+	commit *pfs.Commit
+}
+
+func (c commitTarget) modify(d *driver, f func(tx *txn, t *tree) ([]fileOp, error)) error {
+	return d.write(func(tx *txn) error {
+		ci, cf, err := tx.openCommitFiles(c.commit)
+		if err != nil {
+			return err
+		}
+		ops, err := f(tx, cf.tree)
+		if err != nil {
+			return err
+		}
+		if err := checkOps(cf.tree, ops); err != nil {
+			return err
+		}
+		tx.modify(ci.Commit, ops...)
+		return nil
+	})
+}
+
+// checkOps returns an error if applying ops to t in order would write a file
+// where a directory exists, or beneath another file.
+func checkOps(t *tree, ops []fileOp) error {
+	// a single op can be checked without copying t
+	scratch := t
+	if len(ops) > 1 {
+		scratch = t.clone()
+	}
+	for _, op := range ops {
+		if op.kind != opDelete {
+			if _, ok := scratch.files[op.path]; !ok {
+				if err := scratch.checkWrite(op.path); err != nil {
+					return err
+				}
+			}
+		}
+		if scratch != t {
+			scratch.apply(op)
+		}
+	}
+	return nil
+}
+
+// addFile appends the content of r to the file at path in target. To
+// overwrite a file instead, delete it first. The content is uploaded to chunk
+// storage before the target is locked, so large uploads don't block writers.
+func (d *driver) addFile(ctx context.Context, target fileTarget, path, datum string, r io.Reader) error {
+	path, err := ValidatePath(path)
+	if err != nil {
+		return err
+	}
+	refs, err := d.storage.Upload(ctx, r)
+	if err != nil {
+		return err
+	}
+	return d.addFileRefs(target, path, datum, refs)
+}
+
+// addFileRefs appends content that was already uploaded to chunk storage to
+// the file at path, which must have been validated, in target.
+func (d *driver) addFileRefs(target fileTarget, path, datum string, refs []chunk.DataRef) error {
+	return target.modify(d, func(*txn, *tree) ([]fileOp, error) {
+		return []fileOp{{kind: opAdd, path: path, datum: datum, refs: refs}}, nil
+	})
+}
+
+// openURL returns the Client for the bucket of u. File URLs are opened
+// beneath fileURLRoot, and refused if it is unset, so that clients cannot
+// read or write arbitrary paths on the server.
+func (d *driver) openURL(ctx context.Context, u *obj.URL) (obj.Client, error) {
+	if u.Scheme != "file" {
+		return obj.NewClientFromURL(ctx, u)
+	}
+	if d.fileURLRoot == "" {
+		return nil, errors.Errorf("invalid URL %v: file URLs are disabled on this server", u)
+	}
+	return obj.FileOpener(d.fileURLRoot)(ctx, u)
+}
+
+// addFileURL appends the object at the URL of src to the file at path in
+// target or, if src is recursive, every object beneath the URL to the
+// corresponding file beneath path. Downloads that fail transiently are
+// retried from the start.
+func (d *driver) addFileURL(ctx context.Context, target fileTarget, p, datum string, src *pfs.AddFile_URLSource) error {
+	u, err := obj.ParseURL(src.URL)
+	if err != nil {
+		return err
+	}
+	c, err := d.openURL(ctx, u)
+	if err != nil {
+		return err
+	}
+	if !src.Recursive {
+		return d.addFileObject(ctx, target, p, datum, c, u.Object)
+	}
+	prefix := u.Object
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	var found bool
+	if err := c.Walk(ctx, prefix, func(name string) error {
+		found = true
+		return d.addFileObject(ctx, target, path.Join(p, strings.TrimPrefix(name, prefix)), datum, c, name)
+	}); err != nil {
+		return err
+	}
+	if !found {
+		return errors.Errorf("cannot add %s: there are no objects beneath %v", p, u)
+	}
+	return nil
+}
+
+// addFileObject appends the object name of c to the file at p.
+func (d *driver) addFileObject(ctx context.Context, target fileTarget, p, datum string, c obj.Client, name string) error {
+	p, err := ValidatePath(p)
+	if err != nil {
+		return err
+	}
+	var refs []chunk.DataRef
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(c.Get(ctx, name, pw))
+		}()
+		var err error
+		refs, err = d.storage.Upload(ctx, pr)
+		// unblock the download if the upload failed
+		pr.CloseWithError(err)
+		return err
+	}, d.downloadBackOff(), func(err error, retryIn time.Duration) error {
+		if !obj.IsRetriable(err) {
+			return err
+		}
+		log.WithError(err).WithField("object", name).Warnf("download failed, retrying in %v", retryIn)
+		return nil
+	}); err != nil {
+		return errors.Wrapf(err, "cannot add %s", p)
+	}
+	return d.addFileRefs(target, p, datum, refs)
+}
+
+// deleteFile deletes a file or directory from target. If datum is set, only
+// the content written under that datum is deleted.
+func (d *driver) deleteFile(target fileTarget, path, datum string) error {
+	path = cleanPath(path)
+	return target.modify(d, func(*txn, *tree) ([]fileOp, error) {
+		return []fileOp{{kind: opDelete, path: path, datum: datum}}, nil
+	})
+}
+
+// copyFile copies a file or directory from any commit into target, replacing
+// what is at dst unless appendFile is set.
+func (d *driver) copyFile(target fileTarget, dst, datum string, src *pfs.File, appendFile bool) error {
+	dst, err := ValidatePath(dst)
+	if err != nil {
+		return err
+	}
+	return target.modify(d, func(tx *txn, _ *tree) ([]fileOp, error) {
+		sci, err := tx.resolveCommit(src.Commit)
+		if err != nil {
+			return nil, err
+		}
+		srcTree, srcPath := tx.commitFiles(sci.Commit).tree, cleanPath(src.Path)
+		paths := srcTree.under(srcPath)
+		if len(paths) == 0 {
+			return nil, ErrFileNotFound{File: sci.Commit.NewFile(srcPath)}
+		}
+		var ops []fileOp
+		if !appendFile {
+			ops = append(ops, fileOp{kind: opDelete, path: dst})
+		}
+		for _, p := range paths {
+			target := dst + strings.TrimPrefix(p, strings.TrimSuffix(srcPath, "/"))
+			if appendFile {
+				for _, part := range srcTree.files[p].parts {
+					tag := datum
+					if tag == "" {
+						tag = part.datum
+					}
+					ops = append(ops, fileOp{kind: opAdd, path: target, datum: tag, refs: part.refs})
+				}
+				continue
+			}
+			ops = append(ops, fileOp{kind: opPut, path: target, file: srcTree.files[p]})
+		}
+		return ops, nil
+	})
+}
+
+// getTree resolves the commit of file and returns the commit's content along
+// with the commit itself. The content must not be modified.
+func (d *driver) getTree(file *pfs.File) (*pfs.Commit, *tree, error) {
+	var commit *pfs.Commit
+	var t *tree
+	if err := d.read(func(tx *txn) error {
+		ci, err := tx.resolveCommit(file.Commit)
+		if err != nil {
+			return err
+		}
+		commit, t = ci.Commit, tx.readTree(ci)
+		return nil
+	}); err != nil {
+		return nil, nil, err
+	}
+	return commit, t, nil
+}
+
+// getFile writes the given ranges of a single file to w, one after the
+// other. A range may not start past the end of the file, and is truncated at
+// the end of the file.
+func (d *driver) getFile(ctx context.Context, file *pfs.File, ranges []*pfs.ByteRange, w io.Writer) error {
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return err
+	}
+	p := cleanPath(file.Path)
+	f, ok := t.files[p]
+	if !ok {
+		if t.isDir(p) {
+			return errors.Errorf("cannot get %s: it is a directory", p)
+		}
+		return ErrFileNotFound{File: commit.NewFile(p)}
+	}
+	refs, size := f.dataRefs(), f.size()
+	for _, r := range ranges {
+		if r.Offset > size {
+			return errors.Errorf("cannot get %s: range starts at %d, past the end of the file at %d", p, r.Offset, size)
+		}
+		n := r.SizeBytes
+		if n == 0 {
+			n = -1
+		}
+		if _, err := io.Copy(w, d.storage.NewReader(ctx, chunk.Slice(refs, r.Offset, n))); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+// getFileURL writes the given ranges of a single file to the object at url
+// or, if file is a directory, every file beneath it to the corresponding
+// object beneath url. Each object is written with a single Put, so it is
+// replaced atomically.
+func (d *driver) getFileURL(ctx context.Context, file *pfs.File, ranges []*pfs.ByteRange, url string) error {
+	u, err := obj.ParseURL(url)
+	if err != nil {
+		return err
+	}
+	c, err := d.openURL(ctx, u)
+	if err != nil {
+		return err
+	}
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return err
+	}
+	put := func(name string, f func(w io.Writer) error) error {
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(f(pw))
+		}()
+		err := c.Put(ctx, name, pr)
+		pr.CloseWithError(err)
+		return err
+	}
+	p := cleanPath(file.Path)
+	if _, ok := t.files[p]; ok {
+		return put(u.Object, func(w io.Writer) error {
+			return d.getFile(ctx, commit.NewFile(p), ranges, w)
+		})
+	}
+	if !t.isDir(p) {
+		return ErrFileNotFound{File: commit.NewFile(p)}
+	}
+	for _, r := range ranges {
+		if r.Offset != 0 || r.SizeBytes != 0 {
+			return errors.Errorf("cannot get %s: a range cannot be used when getting a directory", p)
+		}
+	}
+	for _, fp := range t.under(p) {
+		f := t.files[fp]
+		name := path.Join(u.Object, strings.TrimPrefix(fp, dirPrefix(p)))
+		if err := put(name, func(w io.Writer) error {
+			_, err := io.Copy(w, d.storage.NewReader(ctx, f.dataRefs()))
+			return errors.EnsureStack(err)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *driver) inspectFile(file *pfs.File) (*pfs.FileInfo, error) {
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return nil, err
+	}
+	p := cleanPath(file.Path)
+	info := t.fileInfo(commit, p)
+	if info == nil {
+		return nil, ErrFileNotFound{File: commit.NewFile(p)}
+	}
+	return info, nil
+}
+
+// listFile lists the files and directories directly beneath the directory at
+// file's path, or just the file if the path is a regular file.
+func (d *driver) listFile(file *pfs.File, cb func(*pfs.FileInfo) error) error {
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return err
+	}
+	p := cleanPath(file.Path)
+	if _, ok := t.files[p]; ok {
+		return cb(t.fileInfo(commit, p))
+	}
+	if !t.isDir(p) {
+		return ErrFileNotFound{File: commit.NewFile(p)}
+	}
+	for _, child := range t.children(p) {
+		if err := cb(t.fileInfo(commit, child)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkFile lists the file or directory at file's path and, recursively,
+// everything beneath it.
+func (d *driver) walkFile(file *pfs.File, cb func(*pfs.FileInfo) error) error {
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return err
+	}
+	p := cleanPath(file.Path)
+	if _, ok := t.files[p]; !ok && !t.isDir(p) {
+		return ErrFileNotFound{File: commit.NewFile(p)}
+	}
+	return t.walk(p, func(p string) error {
+		return cb(t.fileInfo(commit, p))
+	})
+}
+
+// globFile lists the files and directories in commit whose paths match
+// pattern. Only the directories beneath the pattern's literal prefix are
+// walked, through the tree's directory index, and matches are passed to cb
+// as they are found. The info of a matched directory is computed from every
+// file beneath it, so matching all the directories of a tree costs its
+// number of files times its depth.
+func (d *driver) globFile(commit *pfs.Commit, pattern string, cb func(*pfs.FileInfo) error) error {
+	g, err := glob.Compile(cleanPath(pattern))
+	if err != nil {
+		return err
+	}
+	commit, t, err := d.getTree(commit.NewFile(""))
+	if err != nil {
+		return err
+	}
+	return walkGlob(t, g, func(p string) error {
+		return cb(t.fileInfo(commit, p))
+	})
+}
+
+// walkGlob calls f with every file and directory in t that matches g, in the
+// order of tree.walk.
+func walkGlob(t *tree, g *glob.Glob, f func(p string) error) error {
+	return t.walk(cleanPath(g.Prefix()), func(p string) error {
+		// the root directory only matches the pattern "/", not "/*"
+		if (p == "/" && g.String() != "/") || !g.Match(p) {
+			return nil
+		}
+		return f(p)
+	})
+}
+
+// getFileTAR writes the files and directories at or beneath file's path,
+// which may be a glob pattern, to w as a tar archive. Entries are named
+// relative to the root directory and carry the time they were committed.
+func (d *driver) getFileTAR(ctx context.Context, file *pfs.File, w io.Writer) error {
+	commit, t, err := d.getTree(file)
+	if err != nil {
+		return err
+	}
+	p := cleanPath(file.Path)
+	var roots []string
+	if glob.IsGlob(p) {
+		g, err := glob.Compile(p)
+		if err != nil {
+			return err
+		}
+		if err := walkGlob(t, g, func(p string) error {
+			roots = append(roots, p)
+			return nil
+		}); err != nil {
+			return err
+		}
+	} else {
+		if _, ok := t.files[p]; !ok && !t.isDir(p) {
+			return ErrFileNotFound{File: commit.NewFile(p)}
+		}
+		roots = []string{p}
+	}
+	tw := tar.NewWriter(w)
+	written := make(map[string]bool)
+	for _, root := range roots {
+		if err := t.walk(root, func(p string) error {
+			if p == "/" || written[p] {
+				return nil
+			}
+			written[p] = true
+			return d.writeTAREntry(ctx, tw, t, commit, p)
+		}); err != nil {
+			return err
+		}
+	}
+	return errors.EnsureStack(tw.Close())
+}
+
+func (d *driver) writeTAREntry(ctx context.Context, tw *tar.Writer, t *tree, commit *pfs.Commit, p string) error {
+	info := t.fileInfo(commit, p)
+	hdr := &tar.Header{
+		Name: strings.TrimPrefix(info.File.Path, "/"),
+		// PAX keeps mtimes at full precision
+		Format: tar.FormatPAX,
+	}
+	if info.Committed != nil {
+		hdr.ModTime = info.Committed.AsTime()
+	}
+	if info.FileType == pfs.FileType_DIR {
+		hdr.Typeflag = tar.TypeDir
+		hdr.Mode = 0755
+		return errors.EnsureStack(tw.WriteHeader(hdr))
+	}
+	hdr.Typeflag = tar.TypeReg
+	hdr.Mode = 0644
+	hdr.Size = info.SizeBytes
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err := io.Copy(tw, d.storage.NewReader(ctx, t.files[p].dataRefs()))
+	return errors.EnsureStack(err)
+}
@@ -0,0 +1,222 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sort"
+	"time"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// defaultFileSetTTL is how long a file set lives unless a TTL is given.
+const defaultFileSetTTL = 10 * time.Minute
+
+// fileSet is a temporary, ordered list of file modifications that can be
+// added to commits. A file set is deleted once it expires, and its content is
+// garbage collected unless a commit refers to it by then. File sets are kept
+// in memory and do not survive a restart.
+type fileSet struct {
+	ops     []fileOp
+	expires time.Time
+	// pins counts the cache entries that refer to the file set. A pinned
+	// file set does not expire.
+	pins int
+}
+
+// alive returns true if the file set has not expired at now.
+func (fs *fileSet) alive(now time.Time) bool {
+	return fs.pins > 0 || now.Before(fs.expires)
+}
+
+// size returns the size of the content the file set refers to.
+func (fs *fileSet) size() int64 {
+	var n int64
+	for _, op := range fs.ops {
+		for _, ref := range op.refs {
+			n += ref.SizeBytes
+		}
+		if op.file != nil {
+			n += op.file.size()
+		}
+	}
+	return n
+}
+
+// fileSetWriter is a fileTarget that writes to a new file set. The file set
+// is stored from the start, so that garbage collection sees its content, but
+// its ID is only handed out once it is complete.
+type fileSetWriter struct {
+	id   string
+	tree *tree
+}
+
+func (d *driver) newFileSetWriter() *fileSetWriter {
+	return &fileSetWriter{id: d.createFileSet(nil, defaultFileSetTTL), tree: newTree()}
+}
+
+func (w *fileSetWriter) modify(d *driver, f func(tx *txn, t *tree) ([]fileOp, error)) error {
+	return d.read(func(tx *txn) error {
+		ops, err := f(tx, w.tree)
+		if err != nil {
+			return err
+		}
+		if err := checkOps(w.tree, ops); err != nil {
+			return err
+		}
+		for _, op := range ops {
+			w.tree.apply(op)
+		}
+		d.fileSetsMu.Lock()
+		defer d.fileSetsMu.Unlock()
+		fs, err := d.lookupFileSet(w.id)
+		if err != nil {
+			return err
+		}
+		fs.ops = append(fs.ops, ops...)
+		// keep the file set alive while it is being written
+		fs.expires = time.Now().Add(defaultFileSetTTL)
+		return nil
+	})
+}
+
+// fileSetTTL converts a client supplied TTL, where 0 means the default.
+func fileSetTTL(ttlSeconds int64) (time.Duration, error) {
+	if ttlSeconds < 0 {
+		return 0, errors.Errorf("invalid file set TTL %ds", ttlSeconds)
+	}
+	if ttlSeconds == 0 {
+		return defaultFileSetTTL, nil
+	}
+	return time.Duration(ttlSeconds) * time.Second, nil
+}
+
+// createFileSet stores a new file set with ops that expires after ttl, and
+// returns its ID.
+func (d *driver) createFileSet(ops []fileOp, ttl time.Duration) string {
+	id := uuid.NewWithoutDashes()
+	d.fileSetsMu.Lock()
+	defer d.fileSetsMu.Unlock()
+	d.fileSets[id] = &fileSet{ops: ops, expires: time.Now().Add(ttl)}
+	return id
+}
+
+// lookupFileSet returns the file set id, which must not have expired. The
+// caller must hold fileSetsMu.
+func (d *driver) lookupFileSet(id string) (*fileSet, error) {
+	fs, ok := d.fileSets[id]
+	if !ok || !fs.alive(time.Now()) {
+		return nil, ErrFileSetNotFound{ID: id}
+	}
+	return fs, nil
+}
+
+// getFileSet creates a file set with the content of commit.
+func (d *driver) getFileSet(commit *pfs.Commit) (string, error) {
+	var ops []fileOp
+	if err := d.read(func(tx *txn) error {
+		ci, err := tx.resolveCommit(commit)
+		if err != nil {
+			return err
+		}
+		t := tx.commitFiles(ci.Commit).tree
+		paths := make([]string, 0, len(t.files))
+		for p := range t.files {
+			paths = append(paths, p)
+		}
+		sort.Strings(paths)
+		for _, p := range paths {
+			for _, part := range t.files[p].parts {
+				ops = append(ops, fileOp{kind: opAdd, path: p, datum: part.datum, refs: part.refs})
+			}
+		}
+		return nil
+	}); err != nil {
+		return "", err
+	}
+	return d.createFileSet(ops, defaultFileSetTTL), nil
+}
+
+// addFileSet applies the modifications in the file set id to an open commit.
+func (d *driver) addFileSet(commit *pfs.Commit, id string) error {
+	return commitTarget{commit}.modify(d, func(*txn, *tree) ([]fileOp, error) {
+		d.fileSetsMu.Lock()
+		defer d.fileSetsMu.Unlock()
+		fs, err := d.lookupFileSet(id)
+		if err != nil {
+			return nil, err
+		}
+		return fs.ops, nil
+	})
+}
+
+// renewFileSet makes the file set id expire after ttl from now. A pinned
+// file set does not expire before it is unpinned.
+func (d *driver) renewFileSet(id string, ttl time.Duration) error {
+	d.fileSetsMu.Lock()
+	defer d.fileSetsMu.Unlock()
+	fs, err := d.lookupFileSet(id)
+	if err != nil {
+		return err
+	}
+	fs.expires = time.Now().Add(ttl)
+	return nil
+}
+
+// composeFileSet creates a file set with the modifications of each of the
+// file sets ids in order, so that the later file sets take precedence: a
+// file deleted by a later file set is deleted even if an earlier one added
+// to it.
+func (d *driver) composeFileSet(ids []string, ttl time.Duration) (string, error) {
+	var ops []fileOp
+	if err := func() error {
+		d.fileSetsMu.Lock()
+		defer d.fileSetsMu.Unlock()
+		for _, id := range ids {
+			fs, err := d.lookupFileSet(id)
+			if err != nil {
+				return err
+			}
+			ops = append(ops, fs.ops...)
+		}
+		return nil
+	}(); err != nil {
+		return "", err
+	}
+	if err := checkOps(newTree(), ops); err != nil {
+		return "", err
+	}
+	return d.createFileSet(ops, ttl), nil
+}
+
+// expireFileSets deletes the file sets that have expired.
+func (d *driver) expireFileSets() {
+	d.fileSetsMu.Lock()
+	defer d.fileSetsMu.Unlock()
+	now := time.Now()
+	for id, fs := range d.fileSets {
+		if !fs.alive(now) {
+			delete(d.fileSets, id)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+)
+
+// fsckReport is called by the checks in fsck for every problem they find,
+// along with a description of the fix they staged for it, if any.
+type fsckReport func(problem, fix string)
+
+// fsck checks the consistency of the PFS metadata and streams a description
+// of every problem to cb. If fix is set, the problems that can be fixed are
+// fixed, in a single write, and reported as fixes. Missing chunks cannot be
+// fixed, and are always reported as errors.
+func (d *driver) fsck(ctx context.Context, fix bool, cb func(*pfs.FsckResponse) error) error {
+	var resps []*pfs.FsckResponse
+	check := func(tx *txn) error {
+		resps = nil
+		tx.fsck(func(problem, fixDesc string) {
+			if fix && fixDesc != "" {
+				resps = append(resps, &pfs.FsckResponse{Fix: problem + "; " + fixDesc})
+				return
+			}
+			resps = append(resps, &pfs.FsckResponse{Error: problem})
+		})
+		return nil
+	}
+	var err error
+	if fix {
+		err = d.write(check)
+	} else {
+		// fixes are staged but never applied
+		err = d.read(check)
+	}
+	if err != nil {
+		return err
+	}
+	missing, err := d.fsckChunks(ctx)
+	if err != nil {
+		return err
+	}
+	for _, problem := range missing {
+		resps = append(resps, &pfs.FsckResponse{Error: problem})
+	}
+	for _, resp := range resps {
+		if err := cb(resp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fsck runs every metadata check against tx, staging fixes as it goes so that
+// later checks see the fixed metadata.
+func (tx *txn) fsck(report fsckReport) {
+	tx.fsckSizes(report)
+	tx.fsckRepoBranches(report)
+	tx.fsckHeads(report)
+	tx.fsckParentLinks(report)
+	tx.fsckProvenance(report)
+	tx.fsckCommitSets(report)
+}
+
+// fsckSizes checks that the running size totals of every commit's content
+// match a recount of its files. Drifted totals are recounted, along with the
+// repo's size if the commit is counted in it.
+func (tx *txn) fsckSizes(report fsckReport) {
+	for _, ci := range tx.listCommitInfos(nil) {
+		cf, ok := tx.d.files[ci.Commit.Key()]
+		if !ok {
+			continue
+		}
+		if err := cf.tree.checkSizes(); err != nil {
+			report(fmt.Sprintf("commit %v: %v", ci.Commit, err), "recounted the sizes")
+			commit := ci.Commit
+			tx.afterApply(func() {
+				counted := cf.counted
+				if counted {
+					tx.d.countFiles(commit, cf, -1)
+				}
+				cf.tree.recountSizes()
+				if counted {
+					tx.d.countFiles(commit, cf, 1)
+				}
+			})
+		}
+	}
+}
+
+// fsckRepoBranches checks that every branch belongs to a repo, and that every
+// repo lists exactly its branches.
+func (tx *txn) fsckRepoBranches(report fsckReport) {
+	branches := make(map[string][]*pfs.Branch)
+	for _, bi := range tx.listBranchInfos(nil) {
+		if _, err := tx.getRepoInfo(bi.Branch.Repo); err != nil {
+			report(fmt.Sprintf("branch %v belongs to repo %v, which does not exist", bi.Branch, bi.Branch.Repo), "deleted the branch")
+			tx.delete(branchesCollection, bi.Branch.Key())
+			continue
+		}
+		key := bi.Branch.Repo.Key()
+		branches[key] = append(branches[key], bi.Branch)
+	}
+	for _, ri := range tx.listRepoInfos() {
+		expected := branches[ri.Repo.Key()]
+		sort.Slice(expected, func(i, j int) bool { return expected[i].Name < expected[j].Name })
+		if sameBranches(ri.Branches, expected) {
+			continue
+		}
+		report(fmt.Sprintf("repo %v lists branches %v, but has branches %v", ri.Repo, ri.Branches, expected), "updated the repo's branches")
+		ri.Branches = expected
+		tx.putRepoInfo(ri)
+	}
+}
+
+// fsckHeads checks that every branch has a head commit that exists.
+func (tx *txn) fsckHeads(report fsckReport) {
+	for _, bi := range tx.listBranchInfos(nil) {
+		var problem string
+		if bi.Head == nil {
+			problem = fmt.Sprintf("branch %v has no head commit", bi.Branch)
+		} else if _, ok := tx.getCommitInfoByKey(bi.Head.Key()); !ok {
+			problem = fmt.Sprintf("the head commit %v of branch %v does not exist", bi.Head, bi.Branch)
+		} else {
+			continue
+		}
+		// repair the head with the branch's latest commit, if it has one
+		var latest *pfs.CommitInfo
+		for _, ci := range tx.listCommitInfos(bi.Branch.Repo) {
+			if ci.Commit.Branch.Name == bi.Branch.Name && (latest == nil || ci.Started.AsTime().After(latest.Started.AsTime())) {
+				latest = ci
+			}
+		}
+		if latest == nil {
+			latest = tx.newCommit(bi.Branch, nil, pfs.OriginKind_FSCK, "")
+			tx.finishCommit(latest)
+		}
+		report(problem, fmt.Sprintf("set the head to %v", latest.Commit))
+		bi.Head = latest.Commit
+		tx.putBranchInfo(bi)
+	}
+}
+
+// fsckParentLinks checks that every commit's parent exists and lists it as a
+// child, and that every commit's children exist and list it as their parent.
+func (tx *txn) fsckParentLinks(report fsckReport) {
+	for _, ci := range tx.listCommitInfos(nil) {
+		if ci.ParentCommit == nil {
+			continue
+		}
+		parent, ok := tx.getCommitInfoByKey(ci.ParentCommit.Key())
+		if !ok {
+			report(fmt.Sprintf("the parent %v of commit %v does not exist", ci.ParentCommit, ci.Commit), "removed the parent")
+			ci.ParentCommit = nil
+			tx.putCommitInfo(ci)
+			continue
+		}
+		if !hasCommit(parent.ChildCommits, ci.Commit) {
+			report(fmt.Sprintf("commit %v is not a child of its parent %v", ci.Commit, parent.Commit), "added it to the parent's children")
+			parent.ChildCommits = append(parent.ChildCommits, ci.Commit)
+			tx.putCommitInfo(parent)
+		}
+	}
+	for _, ci := range tx.listCommitInfos(nil) {
+		var children []*pfs.Commit
+		for _, child := range ci.ChildCommits {
+			cci, ok := tx.getCommitInfoByKey(child.Key())
+			switch {
+			case !ok:
+				report(fmt.Sprintf("the child %v of commit %v does not exist", child, ci.Commit), "removed the child")
+			case cci.ParentCommit == nil || cci.ParentCommit.Key() != ci.Commit.Key():
+				report(fmt.Sprintf("commit %v lists %v as a child, but it is not its parent", ci.Commit, child), "removed the child")
+			default:
+				children = append(children, child)
+			}
+		}
+		if len(children) != len(ci.ChildCommits) {
+			ci.ChildCommits = children
+			tx.putCommitInfo(ci)
+		}
+	}
+}
+
+// fsckProvenance checks that every branch's direct provenance exists, and
+// that every branch's provenance and subvenance agree with the direct
+// provenance of all branches.
+func (tx *txn) fsckProvenance(report fsckReport) {
+	for _, bi := range tx.listBranchInfos(nil) {
+		var prov []*pfs.Branch
+		for _, p := range bi.DirectProvenance {
+			if _, err := tx.getBranchInfo(p); err != nil {
+				report(fmt.Sprintf("branch %v is provenant on %v, which does not exist", bi.Branch, p), "removed the provenance")
+				continue
+			}
+			prov = append(prov, p)
+		}
+		if len(prov) != len(bi.DirectProvenance) {
+			bi.DirectProvenance = prov
+			tx.putBranchInfo(bi)
+		}
+	}
+	before := make(map[string]*pfs.BranchInfo)
+	for _, bi := range tx.listBranchInfos(nil) {
+		before[bi.Branch.Key()] = bi
+	}
+	tx.updateProvenance()
+	for _, bi := range tx.listBranchInfos(nil) {
+		old := before[bi.Branch.Key()]
+		if !sameBranches(old.Provenance, bi.Provenance) {
+			report(fmt.Sprintf("branch %v has provenance %v, but its direct provenance implies %v", bi.Branch, old.Provenance, bi.Provenance), "recomputed the provenance")
+		}
+		if !sameBranches(old.Subvenance, bi.Subvenance) {
+			report(fmt.Sprintf("branch %v has subvenance %v, but the provenance of other branches implies %v", bi.Branch, old.Subvenance, bi.Subvenance), "recomputed the subvenance")
+		}
+	}
+}
+
+// fsckCommitSets checks that every CommitSet is complete: a commit on a
+// branch must be accompanied by a commit in the same set on every branch
+// that was downstream of it when the set was created. A missing commit is
+// replaced by an FSCK commit with the content of the downstream branch's head
+// at the time.
+func (tx *txn) fsckCommitSets(report fsckReport) {
+	branchInfos := make(map[string]*pfs.BranchInfo)
+	for _, bi := range tx.listBranchInfos(nil) {
+		branchInfos[bi.Branch.Key()] = bi
+	}
+	sets := make(map[string]map[string]bool)
+	byBranch := make(map[string][]*pfs.CommitInfo)
+	infos := tx.listCommitInfos(nil)
+	for _, ci := range infos {
+		if sets[ci.Commit.Id] == nil {
+			sets[ci.Commit.Id] = make(map[string]bool)
+		}
+		sets[ci.Commit.Id][ci.Commit.Branch.Key()] = true
+		byBranch[ci.Commit.Branch.Key()] = append(byBranch[ci.Commit.Branch.Key()], ci)
+	}
+	for _, ci := range infos {
+		bi, ok := branchInfos[ci.Commit.Branch.Key()]
+		if !ok {
+			continue
+		}
+		for _, sb := range bi.Subvenance {
+			key := sb.Key()
+			if sets[ci.Commit.Id][key] {
+				continue
+			}
+			// the downstream branch's head when the set was created
+			var head *pfs.CommitInfo
+			for _, sci := range byBranch[key] {
+				if !sci.Started.AsTime().After(ci.Started.AsTime()) && (head == nil || sci.Started.AsTime().After(head.Started.AsTime())) {
+					head = sci
+				}
+			}
+			if head == nil || !tx.upstreamOf(ci.Commit.Branch, head.DirectProvenance) {
+				// the branch wasn't downstream yet
+				continue
+			}
+			// the parent's child list may have been updated by an earlier fix
+			parent, _ := tx.getCommitInfoByKey(head.Commit.Key())
+			fci := tx.newCommitInSet(sb, parent, ci.Commit.Id, pfs.OriginKind_FSCK, "")
+			fci.DirectProvenance = head.DirectProvenance
+			tx.finishCommit(fci)
+			if sbi, err := tx.getBranchInfo(sb); err == nil && sbi.Head.Key() == head.Commit.Key() {
+				sbi.Head = fci.Commit
+				tx.putBranchInfo(sbi)
+			}
+			report(fmt.Sprintf("commit set %s has a commit on %v, but not on the downstream branch %v", ci.Commit.Id, ci.Commit.Branch, sb), fmt.Sprintf("created %v", fci.Commit))
+			sets[ci.Commit.Id][key] = true
+		}
+	}
+}
+
+// upstreamOf returns true if branch is one of provenance, or in the
+// provenance of one of them.
+func (tx *txn) upstreamOf(branch *pfs.Branch, provenance []*pfs.Branch) bool {
+	for _, p := range provenance {
+		if p.Key() == branch.Key() {
+			return true
+		}
+		pbi, err := tx.getBranchInfo(p)
+		if err != nil {
+			continue
+		}
+		if hasBranch(pbi.Provenance, branch) {
+			return true
+		}
+	}
+	return false
+}
+
+// fsckChunks checks that every chunk referenced by a commit exists, and
+// returns a description of each commit with missing chunks.
+func (d *driver) fsckChunks(ctx context.Context) ([]string, error) {
+	stored := make(map[string]bool)
+	if err := d.storage.List(ctx, nil, nil, func(id chunk.ID) error {
+		stored[string(id)] = true
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	type missingChunk struct {
+		id   chunk.ID
+		path string
+	}
+	missing := make(map[string][]missingChunk)
+	var keys []string
+	if err := d.read(func(tx *txn) error {
+		for _, ci := range tx.listCommitInfos(nil) {
+			key := ci.Commit.Key()
+			t := tx.commitFiles(ci.Commit).tree
+			for p, f := range t.files {
+				for _, ref := range f.dataRefs() {
+					if !stored[string(ref.ID)] {
+						missing[key] = append(missing[key], missingChunk{ref.ID, p})
+					}
+				}
+			}
+			if len(missing[key]) > 0 {
+				keys = append(keys, key)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var problems []string
+	for _, key := range keys {
+		// chunks uploaded after the listing may have been referenced since
+		var confirmed []missingChunk
+		for _, mc := range missing[key] {
+			exists, err := d.storage.Exists(ctx, mc.id)
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				confirmed = append(confirmed, mc)
+			}
+		}
+		if len(confirmed) == 0 {
+			continue
+		}
+		sort.Slice(confirmed, func(i, j int) bool { return confirmed[i].path < confirmed[j].path })
+		problems = append(problems, fmt.Sprintf("commit %s refers to %d chunks that do not exist, including %s in %s", key, len(confirmed), confirmed[0].id.HexString(), confirmed[0].path))
+	}
+	return problems, nil
+}
+
+func hasCommit(commits []*pfs.Commit, commit *pfs.Commit) bool {
+	for _, c := range commits {
+		if c.Key() == commit.Key() {
+			return true
+		}
+	}
+	return false
+}
+
+func hasBranch(branches []*pfs.Branch, branch *pfs.Branch) bool {
+	for _, b := range branches {
+		if b.Key() == branch.Key() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+)
+
+const (
+	// defaultGCPeriod is how often garbage is collected.
+	defaultGCPeriod = 10 * time.Minute
+	// defaultGCGrace is how long newly uploaded chunks are protected from
+	// garbage collection, while they wait to be referenced.
+	defaultGCGrace = 10 * time.Minute
+)
+
+// runGC collects garbage every period until ctx is done.
+func (d *driver) runGC(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		n, err := d.collectGarbage(ctx)
+		if err != nil {
+			log.WithError(err).Warn("garbage collection failed")
+			continue
+		}
+		log.WithField("chunks", n).Debug("garbage collected")
+	}
+}
+
+// collectGarbage deletes expired file sets and uploads, and then every chunk
+// that no commit, file set or upload refers to. It returns the number of
+// chunks deleted.
+func (d *driver) collectGarbage(ctx context.Context) (int64, error) {
+	d.expireFileSets()
+	d.expireUploads()
+	return d.storage.GarbageCollect(ctx, d.gcGrace, d.markChunks)
+}
+
+// markChunks calls live with every chunk that is referenced.
+func (d *driver) markChunks(live func(chunk.ID)) error {
+	markRefs := func(refs []chunk.DataRef) {
+		for _, ref := range refs {
+			live(ref.ID)
+		}
+	}
+	markOp := func(op fileOp) {
+		markRefs(op.refs)
+		if op.file != nil {
+			markRefs(op.file.dataRefs())
+		}
+	}
+	// uploads are marked before commits, as a finished upload's content
+	// moves from the upload to its commit
+	d.uploadsMu.Lock()
+	now := time.Now()
+	for _, u := range d.uploads {
+		if !now.Before(u.expires) {
+			continue
+		}
+		for _, refs := range u.refs {
+			markRefs(refs)
+		}
+	}
+	d.uploadsMu.Unlock()
+	d.fileSetsMu.Lock()
+	for _, fs := range d.fileSets {
+		for _, op := range fs.ops {
+			markOp(op)
+		}
+	}
+	d.fileSetsMu.Unlock()
+	return d.read(func(*txn) error {
+		// trees share unmodified files with their parents
+		marked := make(map[*file]bool)
+		for _, cf := range d.files {
+			for _, op := range cf.diff {
+				markOp(op)
+			}
+			for _, f := range cf.tree.files {
+				if !marked[f] {
+					marked[f] = true
+					markRefs(f.dataRefs())
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,165 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+)
+
+// httpPrefix is the path under which the HTTP gateway serves files.
+const httpPrefix = "/pfs/"
+
+// NewHTTPHandler returns an HTTP gateway that serves the content of files
+// from c at /pfs/<repo>@<branch-or-commit>/<path>. It supports the HTTP Range
+// semantics of GetFile, including multiple ranges, and conditional requests
+// using the file's hash as its ETag, so that clients can seek inside large
+// files.
+func NewHTTPHandler(c pfs.APIClient) http.Handler {
+	return &httpGateway{c: c}
+}
+
+type httpGateway struct {
+	c pfs.APIClient
+}
+
+func (g *httpGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, httpPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	commitPart, p := strings.TrimPrefix(r.URL.Path, httpPrefix), "/"
+	if i := strings.IndexByte(commitPart, '/'); i >= 0 {
+		commitPart, p = commitPart[:i], commitPart[i:]
+	}
+	commit, err := pfs.ParseCommit(commitPart)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fi, err := g.c.InspectFile(r.Context(), &pfs.InspectFileRequest{File: commit.NewFile(p)})
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if fi.FileType != pfs.FileType_FILE {
+		http.Error(w, p+" is a directory", http.StatusBadRequest)
+		return
+	}
+	var modTime time.Time
+	if fi.Committed != nil {
+		modTime = fi.Committed.AsTime()
+	}
+	w.Header().Set("ETag", `"`+pfs.EncodeHash(fi.Hash)+`"`)
+	// fi.File names the resolved commit, so every range is read from the same
+	// version of the file even if the branch moves
+	fr := &fileReader{ctx: r.Context(), c: g.c, file: fi.File, size: fi.SizeBytes}
+	defer fr.Close()
+	http.ServeContent(w, r, path.Base(p), modTime, fr)
+}
+
+// httpError writes the HTTP equivalent of a gRPC error.
+func httpError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch status.Code(err) {
+	case codes.NotFound:
+		code = http.StatusNotFound
+	case codes.InvalidArgument, codes.FailedPrecondition:
+		code = http.StatusBadRequest
+	case codes.PermissionDenied:
+		code = http.StatusForbidden
+	case codes.Unauthenticated:
+		code = http.StatusUnauthorized
+	}
+	http.Error(w, status.Convert(err).Message(), code)
+}
+
+// fileReader is an io.ReadSeeker over a file in PFS. Each read after a seek
+// opens a GetFile stream that starts at the new offset.
+type fileReader struct {
+	ctx    context.Context
+	c      pfs.APIClient
+	file   *pfs.File
+	size   int64
+	offset int64
+	r      io.Reader
+	cancel context.CancelFunc
+}
+
+func (f *fileReader) Read(p []byte) (int, error) {
+	if f.offset >= f.size {
+		return 0, io.EOF
+	}
+	if f.r == nil {
+		ctx, cancel := context.WithCancel(f.ctx)
+		gfc, err := f.c.GetFile(ctx, &pfs.GetFileRequest{File: f.file, Offset: f.offset})
+		if err != nil {
+			cancel()
+			return 0, errors.EnsureStack(err)
+		}
+		f.r, f.cancel = grpcutil.NewStreamingBytesReader(gfc), cancel
+	}
+	n, err := f.r.Read(p)
+	f.offset += int64(n)
+	return n, err
+}
+
+func (f *fileReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+	if offset < 0 {
+		return 0, errors.Errorf("cannot seek to negative offset %d", offset)
+	}
+	if offset != f.offset {
+		f.Close()
+		f.offset = offset
+	}
+	return offset, nil
+}
+
+// Close stops the current GetFile stream, if there is one.
+func (f *fileReader) Close() error {
+	if f.cancel != nil {
+		f.cancel()
+		f.r, f.cancel = nil, nil
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/split"
+	"github.com/bhojpur/data/pkg/internal/sqlutil"
+)
+
+// ingestSQL commits a snapshot of the result of an SQL query to branch, and
+// returns the commit along with the number of rows read. The result is
+// stored before the commit is started, so a failing query leaves the branch
+// untouched.
+func (d *driver) ingestSQL(ctx context.Context, branch *pfs.Branch, ingest *pfs.SQLIngest) (*pfs.Commit, int64, error) {
+	if ingest.Query == "" {
+		return nil, 0, errors.New("SQL ingest must have a query")
+	}
+	dbURL, err := d.sqlURL(ctx, ingest.Url, ingest.Secret)
+	if err != nil {
+		return nil, 0, err
+	}
+	db, err := dbutil.NewDB(ctx, dbURL)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer db.Close()
+	rows, err := db.QueryContext(ctx, ingest.Query)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "cannot run SQL ingest query")
+	}
+	defer rows.Close()
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, 0, errors.EnsureStack(err)
+	}
+	return d.ingestRows(ctx, branch, ingest, columns, func(w sqlutil.RowWriter) (int64, error) {
+		return sqlutil.WriteRows(rows, w)
+	})
+}
+
+// ingestRows commits the rows that write writes, which have the given
+// columns, to the files beneath the path of ingest, replacing what was there.
+func (d *driver) ingestRows(ctx context.Context, branch *pfs.Branch, ingest *pfs.SQLIngest, columns []string, write func(sqlutil.RowWriter) (int64, error)) (*pfs.Commit, int64, error) {
+	dir, err := ValidatePath(ingest.Path)
+	if err != nil {
+		return nil, 0, err
+	}
+	var format split.Format
+	var newRowWriter func(io.Writer, []string) (sqlutil.RowWriter, error)
+	switch ingest.Delimiter {
+	case pfs.Delimiter_CSV:
+		format, newRowWriter = split.CSV, sqlutil.NewCSVWriter
+	case pfs.Delimiter_JSON:
+		format, newRowWriter = split.JSON, sqlutil.NewJSONWriter
+	default:
+		return nil, 0, errors.Errorf("cannot ingest SQL as %v: the delimiter must be CSV or JSON", ingest.Delimiter)
+	}
+	if err := d.read(func(tx *txn) error {
+		_, err := tx.getRepoInfo(branch.Repo)
+		return err
+	}); err != nil {
+		return nil, 0, err
+	}
+
+	// the rows are written to a pipe and split into files on the other end
+	pr, pw := io.Pipe()
+	var n int64
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rw, err := newRowWriter(pw, columns)
+		if err == nil {
+			n, err = write(rw)
+			if err == nil {
+				err = rw.Close()
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	w := d.newFileSetWriter()
+	err = d.deleteFile(w, dir, "")
+	if err == nil {
+		opts := split.Options{TargetRecords: ingest.TargetFileDatums, TargetBytes: ingest.TargetFileBytes}
+		err = split.Split(pr, format, opts, func(i int, data []byte) error {
+			return d.addFile(ctx, w, pfs.SplitFileName(dir, i), "", bytes.NewReader(data))
+		})
+	}
+	pr.CloseWithError(err)
+	<-done
+	if err != nil {
+		return nil, 0, err
+	}
+
+	commit, err := d.startCommit(branch, nil, "")
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := d.addFileSet(commit, w.id); err != nil {
+		// don't leave an open commit on the branch
+		if ferr := d.finishCommit(ctx, commit, "", err.Error()); ferr != nil {
+			return nil, 0, ferr
+		}
+		return nil, 0, err
+	}
+	if err := d.finishCommit(ctx, commit, "", ""); err != nil {
+		return nil, 0, err
+	}
+	return commit, n, nil
+}
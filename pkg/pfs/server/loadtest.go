@@ -0,0 +1,481 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	units "github.com/docker/go-units"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"sigs.k8s.io/yaml"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+)
+
+// DefaultLoadTestSpec is the spec of the load test RunLoadTestDefault runs.
+const DefaultLoadTestSpec = `commits: 5
+operations: 100
+concurrency: 4
+add: 7
+modify: 2
+delete: 1
+fileSizes:
+- min: 1KB
+  max: 64KB
+  weight: 9
+- min: 1MB
+  max: 4MB
+  weight: 1
+validate: true
+`
+
+// defaultLoadTestBranch is where load tests commit unless a branch is given.
+var defaultLoadTestBranch = (&pfs.Repo{Name: "load_test", Type: pfs.UserRepoType}).NewBranch("master")
+
+// LoadTestSpec describes a load test, in YAML. A load test makes a number of
+// commits to a branch, one after the other, each of which puts, replaces and
+// deletes files with content generated from the test's seed, so that the
+// same spec and seed always make the same commits.
+type LoadTestSpec struct {
+	// Commits is how many commits the load test makes.
+	Commits int `json:"commits"`
+	// Operations is how many files each commit puts, replaces or deletes.
+	Operations int `json:"operations"`
+	// Concurrency is how many of a commit's operations run at once. It
+	// defaults to 1.
+	Concurrency int `json:"concurrency"`
+	// Add, Modify and Delete weight how often an operation puts a new file,
+	// replaces a file of an earlier commit or deletes one. An operation puts
+	// a new file if there is no earlier file left for it.
+	Add    float64 `json:"add"`
+	Modify float64 `json:"modify"`
+	Delete float64 `json:"delete"`
+	// FileSizes is the distribution of the sizes of the files put.
+	FileSizes []LoadTestFileSize `json:"fileSizes"`
+	// Validate makes the load test read back each file a commit touched once
+	// the commit is finished.
+	Validate bool `json:"validate"`
+}
+
+// LoadTestFileSize is a range of file sizes, such as "1MB", from which a
+// size is picked uniformly. Ranges are picked in proportion to their weight.
+type LoadTestFileSize struct {
+	Min    string  `json:"min"`
+	Max    string  `json:"max"`
+	Weight float64 `json:"weight"`
+}
+
+type loadTestSizeRange struct {
+	min, max int64
+	weight   float64
+}
+
+// loadTestOp is one file operation of a load test.
+type loadTestOp struct {
+	path   string
+	delete bool
+	size   int64
+	// seed generates the file's content.
+	seed int64
+}
+
+// loadTestSpec is a parsed load test spec.
+type loadTestSpec struct {
+	*LoadTestSpec
+	sizes []loadTestSizeRange
+}
+
+// parseLoadTestSpec parses and validates a spec.
+func parseLoadTestSpec(spec string) (*loadTestSpec, error) {
+	s := &LoadTestSpec{}
+	if err := yaml.UnmarshalStrict([]byte(spec), s); err != nil {
+		return nil, errors.Wrapf(err, "invalid load test spec")
+	}
+	if s.Commits <= 0 || s.Operations <= 0 {
+		return nil, errors.New("invalid load test spec: commits and operations must be positive")
+	}
+	if s.Concurrency == 0 {
+		s.Concurrency = 1
+	}
+	if s.Concurrency < 0 {
+		return nil, errors.New("invalid load test spec: concurrency cannot be negative")
+	}
+	if s.Add < 0 || s.Modify < 0 || s.Delete < 0 || s.Add+s.Modify+s.Delete == 0 {
+		return nil, errors.New("invalid load test spec: add, modify and delete cannot be negative, and one must be positive")
+	}
+	ls := &loadTestSpec{LoadTestSpec: s}
+	var total float64
+	for _, fs := range s.FileSizes {
+		min, err := units.FromHumanSize(fs.Min)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid load test spec: invalid file size %q", fs.Min)
+		}
+		max, err := units.FromHumanSize(fs.Max)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid load test spec: invalid file size %q", fs.Max)
+		}
+		if min < 0 || max < min || fs.Weight < 0 {
+			return nil, errors.Errorf("invalid load test spec: invalid file size range %s-%s with weight %v", fs.Min, fs.Max, fs.Weight)
+		}
+		ls.sizes = append(ls.sizes, loadTestSizeRange{min: min, max: max, weight: fs.Weight})
+		total += fs.Weight
+	}
+	// deletes put a new file when there are no files to delete yet
+	if total == 0 {
+		return nil, errors.New("invalid load test spec: fileSizes must have a positive weight")
+	}
+	return ls, nil
+}
+
+// plan returns the operations of every commit, generated from seed.
+func (s *loadTestSpec) plan(seed int64) [][]loadTestOp {
+	rng := rand.New(rand.NewSource(seed))
+	// files holds the paths put by earlier operations, in order, so that
+	// picking one is deterministic
+	var files []string
+	var next int
+	commits := make([][]loadTestOp, s.Commits)
+	for i := range commits {
+		// each path is touched at most once per commit, as the operations
+		// of a commit run in no particular order
+		touched := make(map[string]bool)
+		for j := 0; j < s.Operations; j++ {
+			op := loadTestOp{seed: rng.Int63()}
+			r := rng.Float64() * (s.Add + s.Modify + s.Delete)
+			if r >= s.Add {
+				op.path = pickUntouched(rng, files, touched)
+				op.delete = r >= s.Add+s.Modify && op.path != ""
+			}
+			if op.path == "" {
+				op.path = fmt.Sprintf("/load/%08d", next)
+				next++
+				files = append(files, op.path)
+			}
+			touched[op.path] = true
+			if op.delete {
+				for k, f := range files {
+					if f == op.path {
+						files = append(files[:k], files[k+1:]...)
+						break
+					}
+				}
+			} else {
+				op.size = s.pickSize(rng)
+			}
+			commits[i] = append(commits[i], op)
+		}
+	}
+	return commits
+}
+
+// pickUntouched returns a random path of files that is not touched, or ""
+// if there is none.
+func pickUntouched(rng *rand.Rand, files []string, touched map[string]bool) string {
+	if len(files) == 0 {
+		return ""
+	}
+	start := rng.Intn(len(files))
+	for k := range files {
+		if f := files[(start+k)%len(files)]; !touched[f] {
+			return f
+		}
+	}
+	return ""
+}
+
+func (s *loadTestSpec) pickSize(rng *rand.Rand) int64 {
+	var total float64
+	for _, r := range s.sizes {
+		total += r.weight
+	}
+	x := rng.Float64() * total
+	for i, r := range s.sizes {
+		// the last range takes what rounding errors leave over
+		if x < r.weight || i == len(s.sizes)-1 {
+			return r.min + rng.Int63n(r.max-r.min+1)
+		}
+		x -= r.weight
+	}
+	panic("no file sizes")
+}
+
+// loadTestContent generates the content of a file put by op.
+func loadTestContent(op loadTestOp) []byte {
+	data := make([]byte, op.size)
+	rand.New(rand.NewSource(op.seed)).Read(data)
+	return data
+}
+
+// loadTestStats collects the latencies of each kind of operation.
+type loadTestStats struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	bytes     map[string]int64
+}
+
+func (s *loadTestStats) record(op string, start time.Time, n int64) {
+	d := time.Since(start)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latencies[op] = append(s.latencies[op], d)
+	s.bytes[op] += n
+}
+
+// loadTestOperations orders the stats of a load test.
+var loadTestOperations = []string{"StartCommit", "PutFile", "DeleteFile", "FinishCommit", "GetFile"}
+
+func (s *loadTestStats) proto() []*pfs.LoadTestStats {
+	var stats []*pfs.LoadTestStats
+	for _, op := range loadTestOperations {
+		ds := s.latencies[op]
+		if len(ds) == 0 {
+			continue
+		}
+		sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+		// percentiles are nearest-rank
+		p := func(p float64) *durationpb.Duration {
+			return durationpb.New(ds[int(math.Ceil(p*float64(len(ds))))-1])
+		}
+		stats = append(stats, &pfs.LoadTestStats{
+			Operation: op,
+			Count:     int64(len(ds)),
+			Bytes:     s.bytes[op],
+			P50:       p(0.5),
+			P90:       p(0.9),
+			P99:       p(0.99),
+			Max:       durationpb.New(ds[len(ds)-1]),
+		})
+	}
+	return stats
+}
+
+// RunLoadTest runs the load test spec against branch, creating the branch
+// and its repo if necessary. It only returns an error if the spec is
+// invalid: failures of the load test itself are reported in the response,
+// along with the latencies measured up to the failure.
+func RunLoadTest(ctx context.Context, c pfs.APIClient, branch *pfs.Branch, spec string, seed int64) (*pfs.RunLoadTestResponse, error) {
+	s, err := parseLoadTestSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	stats := &loadTestStats{latencies: make(map[string][]time.Duration), bytes: make(map[string]int64)}
+	start := time.Now()
+	err = runLoadTest(ctx, c, branch, s, seed, stats)
+	duration := time.Since(start)
+	resp := &pfs.RunLoadTestResponse{
+		Spec:     spec,
+		Branch:   branch,
+		Seed:     seed,
+		Duration: durationpb.New(duration),
+		Stats:    stats.proto(),
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	if duration > 0 {
+		resp.BytesPerSecond = float64(stats.bytes["PutFile"]) / duration.Seconds()
+	}
+	return resp, nil
+}
+
+func runLoadTest(ctx context.Context, c pfs.APIClient, branch *pfs.Branch, s *loadTestSpec, seed int64, stats *loadTestStats) error {
+	if _, err := c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: branch.Repo}); err != nil && !IsRepoExistsErr(err) {
+		return errors.EnsureStack(err)
+	}
+	for _, ops := range s.plan(seed) {
+		start := time.Now()
+		commit, err := c.StartCommit(ctx, &pfs.StartCommitRequest{Branch: branch})
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		stats.record("StartCommit", start, 0)
+		if err := runConcurrently(ctx, s.Concurrency, ops, func(ctx context.Context, op loadTestOp) error {
+			return loadTestModify(ctx, c, commit, op, stats)
+		}); err != nil {
+			// the commit is finished regardless, so that the branch stays
+			// usable for later load tests
+			c.FinishCommit(ctx, &pfs.FinishCommitRequest{Commit: commit, Error: err.Error()})
+			return err
+		}
+		start = time.Now()
+		if _, err := c.FinishCommit(ctx, &pfs.FinishCommitRequest{Commit: commit}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		stats.record("FinishCommit", start, 0)
+		if !s.Validate {
+			continue
+		}
+		if err := runConcurrently(ctx, s.Concurrency, ops, func(ctx context.Context, op loadTestOp) error {
+			return loadTestValidate(ctx, c, commit, op, stats)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runConcurrently calls f with each of ops, running up to n calls at once,
+// and returns the first error. The remaining calls are canceled once one
+// fails.
+func runConcurrently(ctx context.Context, n int, ops []loadTestOp, f func(context.Context, loadTestOp) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	opc := make(chan loadTestOp)
+	errc := make(chan error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for op := range opc {
+				if err := f(ctx, op); err != nil {
+					errc <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(opc)
+		for _, op := range ops {
+			select {
+			case opc <- op:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	close(errc)
+	if err := <-errc; err != nil {
+		return err
+	}
+	return errors.EnsureStack(ctx.Err())
+}
+
+// loadTestModify makes the modification of op in commit, over its own
+// ModifyFile stream, so that its latency is that of the whole round trip.
+func loadTestModify(ctx context.Context, c pfs.APIClient, commit *pfs.Commit, op loadTestOp, stats *loadTestStats) error {
+	reqs := []*pfs.ModifyFileRequest{
+		{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}},
+		deleteFileReq(op.path),
+	}
+	if !op.delete {
+		data := loadTestContent(op)
+		for len(reqs) == 2 || len(data) > 0 {
+			n := len(data)
+			if n > grpcutil.ChunkSize {
+				n = grpcutil.ChunkSize
+			}
+			reqs = append(reqs, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+				Path:   op.path,
+				Source: &pfs.AddFile_Raw{Raw: wrapperspb.Bytes(data[:n])},
+			}}})
+			data = data[n:]
+		}
+	}
+	start := time.Now()
+	mfc, err := c.ModifyFile(ctx)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, req := range reqs {
+		if err := mfc.Send(req); err != nil {
+			// the cause is returned by CloseAndRecv
+			break
+		}
+	}
+	if _, err := mfc.CloseAndRecv(); err != nil {
+		return errors.Wrapf(err, "cannot modify %s", op.path)
+	}
+	if op.delete {
+		stats.record("DeleteFile", start, 0)
+	} else {
+		stats.record("PutFile", start, op.size)
+	}
+	return nil
+}
+
+// loadTestValidate checks that op's file has the content op put in commit,
+// or that it does not exist if op deleted it.
+func loadTestValidate(ctx context.Context, c pfs.APIClient, commit *pfs.Commit, op loadTestOp, stats *loadTestStats) error {
+	start := time.Now()
+	gfc, err := c.GetFile(ctx, &pfs.GetFileRequest{File: commit.NewFile(op.path)})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	h := sha256.New()
+	n, err := io.Copy(h, grpcutil.NewStreamingBytesReader(gfc))
+	if op.delete && err == nil {
+		return errors.Errorf("%s still exists in commit %v after it was deleted", op.path, commit)
+	}
+	if op.delete && IsFileNotFoundErr(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot read back %s", op.path)
+	}
+	stats.record("GetFile", start, n)
+	want := sha256.Sum256(loadTestContent(op))
+	if !bytes.Equal(h.Sum(nil), want[:]) {
+		return errors.Errorf("%s has the wrong content in commit %v: read %d bytes, want %d", op.path, commit, n, op.size)
+	}
+	return nil
+}
+
+// loopbackClient returns a client for the server itself, over a local
+// connection, so that load tests drive the server through its API.
+func (a *apiServer) loopbackClient() (pfs.APIClient, error) {
+	a.loopbackOnce.Do(func() {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			a.loopbackErr = errors.EnsureStack(err)
+			return
+		}
+		s := grpc.NewServer()
+		pfs.RegisterAPIServer(s, a)
+		go s.Serve(l)
+		conn, err := grpc.Dial(l.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			s.Stop()
+			a.loopbackErr = errors.EnsureStack(err)
+			return
+		}
+		a.loopback = pfs.NewAPIClient(conn)
+	})
+	return a.loopback, a.loopbackErr
+}
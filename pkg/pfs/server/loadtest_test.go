@@ -0,0 +1,118 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+const testLoadTestSpec = `commits: 3
+operations: 20
+concurrency: 3
+add: 2
+modify: 1
+delete: 1
+fileSizes:
+- min: 0B
+  max: 1KB
+  weight: 3
+- min: 1MB
+  max: 1.5MB
+  weight: 1
+validate: true
+`
+
+func TestLoadTestSpec(t *testing.T) {
+	s, err := parseLoadTestSpec(DefaultLoadTestSpec)
+	require.NoError(t, err)
+	require.Equal(t, 4, s.Concurrency)
+	require.Equal(t, int64(1000), s.sizes[0].min)
+
+	s, err = parseLoadTestSpec(testLoadTestSpec)
+	require.NoError(t, err)
+	plan := s.plan(42)
+	require.Equal(t, plan, s.plan(42))
+	require.NotEqual(t, plan, s.plan(43))
+	require.Equal(t, 3, len(plan))
+	for _, ops := range plan {
+		require.Equal(t, 20, len(ops))
+		touched := make(map[string]bool)
+		for _, op := range ops {
+			require.False(t, touched[op.path], "%s is touched twice in a commit", op.path)
+			touched[op.path] = true
+		}
+	}
+
+	for _, spec := range []string{
+		"",
+		"commits: 1\noperations: 1\nadd: 1\n",
+		"commits: 1\noperations: 1\nfileSizes: [{min: 1B, max: 2B, weight: 1}]\n",
+		"commits: 1\noperations: 1\nadd: 1\nfileSizes: [{min: 2B, max: 1B, weight: 1}]\n",
+		"commits: 1\noperations: 1\nadd: 1\nfileSizes: [{min: 1B, max: 2B, weight: 1}]\nunknown: true\n",
+	} {
+		_, err := parseLoadTestSpec(spec)
+		require.YesError(t, err, "spec %q", spec)
+	}
+}
+
+func TestRunLoadTest(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	run := func(branch *pfs.Branch) *pfs.RunLoadTestResponse {
+		resp, err := c.RunLoadTest(ctx, &pfs.RunLoadTestRequest{Spec: testLoadTestSpec, Branch: branch, Seed: 7})
+		require.NoError(t, err)
+		require.Equal(t, "", resp.Error)
+		return resp
+	}
+	first := run(nil)
+	require.Equal(t, defaultLoadTestBranch.Key(), first.Branch.Key())
+	ops := make(map[string]int64)
+	for _, stats := range first.Stats {
+		ops[stats.Operation] = stats.Count
+		require.True(t, stats.P50.AsDuration() <= stats.P90.AsDuration())
+		require.True(t, stats.P99.AsDuration() <= stats.Max.AsDuration())
+	}
+	require.Equal(t, int64(3), ops["StartCommit"])
+	require.Equal(t, int64(3), ops["FinishCommit"])
+	require.Equal(t, int64(60), ops["PutFile"]+ops["DeleteFile"])
+	require.Equal(t, ops["PutFile"], ops["GetFile"])
+	require.True(t, first.BytesPerSecond > 0)
+
+	// the same seed makes the same commits
+	second := run(newRepo("other").NewBranch("test"))
+	hashes := func(branch *pfs.Branch) map[string]string {
+		m := make(map[string]string)
+		wfc, err := c.WalkFile(ctx, &pfs.WalkFileRequest{File: branch.NewCommit("").NewFile("/")})
+		require.NoError(t, recvFileInfos(wfc, err)(func(fi *pfs.FileInfo) error {
+			m[fi.File.Path] = pfs.EncodeHash(fi.Hash)
+			return nil
+		}))
+		return m
+	}
+	require.Equal(t, hashes(first.Branch), hashes(second.Branch))
+
+	_, err := c.RunLoadTest(ctx, &pfs.RunLoadTestRequest{Spec: "commits: 0"})
+	require.YesError(t, err)
+}
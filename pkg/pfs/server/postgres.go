@@ -0,0 +1,307 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/migrations"
+)
+
+// postgresMigrations creates and evolves the pfs schema. Append to it; never
+// edit a migration that has been released.
+var postgresMigrations = []migrations.Migration{
+	migrations.Exec("create pfs schema",
+		`CREATE SCHEMA IF NOT EXISTS pfs`,
+		`CREATE TABLE pfs.repos (
+			key TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			type TEXT NOT NULL,
+			info BYTEA NOT NULL
+		)`,
+		`CREATE TABLE pfs.branches (
+			key TEXT PRIMARY KEY,
+			repo TEXT NOT NULL,
+			info BYTEA NOT NULL
+		)`,
+		`CREATE INDEX branches_repo ON pfs.branches (repo)`,
+		`CREATE TABLE pfs.commit_sets (
+			id TEXT PRIMARY KEY,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)`,
+		`CREATE TABLE pfs.commits (
+			key TEXT PRIMARY KEY,
+			repo TEXT NOT NULL,
+			commit_set_id TEXT NOT NULL REFERENCES pfs.commit_sets (id),
+			info BYTEA NOT NULL
+		)`,
+		`CREATE INDEX commits_repo ON pfs.commits (repo)`,
+		`CREATE INDEX commits_commit_set_id ON pfs.commits (commit_set_id)`,
+		`CREATE TABLE pfs.file_ops (
+			commit_key TEXT NOT NULL REFERENCES pfs.commits (key) ON DELETE CASCADE,
+			seq INT NOT NULL,
+			op JSONB NOT NULL,
+			PRIMARY KEY (commit_key, seq)
+		)`,
+	),
+}
+
+// tables maps each collection to the table it is stored in.
+var tables = map[collection]string{
+	reposCollection:    "pfs.repos",
+	branchesCollection: "pfs.branches",
+	commitsCollection:  "pfs.commits",
+}
+
+// collections lists the collections in the order their changes are written,
+// so that rows are written after the rows they refer to.
+var collections = []collection{reposCollection, branchesCollection, commitsCollection}
+
+// postgresStore is a metadataStore backed by Postgres. Each metadata item is
+// stored as a serialized proto alongside the columns it is looked up by.
+type postgresStore struct {
+	db *sql.DB
+}
+
+// newPostgresStore applies the pfs migrations to db and returns a store
+// backed by it.
+func newPostgresStore(ctx context.Context, db *sql.DB) (*postgresStore, error) {
+	if err := migrations.Apply(ctx, db, "pfs", postgresMigrations); err != nil {
+		return nil, err
+	}
+	return &postgresStore{db: db}, nil
+}
+
+func (s *postgresStore) load(ctx context.Context) (*snapshot, error) {
+	snap := newSnapshot()
+	for _, c := range collections {
+		if err := s.query(ctx, `SELECT key, info FROM `+tables[c], nil, func(rows *sql.Rows) error {
+			var key string
+			var data []byte
+			if err := rows.Scan(&key, &data); err != nil {
+				return errors.EnsureStack(err)
+			}
+			m := newMessage(c)
+			if err := proto.Unmarshal(data, m); err != nil {
+				return errors.Wrapf(err, "cannot unmarshal %s %s", c, key)
+			}
+			snap.meta[c][key] = m
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.query(ctx, `SELECT commit_key, op FROM pfs.file_ops ORDER BY commit_key, seq`, nil, func(rows *sql.Rows) error {
+		var key string
+		var data []byte
+		if err := rows.Scan(&key, &data); err != nil {
+			return errors.EnsureStack(err)
+		}
+		op, err := unmarshalFileOp(data)
+		if err != nil {
+			return errors.Wrapf(err, "cannot unmarshal file op of commit %s", key)
+		}
+		snap.diffs[key] = append(snap.diffs[key], op)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func (s *postgresStore) query(ctx context.Context, query string, args []interface{}, cb func(*sql.Rows) error) error {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := cb(rows); err != nil {
+			return err
+		}
+	}
+	return errors.EnsureStack(rows.Err())
+}
+
+func (s *postgresStore) write(ctx context.Context, tx *txn) error {
+	return dbutil.WithTx(ctx, s.db, func(sqlTx *sql.Tx) error {
+		for _, c := range collections {
+			for _, key := range sortedKeys(tx.staged[c]) {
+				m := tx.staged[c][key]
+				old, existed := tx.d.meta[c][key]
+				var err error
+				switch {
+				case m == nil && !existed:
+					continue
+				case m == nil:
+					err = deleteRow(ctx, sqlTx, c, key, old)
+				case existed:
+					err = updateRow(ctx, sqlTx, c, key, m)
+				default:
+					err = insertRow(ctx, sqlTx, c, key, m)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+		keys := make([]string, 0, len(tx.diffs))
+		for key := range tx.diffs {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if m, ok := tx.staged[commitsCollection][key]; ok && m == nil {
+				// the commit is being deleted, along with its diff
+				continue
+			}
+			if err := writeDiff(ctx, sqlTx, key, tx.diffs[key]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func sortedKeys(ms map[string]proto.Message) []string {
+	keys := make([]string, 0, len(ms))
+	for key := range ms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// columns returns the lookup columns of m, besides its key and serialized
+// form.
+func columns(m proto.Message) ([]string, []interface{}) {
+	switch info := m.(type) {
+	case *pfs.RepoInfo:
+		return []string{"name", "type"}, []interface{}{info.Repo.Name, info.Repo.Type}
+	case *pfs.BranchInfo:
+		return []string{"repo"}, []interface{}{info.Branch.Repo.Key()}
+	case *pfs.CommitInfo:
+		return []string{"repo", "commit_set_id"}, []interface{}{info.Commit.Branch.Repo.Key(), info.Commit.Id}
+	}
+	return nil, nil
+}
+
+func insertRow(ctx context.Context, sqlTx *sql.Tx, c collection, key string, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if ci, ok := m.(*pfs.CommitInfo); ok {
+		if _, err := sqlTx.ExecContext(ctx, `INSERT INTO pfs.commit_sets (id) VALUES ($1) ON CONFLICT DO NOTHING`, ci.Commit.Id); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	cols, args := columns(m)
+	cols = append([]string{"key", "info"}, cols...)
+	args = append([]interface{}{key, data}, args...)
+	params := make([]string, len(cols))
+	for i := range params {
+		params[i] = fmt.Sprintf("$%d", i+1)
+	}
+	query := `INSERT INTO ` + tables[c] + ` (` + strings.Join(cols, ", ") + `) VALUES (` + strings.Join(params, ", ") + `)`
+	if _, err := sqlTx.ExecContext(ctx, query, args...); err != nil {
+		if dbutil.IsUniqueViolation(err) {
+			return dataerr.NewExists(tables[c], key)
+		}
+		return errors.EnsureStack(err)
+	}
+	return nil
+}
+
+func updateRow(ctx context.Context, sqlTx *sql.Tx, c collection, key string, m proto.Message) error {
+	data, err := proto.Marshal(m)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	res, err := sqlTx.ExecContext(ctx, `UPDATE `+tables[c]+` SET info = $2 WHERE key = $1`, key, data)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return checkRowAffected(res, c, key)
+}
+
+func deleteRow(ctx context.Context, sqlTx *sql.Tx, c collection, key string, old proto.Message) error {
+	res, err := sqlTx.ExecContext(ctx, `DELETE FROM `+tables[c]+` WHERE key = $1`, key)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if err := checkRowAffected(res, c, key); err != nil {
+		return err
+	}
+	if ci, ok := old.(*pfs.CommitInfo); ok {
+		if _, err := sqlTx.ExecContext(ctx, `DELETE FROM pfs.commit_sets WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM pfs.commits WHERE commit_set_id = $1)`, ci.Commit.Id); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	return nil
+}
+
+func checkRowAffected(res sql.Result, c collection, key string) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if n == 0 {
+		return dataerr.NewNotExist(tables[c], key)
+	}
+	return nil
+}
+
+func writeDiff(ctx context.Context, sqlTx *sql.Tx, key string, dc *diffChange) error {
+	if dc.reset {
+		if _, err := sqlTx.ExecContext(ctx, `DELETE FROM pfs.file_ops WHERE commit_key = $1`, key); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	if len(dc.ops) == 0 {
+		return nil
+	}
+	var seq int
+	if err := sqlTx.QueryRowContext(ctx, `SELECT COALESCE(MAX(seq) + 1, 0) FROM pfs.file_ops WHERE commit_key = $1`, key).Scan(&seq); err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, op := range dc.ops {
+		data, err := marshalFileOp(op)
+		if err != nil {
+			return err
+		}
+		if _, err := sqlTx.ExecContext(ctx, `INSERT INTO pfs.file_ops (commit_key, seq, op) VALUES ($1, $2, $3)`, key, seq, string(data)); err != nil {
+			return errors.EnsureStack(err)
+		}
+		seq++
+	}
+	return nil
+}
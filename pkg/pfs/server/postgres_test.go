@@ -0,0 +1,290 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/dataerr"
+	"github.com/bhojpur/data/pkg/internal/dbutil"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/sqlutil"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// newTestPostgresURL creates an empty database and returns its URL. Tests
+// that need Postgres are skipped unless DATA_TEST_POSTGRES_URL points at a
+// server the tests may create databases on.
+func newTestPostgresURL(t *testing.T) string {
+	base := os.Getenv("DATA_TEST_POSTGRES_URL")
+	if base == "" {
+		t.Skip("DATA_TEST_POSTGRES_URL is not set")
+	}
+	ctx := context.Background()
+	db, err := dbutil.NewDB(ctx, base)
+	require.NoError(t, err)
+	defer db.Close()
+	name := "test_" + uuid.NewWithoutDashes()
+	_, err = db.ExecContext(ctx, "CREATE DATABASE "+name)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db, err := dbutil.NewDB(ctx, base)
+		if err == nil {
+			db.ExecContext(ctx, "DROP DATABASE "+name+" WITH (FORCE)")
+			db.Close()
+		}
+	})
+	u, err := url.Parse(base)
+	require.NoError(t, err)
+	u.Path = "/" + name
+	return u.String()
+}
+
+func TestPostgresMetadataSurvivesRestart(t *testing.T) {
+	env := Env{StorageRoot: t.TempDir(), PostgresURL: newTestPostgresURL(t)}
+	ctx := context.Background()
+
+	apiServer, err := NewAPIServer(ctx, env)
+	require.NoError(t, err)
+	c := newTestClientWithServer(t, apiServer)
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "foo")
+	putFile(t, c, c1, "/dir/b", "bar")
+	finishCommit(t, c, c1)
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, addFileReq("/a", "baz"), deleteFileReq("/dir")))
+	_, err = c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: repo.NewBranch("staging"), Head: c1})
+	require.NoError(t, err)
+	deleted := createRepo(t, c, "deleted")
+	startCommit(t, c, deleted, "master")
+	_, err = c.DeleteRepo(ctx, &pfs.DeleteRepoRequest{Repo: deleted, Force: true})
+	require.NoError(t, err)
+
+	// a second server on the same database sees everything, including the
+	// open commit
+	apiServer, err = NewAPIServer(ctx, env)
+	require.NoError(t, err)
+	c = newTestClientWithServer(t, apiServer)
+	ri, err := c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ri.Branches))
+	_, err = c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: deleted})
+	require.True(t, IsRepoNotFoundErr(err))
+	require.Equal(t, "foo", getFile(t, c, repo.NewCommit("staging", ""), "/a"))
+	require.Equal(t, "bar", getFile(t, c, c1, "/dir/b"))
+	require.Equal(t, "foobaz", getFile(t, c, c2, "/a"))
+	require.Equal(t, []string{"/a"}, listFile(t, c, c2, "/"))
+	putFile(t, c, c2, "/c", "qux")
+	finishCommit(t, c, c2)
+	require.Equal(t, "qux", getFile(t, c, repo.NewCommit("master", ""), "/c"))
+}
+
+func TestPostgresStore(t *testing.T) {
+	pgURL := newTestPostgresURL(t)
+	ctx := context.Background()
+	db, err := dbutil.NewDB(ctx, pgURL)
+	require.NoError(t, err)
+	defer db.Close()
+	store, err := newPostgresStore(ctx, db)
+	require.NoError(t, err)
+	// migrations are idempotent
+	store, err = newPostgresStore(ctx, db)
+	require.NoError(t, err)
+
+	d := newDriver(nil, store)
+	repo := newRepo("data")
+	require.NoError(t, d.createRepo(repo, "", false))
+	commit, err := d.startCommit(repo.NewBranch("master"), nil, "")
+	require.NoError(t, err)
+
+	csi, err := store.inspectCommitSet(ctx, commit.Id)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(csi.Commits))
+	require.Equal(t, commit.Id, csi.Commits[0].Commit.Id)
+
+	// a second driver that doesn't know about the repo can't create it again
+	d2 := newDriver(nil, store)
+	err = d2.createRepo(repo, "", false)
+	require.True(t, dataerr.IsExists(err))
+	// nor can a driver that knows about a repo that has since been deleted
+	// update it
+	require.NoError(t, d2.load(ctx))
+	require.NoError(t, d.deleteRepo(repo, true))
+	err = d2.createRepo(repo, "updated", true)
+	require.True(t, dataerr.IsNotExist(err))
+
+	_, err = store.inspectCommitSet(ctx, commit.Id)
+	require.True(t, dataerr.IsNotExist(err))
+}
+
+// inspectCommitSet reads the CommitSetInfo of the commit set id straight from
+// the store, with its commits oldest first.
+func (s *postgresStore) inspectCommitSet(ctx context.Context, id string) (*pfs.CommitSetInfo, error) {
+	var exists bool
+	if err := s.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM pfs.commit_sets WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if !exists {
+		return nil, dataerr.NewNotExist("pfs.commit_sets", id)
+	}
+	csi := &pfs.CommitSetInfo{CommitSet: &pfs.CommitSet{Id: id}}
+	if err := s.query(ctx, `SELECT info FROM pfs.commits WHERE commit_set_id = $1`, []interface{}{id}, func(rows *sql.Rows) error {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return errors.EnsureStack(err)
+		}
+		ci := &pfs.CommitInfo{}
+		if err := proto.Unmarshal(data, ci); err != nil {
+			return errors.EnsureStack(err)
+		}
+		csi.Commits = append(csi.Commits, ci)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sortCommitInfos(csi.Commits)
+	for i, j := 0, len(csi.Commits)-1; i < j; i, j = i+1, j-1 {
+		csi.Commits[i], csi.Commits[j] = csi.Commits[j], csi.Commits[i]
+	}
+	return csi, nil
+}
+
+func TestEgressSQLDatabase(t *testing.T) {
+	dbURL := newTestPostgresURL(t)
+	ctx := context.Background()
+	db, err := dbutil.NewDB(ctx, dbURL)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.ExecContext(ctx, `CREATE TABLE users (id INT PRIMARY KEY, name TEXT, score REAL)`)
+	require.NoError(t, err)
+
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/users/0", "1,alice,2.5\n2,bob,\n")
+	putFile(t, c, commit, "/users/1", "3,carol,1\n")
+	finishCommit(t, c, commit)
+	egress := func(format *pfs.SQLDatabaseEgress_FileFormat) (*pfs.EgressResponse, error) {
+		return c.Egress(ctx, &pfs.EgressRequest{Commit: commit, Target: &pfs.EgressRequest_SqlDatabase{SqlDatabase: &pfs.SQLDatabaseEgress{
+			Url:        dbURL,
+			FileFormat: format,
+		}}})
+	}
+	resp, err := egress(&pfs.SQLDatabaseEgress_FileFormat{Type: pfs.SQLDatabaseEgress_FileFormat_CSV})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"users": 3}, resp.GetSqlDatabase().RowsWritten)
+
+	// a conflicting egress writes nothing
+	_, err = egress(&pfs.SQLDatabaseEgress_FileFormat{Type: pfs.SQLDatabaseEgress_FileFormat_CSV, Columns: []string{"id", "name", "score"}})
+	require.YesError(t, err)
+	var n int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users`).Scan(&n))
+	require.Equal(t, 3, n)
+
+	// query results exported as Parquet load back into an identical table
+	rows, err := db.QueryContext(ctx, `SELECT * FROM users ORDER BY id`)
+	require.NoError(t, err)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := sqlutil.NewParquetWriter(&buf, types)
+	require.NoError(t, err)
+	written, err := sqlutil.WriteRows(rows, w)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	require.NoError(t, w.Close())
+	require.Equal(t, int64(3), written)
+	_, err = db.ExecContext(ctx, `CREATE TABLE users_copy (LIKE users)`)
+	require.NoError(t, err)
+	commit = startCommit(t, c, createRepo(t, c, "copy"), "master")
+	putFile(t, c, commit, "/users_copy/0.parquet", buf.String())
+	finishCommit(t, c, commit)
+	resp, err = egress(&pfs.SQLDatabaseEgress_FileFormat{Type: pfs.SQLDatabaseEgress_FileFormat_PARQUET})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"users_copy": 3}, resp.GetSqlDatabase().RowsWritten)
+	var mismatched int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT * FROM users EXCEPT SELECT * FROM users_copy) AS d`).Scan(&mismatched))
+	require.Equal(t, 0, mismatched)
+}
+
+func TestIngestSQL(t *testing.T) {
+	dbURL := newTestPostgresURL(t)
+	ctx := context.Background()
+	db, err := dbutil.NewDB(ctx, dbURL)
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.ExecContext(ctx, `CREATE TABLE users (id INT PRIMARY KEY, name TEXT, score NUMERIC(4, 2))`)
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `INSERT INTO users VALUES (1, 'alice', 2.5), (2, 'bob', NULL), (3, 'carol', 1)`)
+	require.NoError(t, err)
+
+	// the password comes from the secret rather than the URL
+	u, err := url.Parse(dbURL)
+	require.NoError(t, err)
+	password, _ := u.User.Password()
+	u.User = url.User(u.User.Username())
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), Secrets: func(_ context.Context, name, key string) ([]byte, error) {
+		if name != "db" || key != "password" {
+			return nil, errors.Errorf("no secret %s/%s", name, key)
+		}
+		return []byte(password), nil
+	}})
+	require.NoError(t, err)
+	c := newTestClientWithServer(t, s)
+	repo := createRepo(t, c, "snapshots")
+	resp, err := c.IngestSQL(ctx, &pfs.IngestSQLRequest{Branch: repo.NewBranch("master"), Sql: &pfs.SQLIngest{
+		Url:       u.String(),
+		Secret:    &pfs.SQLDatabaseEgress_Secret{Name: "db", Key: "password"},
+		Query:     `SELECT * FROM users ORDER BY id`,
+		Delimiter: pfs.Delimiter_CSV,
+		Path:      "/users",
+	}})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.RowsRead)
+	require.Equal(t, "id,name,score\n2,bob,\n", getFile(t, c, resp.Commit, pfs.SplitFileName("/users", 1)))
+
+	// the snapshot, headers and all, egresses back into an identical table
+	_, err = db.ExecContext(ctx, `CREATE TABLE users_copy (LIKE users)`)
+	require.NoError(t, err)
+	commit := startCommit(t, c, createRepo(t, c, "copy"), "master")
+	for i := 0; i < 3; i++ {
+		putFile(t, c, commit, pfs.SplitFileName("/users_copy", i), getFile(t, c, resp.Commit, pfs.SplitFileName("/users", i)))
+	}
+	finishCommit(t, c, commit)
+	_, err = c.Egress(ctx, &pfs.EgressRequest{Commit: commit, Target: &pfs.EgressRequest_SqlDatabase{SqlDatabase: &pfs.SQLDatabaseEgress{
+		Url:        dbURL,
+		FileFormat: &pfs.SQLDatabaseEgress_FileFormat{Type: pfs.SQLDatabaseEgress_FileFormat_CSV},
+	}}})
+	require.NoError(t, err)
+	var mismatched int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM (SELECT * FROM users EXCEPT SELECT * FROM users_copy) AS d`).Scan(&mismatched))
+	require.Equal(t, 0, mismatched)
+}
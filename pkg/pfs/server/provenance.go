@@ -0,0 +1,198 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sort"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// Branches form a DAG through their provenance. A branch's DirectProvenance
+// is set by the user; its full Provenance and Subvenance are derived from the
+// DirectProvenance of every branch and are kept up to date by
+// updateProvenance. Whenever the head of a branch moves, propagate creates an
+// AUTO commit on every branch downstream of it, all in one CommitSet.
+
+// setDirectProvenance replaces the direct provenance of bi, failing if that
+// would create a cycle. bi is staged along with every other branch whose
+// provenance or subvenance changes as a result.
+func (tx *txn) setDirectProvenance(bi *pfs.BranchInfo, provenance []*pfs.Branch) error {
+	key := bi.Branch.Key()
+	for _, p := range provenance {
+		if p.Key() == key {
+			return ErrProvenanceCycle{Branch: bi.Branch, Provenance: p}
+		}
+		pbi, err := tx.getBranchInfo(p)
+		if err != nil {
+			return err
+		}
+		for _, pp := range pbi.Provenance {
+			if pp.Key() == key {
+				return ErrProvenanceCycle{Branch: bi.Branch, Provenance: p}
+			}
+		}
+	}
+	bi.DirectProvenance = provenance
+	tx.putBranchInfo(bi)
+	tx.updateProvenance()
+	return nil
+}
+
+// updateProvenance recomputes the full provenance and subvenance of every
+// branch from their direct provenance, and stages the branches that changed.
+// Provenance on branches that no longer exist is ignored.
+func (tx *txn) updateProvenance() {
+	infos := make(map[string]*pfs.BranchInfo)
+	for _, bi := range tx.listBranchInfos(nil) {
+		infos[bi.Branch.Key()] = bi
+	}
+	provenance := make(map[string]map[string]*pfs.Branch)
+	var visit func(key string) map[string]*pfs.Branch
+	visit = func(key string) map[string]*pfs.Branch {
+		if prov, ok := provenance[key]; ok {
+			return prov
+		}
+		prov := make(map[string]*pfs.Branch)
+		// guards against cycles, which setDirectProvenance rejects
+		provenance[key] = prov
+		for _, p := range infos[key].DirectProvenance {
+			pkey := p.Key()
+			if _, ok := infos[pkey]; !ok {
+				continue
+			}
+			prov[pkey] = p
+			for ppkey, pp := range visit(pkey) {
+				prov[ppkey] = pp
+			}
+		}
+		return prov
+	}
+	subvenance := make(map[string]map[string]*pfs.Branch)
+	for key, bi := range infos {
+		for pkey := range visit(key) {
+			if subvenance[pkey] == nil {
+				subvenance[pkey] = make(map[string]*pfs.Branch)
+			}
+			subvenance[pkey][key] = bi.Branch
+		}
+	}
+	for key, bi := range infos {
+		prov, subv := sortedBranches(provenance[key]), sortedBranches(subvenance[key])
+		if sameBranches(bi.Provenance, prov) && sameBranches(bi.Subvenance, subv) {
+			continue
+		}
+		bi.Provenance, bi.Subvenance = prov, subv
+		tx.putBranchInfo(bi)
+	}
+}
+
+// propagate creates an AUTO commit in the CommitSet id on every branch
+// downstream of branches. Each new commit is parented on its branch's
+// previous head and becomes the new head. Branches are processed upstream
+// first, so a commit's direct provenance always exists before it does.
+func (tx *txn) propagate(branches []*pfs.Branch, id string) error {
+	downstream := make(map[string]*pfs.BranchInfo)
+	for _, b := range branches {
+		bi, err := tx.getBranchInfo(b)
+		if err != nil {
+			return err
+		}
+		for _, sb := range bi.Subvenance {
+			if _, ok := downstream[sb.Key()]; ok {
+				continue
+			}
+			sbi, err := tx.getBranchInfo(sb)
+			if err != nil {
+				return err
+			}
+			downstream[sb.Key()] = sbi
+		}
+	}
+	sbis := make([]*pfs.BranchInfo, 0, len(downstream))
+	for _, sbi := range downstream {
+		sbis = append(sbis, sbi)
+	}
+	// a branch's provenance strictly contains the provenance of every branch
+	// upstream of it
+	sort.Slice(sbis, func(i, j int) bool {
+		if len(sbis[i].Provenance) != len(sbis[j].Provenance) {
+			return len(sbis[i].Provenance) < len(sbis[j].Provenance)
+		}
+		return sbis[i].Branch.Key() < sbis[j].Branch.Key()
+	})
+	for _, sbi := range sbis {
+		if _, ok := tx.getCommitInfoByKey(sbi.Branch.NewCommit(id).Key()); ok {
+			continue
+		}
+		tx.newAutoCommit(sbi, id)
+	}
+	return nil
+}
+
+// newAutoCommit stages an open AUTO commit in the CommitSet id as the new head
+// of bi, provenant on the branches bi is directly provenant on.
+func (tx *txn) newAutoCommit(bi *pfs.BranchInfo, id string) *pfs.CommitInfo {
+	var parent *pfs.CommitInfo
+	if bi.Head != nil {
+		parent, _ = tx.getCommitInfoByKey(bi.Head.Key())
+	}
+	ci := tx.newCommitInSet(bi.Branch, parent, id, pfs.OriginKind_AUTO, "")
+	ci.DirectProvenance = bi.DirectProvenance
+	tx.putCommitInfo(ci)
+	bi.Head = ci.Commit
+	tx.putBranchInfo(bi)
+	return ci
+}
+
+// newCommitSetID returns the ID of a new CommitSet.
+func newCommitSetID() string {
+	return uuid.NewWithoutDashes()
+}
+
+func sortedBranches(branches map[string]*pfs.Branch) []*pfs.Branch {
+	if len(branches) == 0 {
+		return nil
+	}
+	sorted := make([]*pfs.Branch, 0, len(branches))
+	for _, b := range branches {
+		sorted = append(sorted, b)
+	}
+	sortBranches(sorted)
+	return sorted
+}
+
+func sortBranches(branches []*pfs.Branch) {
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Key() < branches[j].Key() })
+}
+
+func sameBranches(a, b []*pfs.Branch) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Key() != b[i].Key() {
+			return false
+		}
+	}
+	return true
+}
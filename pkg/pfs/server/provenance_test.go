@@ -0,0 +1,241 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func createBranch(t testing.TB, c pfs.APIClient, branch *pfs.Branch, provenance ...*pfs.Branch) {
+	_, err := c.CreateBranch(context.Background(), &pfs.CreateBranchRequest{Branch: branch, Provenance: provenance})
+	require.NoError(t, err)
+}
+
+func inspectBranch(t testing.TB, c pfs.APIClient, branch *pfs.Branch) *pfs.BranchInfo {
+	bi, err := c.InspectBranch(context.Background(), &pfs.InspectBranchRequest{Branch: branch})
+	require.NoError(t, err)
+	return bi
+}
+
+func branchNames(branches []*pfs.Branch) []string {
+	names := make([]string, len(branches))
+	for i, b := range branches {
+		names[i] = b.Key()
+	}
+	return names
+}
+
+func inspectCommitSet(t testing.TB, c pfs.APIClient, id string) []*pfs.CommitInfo {
+	ics, err := c.InspectCommitSet(context.Background(), &pfs.InspectCommitSetRequest{CommitSet: &pfs.CommitSet{Id: id}})
+	require.NoError(t, err)
+	var cis []*pfs.CommitInfo
+	for {
+		ci, err := ics.Recv()
+		if err == io.EOF {
+			return cis
+		}
+		require.NoError(t, err)
+		cis = append(cis, ci)
+	}
+}
+
+func TestProvenancePropagation(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	a := createRepo(t, c, "a")
+	b := createRepo(t, c, "b")
+	createBranch(t, c, b.NewBranch("master"), a.NewBranch("master"))
+
+	// the upstream branch was created along the way
+	abi := inspectBranch(t, c, a.NewBranch("master"))
+	require.Equal(t, []string{"b@master"}, branchNames(abi.Subvenance))
+	bbi := inspectBranch(t, c, b.NewBranch("master"))
+	require.Equal(t, []string{"a@master"}, branchNames(bbi.Provenance))
+	require.Equal(t, []string{"a@master"}, branchNames(bbi.DirectProvenance))
+
+	_, err := c.StartCommit(ctx, &pfs.StartCommitRequest{Branch: b.NewBranch("master")})
+	require.True(t, IsCommitOnOutputBranchErr(err))
+
+	commit := startCommit(t, c, a, "master")
+	cis := inspectCommitSet(t, c, commit.Id)
+	require.Equal(t, 2, len(cis))
+	require.Equal(t, "a@master", cis[0].Commit.Branch.Key())
+	require.Equal(t, "b@master", cis[1].Commit.Branch.Key())
+	require.Equal(t, pfs.OriginKind_AUTO, cis[1].Origin.Kind)
+	require.Equal(t, []string{"a@master"}, branchNames(cis[1].DirectProvenance))
+	require.Equal(t, commit.Id, inspectBranch(t, c, b.NewBranch("master")).Head.Id)
+
+	// the downstream commit becomes ready once its provenance finishes
+	ready := make(chan struct{})
+	go func() {
+		_, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: b.NewCommit("master", commit.Id), Wait: pfs.CommitState_READY})
+		require.NoError(t, err)
+		close(ready)
+	}()
+	select {
+	case <-ready:
+		t.Fatal("downstream commit is ready before its provenance finished")
+	case <-time.After(50 * time.Millisecond):
+	}
+	finishCommit(t, c, commit)
+	<-ready
+}
+
+func TestProvenanceDiamond(t *testing.T) {
+	c := newTestClient(t)
+	var repos []*pfs.Repo
+	for _, name := range []string{"a", "b", "c", "d"} {
+		repos = append(repos, createRepo(t, c, name))
+	}
+	master := func(i int) *pfs.Branch { return repos[i].NewBranch("master") }
+	createBranch(t, c, master(1), master(0))
+	createBranch(t, c, master(2), master(0))
+	createBranch(t, c, master(3), master(1), master(2))
+
+	require.Equal(t, []string{"b@master", "c@master", "d@master"}, branchNames(inspectBranch(t, c, master(0)).Subvenance))
+	require.Equal(t, []string{"a@master", "b@master", "c@master"}, branchNames(inspectBranch(t, c, master(3)).Provenance))
+
+	commit := startCommit(t, c, repos[0], "master")
+	cis := inspectCommitSet(t, c, commit.Id)
+	require.Equal(t, 4, len(cis))
+	for _, ci := range cis {
+		require.Equal(t, commit.Id, ci.Commit.Id)
+	}
+
+	// moving an upstream head also propagates
+	_, err := c.CreateBranch(context.Background(), &pfs.CreateBranchRequest{Branch: master(0), Head: cis[0].ParentCommit})
+	require.NoError(t, err)
+	head := inspectBranch(t, c, master(3)).Head
+	require.NotEqual(t, commit.Id, head.Id)
+	require.Equal(t, 3, len(inspectCommitSet(t, c, head.Id)))
+}
+
+func TestProvenanceCycle(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	a := createRepo(t, c, "a")
+	b := createRepo(t, c, "b")
+	createBranch(t, c, b.NewBranch("master"), a.NewBranch("master"))
+
+	_, err := c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: a.NewBranch("master"), Provenance: []*pfs.Branch{b.NewBranch("master")}})
+	require.True(t, IsProvenanceCycleErr(err))
+	_, err = c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: a.NewBranch("x"), Provenance: []*pfs.Branch{a.NewBranch("x")}})
+	require.True(t, IsProvenanceCycleErr(err))
+	// the failed requests changed nothing
+	require.Equal(t, 0, len(inspectBranch(t, c, a.NewBranch("master")).Provenance))
+	_, err = c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: a.NewBranch("x")})
+	require.True(t, IsBranchNotFoundErr(err))
+}
+
+func TestDeleteProvenantBranch(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	a := createRepo(t, c, "a")
+	b := createRepo(t, c, "b")
+	createBranch(t, c, b.NewBranch("master"), a.NewBranch("master"))
+
+	_, err := c.DeleteBranch(ctx, &pfs.DeleteBranchRequest{Branch: a.NewBranch("master")})
+	require.YesError(t, err)
+	_, err = c.DeleteRepo(ctx, &pfs.DeleteRepoRequest{Repo: a})
+	require.YesError(t, err)
+
+	_, err = c.DeleteBranch(ctx, &pfs.DeleteBranchRequest{Branch: a.NewBranch("master"), Force: true})
+	require.NoError(t, err)
+	bbi := inspectBranch(t, c, b.NewBranch("master"))
+	require.Equal(t, 0, len(bbi.Provenance))
+	require.Equal(t, 0, len(bbi.DirectProvenance))
+
+	// changing provenance keeps subvenance in sync
+	createBranch(t, c, b.NewBranch("master"), a.NewBranch("staging"))
+	require.Equal(t, []string{"b@master"}, branchNames(inspectBranch(t, c, a.NewBranch("staging")).Subvenance))
+	createBranch(t, c, b.NewBranch("master"))
+	require.Equal(t, 0, len(inspectBranch(t, c, a.NewBranch("staging")).Subvenance))
+}
+
+func TestSquashAndDropCommitSet(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	in := createRepo(t, c, "in")
+	out := createRepo(t, c, "out")
+	createBranch(t, c, out.NewBranch("master"), in.NewBranch("master"))
+	// commit writes a file to in and out in a new CommitSet
+	commit := func(p string) *pfs.Commit {
+		commit := startCommit(t, c, in, "master")
+		putFile(t, c, commit, p, p)
+		finishCommit(t, c, commit)
+		outCommit := out.NewCommit("master", commit.Id)
+		putFile(t, c, outCommit, p, p)
+		finishCommit(t, c, outCommit)
+		return commit
+	}
+	first, second, third := commit("/a"), commit("/b"), commit("/c")
+
+	_, err := c.SquashCommitSet(ctx, &pfs.SquashCommitSetRequest{CommitSet: &pfs.CommitSet{Id: third.Id}})
+	require.True(t, IsSquashWithoutChildrenErr(err))
+	_, err = c.SquashCommitSet(ctx, &pfs.SquashCommitSetRequest{CommitSet: &pfs.CommitSet{Id: second.Id}})
+	require.NoError(t, err)
+	ics, err := c.InspectCommitSet(ctx, &pfs.InspectCommitSetRequest{CommitSet: &pfs.CommitSet{Id: second.Id}})
+	require.NoError(t, err)
+	_, err = ics.Recv()
+	require.YesError(t, err)
+	for _, repo := range []*pfs.Repo{in, out} {
+		head := repo.NewCommit("master", "")
+		require.Equal(t, []string{"/a", "/b", "/c"}, listFile(t, c, head, "/"))
+		ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: head})
+		require.NoError(t, err)
+		require.Equal(t, first.Id, ci.ParentCommit.Id)
+		parent, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: ci.ParentCommit})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(parent.ChildCommits))
+		require.Equal(t, third.Id, parent.ChildCommits[0].Id)
+	}
+
+	// dropping the first set removes its files from every later commit
+	_, err = c.DropCommitSet(ctx, &pfs.DropCommitSetRequest{CommitSet: &pfs.CommitSet{Id: first.Id}})
+	require.NoError(t, err)
+	for _, repo := range []*pfs.Repo{in, out} {
+		require.Equal(t, []string{"/b", "/c"}, listFile(t, c, repo.NewCommit("master", ""), "/"))
+		_, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: repo.NewCommit("master", first.Id)})
+		require.True(t, IsCommitNotFoundErr(err))
+	}
+
+	// a commit downstream of the head keeps it from being dropped
+	side := createRepo(t, c, "side")
+	join := createRepo(t, c, "join")
+	createBranch(t, c, join.NewBranch("master"), in.NewBranch("master"), side.NewBranch("master"))
+	finishCommit(t, c, startCommit(t, c, side, "master"))
+	_, err = c.DropCommitSet(ctx, &pfs.DropCommitSetRequest{CommitSet: &pfs.CommitSet{Id: third.Id}})
+	require.True(t, IsCommitSetDependencyErr(err))
+
+	open := startCommit(t, c, side, "master")
+	_, err = c.DropCommitSet(ctx, &pfs.DropCommitSetRequest{CommitSet: &pfs.CommitSet{Id: open.Id}})
+	require.True(t, IsCommitNotFinishedErr(err))
+	finishCommit(t, c, open)
+	fixes, errs := fsck(t, c, false)
+	require.Equal(t, 0, len(fixes))
+	require.Equal(t, 0, len(errs))
+}
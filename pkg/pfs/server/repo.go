@@ -0,0 +1,138 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+func (d *driver) createRepo(repo *pfs.Repo, description string, update bool) error {
+	if err := ValidateRepoName(repo.Name); err != nil {
+		return err
+	}
+	return d.write(func(tx *txn) error {
+		info, err := tx.getRepoInfo(repo)
+		if err == nil {
+			if !update {
+				return ErrRepoExists{Repo: repo}
+			}
+			info.Description = description
+			tx.putRepoInfo(info)
+			return nil
+		}
+		if !IsRepoNotFoundErr(err) {
+			return err
+		}
+		tx.putRepoInfo(&pfs.RepoInfo{
+			Repo:        repo,
+			Created:     timestamppb.Now(),
+			Description: description,
+		})
+		return nil
+	})
+}
+
+func (d *driver) inspectRepo(repo *pfs.Repo) (*pfs.RepoInfo, error) {
+	var info *pfs.RepoInfo
+	if err := d.read(func(tx *txn) error {
+		var err error
+		if info, err = tx.getRepoInfo(repo); err != nil {
+			return err
+		}
+		tx.addRepoSizes(info)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+func (d *driver) listRepo(repoType string, cb func(*pfs.RepoInfo) error) error {
+	var infos []*pfs.RepoInfo
+	if err := d.read(func(tx *txn) error {
+		for _, info := range tx.listRepoInfos() {
+			if repoType == "" || info.Repo.Type == repoType {
+				tx.addRepoSizes(info)
+				infos = append(infos, info)
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	for _, info := range infos {
+		if err := cb(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *driver) deleteRepo(repo *pfs.Repo, force bool) error {
+	return d.write(func(tx *txn) error {
+		return tx.deleteRepo(repo, force)
+	})
+}
+
+func (tx *txn) deleteRepo(repo *pfs.Repo, force bool) error {
+	if _, err := tx.getRepoInfo(repo); err != nil {
+		return err
+	}
+	bis := tx.listBranchInfos(repo)
+	if !force {
+		for _, bi := range bis {
+			for _, sb := range bi.Subvenance {
+				if sb.Repo.Key() != repo.Key() {
+					return errors.Errorf("branch %v of repo %v has subvenance %v in another repo; use force to delete it", bi.Branch, repo, sb)
+				}
+			}
+		}
+	}
+	for _, bi := range bis {
+		// branches of the repo may be provenant on each other
+		if err := tx.deleteBranch(bi.Branch, true); err != nil {
+			return err
+		}
+	}
+	for _, ci := range tx.listCommitInfos(repo) {
+		commit := ci.Commit
+		tx.deleteCommitInfo(commit)
+		tx.afterApply(func() { tx.d.dropFiles(commit) })
+	}
+	tx.delete(reposCollection, repo.Key())
+	key := repo.Key()
+	tx.afterApply(func() { delete(tx.d.repoSizes, key) })
+	return nil
+}
+
+func (d *driver) deleteAll() error {
+	return d.write(func(tx *txn) error {
+		for _, info := range tx.listRepoInfos() {
+			if err := tx.deleteRepo(info.Repo, true); err != nil {
+				return errors.Wrapf(err, "cannot delete repo %v", info.Repo)
+			}
+		}
+		return nil
+	})
+}
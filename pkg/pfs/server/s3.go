@@ -0,0 +1,872 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/api/v1/auth"
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+const (
+	// s3Namespace is the XML namespace of S3 responses.
+	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+	// s3TimeFormat is how S3 responses format times.
+	s3TimeFormat = "2006-01-02T15:04:05.000Z"
+	// s3MaxKeys is the most objects a listing returns at once.
+	s3MaxKeys = 1000
+)
+
+// NewS3Handler returns an S3-compatible gateway over the branches of c, with
+// path-style URLs. Each branch is a bucket named <branch>.<repo>, where the
+// repo is in its string form, so the master branch of repo images is the
+// bucket master.images. Objects are the files of the branch's head, and
+// every write makes a new commit to the branch.
+//
+// Requests may be signed with AWS Signature Version 4, using an auth token as
+// both the access key ID and the secret access key. The token is passed on to
+// PFS. If authC is set, the token is checked with WhoAmI, and unsigned
+// requests are only accepted while auth is not activated.
+func NewS3Handler(c pfs.APIClient, authC auth.APIClient) http.Handler {
+	return &s3Gateway{
+		c:       c,
+		authC:   authC,
+		locks:   make(map[string]*sync.Mutex),
+		uploads: make(map[string]*s3Upload),
+	}
+}
+
+type s3Gateway struct {
+	c     pfs.APIClient
+	authC auth.APIClient
+	// locks serialize the commits the gateway makes to each branch, since a
+	// branch can only have one open commit.
+	locksMu sync.Mutex
+	locks   map[string]*sync.Mutex
+	// uploads are the multipart uploads in progress, by ID.
+	uploadsMu sync.Mutex
+	uploads   map[string]*s3Upload
+}
+
+// s3Error is an error response of the S3 API.
+type s3Error struct {
+	status  int
+	code    string
+	message string
+}
+
+func s3ErrorOf(status int, code, message string) *s3Error {
+	return &s3Error{status: status, code: code, message: message}
+}
+
+func (e *s3Error) Error() string {
+	return e.code + ": " + e.message
+}
+
+func (g *s3Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("x-amz-request-id", uuid.NewWithoutDashes())
+	ctx, err := g.authenticate(r)
+	if err == nil {
+		checkContentMD5(r)
+		bucket, key := strings.TrimPrefix(r.URL.Path, "/"), ""
+		if i := strings.IndexByte(bucket, '/'); i >= 0 {
+			bucket, key = bucket[:i], bucket[i+1:]
+		}
+		switch {
+		case bucket == "" && r.Method == http.MethodGet:
+			err = g.listBuckets(ctx, w)
+		case bucket == "":
+			err = s3ErrorOf(http.StatusMethodNotAllowed, "MethodNotAllowed", "the method is not allowed on the service")
+		case key == "":
+			err = g.serveBucket(ctx, w, r, bucket)
+		default:
+			err = g.serveObject(ctx, w, r, bucket, key)
+		}
+	}
+	if err != nil {
+		writeS3Error(w, r, err)
+	}
+}
+
+// authenticate checks the signature of r, if it is signed, and returns the
+// context to make PFS requests for r in.
+func (g *s3Gateway) authenticate(r *http.Request) (context.Context, error) {
+	ctx := r.Context()
+	sig, serr := parseSigV4(r)
+	if serr != nil {
+		return nil, serr
+	}
+	if sig == nil {
+		if err := g.checkToken(ctx); err != nil {
+			return nil, s3ErrorOf(http.StatusForbidden, "AccessDenied", "requests must be signed while auth is activated")
+		}
+		return ctx, nil
+	}
+	// the access key is an auth token, which is also the secret key
+	if serr := sig.verify(r, sig.accessKey); serr != nil {
+		return nil, serr
+	}
+	ctx = metadata.AppendToOutgoingContext(ctx, auth.ContextTokenKey, sig.accessKey)
+	if err := g.checkToken(ctx); err != nil {
+		return nil, s3ErrorOf(http.StatusForbidden, "InvalidAccessKeyId", "the access key is not a valid auth token")
+	}
+	return ctx, nil
+}
+
+// checkToken returns an error if auth is activated and ctx has no valid auth
+// token.
+func (g *s3Gateway) checkToken(ctx context.Context) error {
+	if g.authC == nil {
+		return nil
+	}
+	_, err := g.authC.WhoAmI(ctx, &auth.WhoAmIRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		// auth is not activated
+		return nil
+	}
+	return errors.EnsureStack(err)
+}
+
+// writeS3Error writes the S3 error response for err.
+func writeS3Error(w http.ResponseWriter, r *http.Request, err error) {
+	var serr *s3Error
+	if !errors.As(err, &serr) {
+		serr = s3ErrorOf(http.StatusInternalServerError, "InternalError", status.Convert(err).Message())
+		switch {
+		case IsRepoNotFoundErr(err), IsBranchNotFoundErr(err):
+			serr.status, serr.code = http.StatusNotFound, "NoSuchBucket"
+		case IsCommitNotFoundErr(err):
+			serr.status, serr.code = http.StatusNotFound, "NoSuchVersion"
+		case IsFileNotFoundErr(err), status.Code(err) == codes.NotFound:
+			serr.status, serr.code = http.StatusNotFound, "NoSuchKey"
+		case status.Code(err) == codes.InvalidArgument:
+			serr.status, serr.code = http.StatusBadRequest, "InvalidArgument"
+		case status.Code(err) == codes.FailedPrecondition:
+			serr.status, serr.code = http.StatusConflict, "OperationAborted"
+		case status.Code(err) == codes.PermissionDenied, status.Code(err) == codes.Unauthenticated:
+			serr.status, serr.code = http.StatusForbidden, "AccessDenied"
+		}
+	}
+	if r.Method == http.MethodHead {
+		w.WriteHeader(serr.status)
+		return
+	}
+	writeXML(w, serr.status, struct {
+		XMLName   xml.Name `xml:"Error"`
+		Code      string
+		Message   string
+		Resource  string
+		RequestID string `xml:"RequestId"`
+	}{Code: serr.code, Message: serr.message, Resource: r.URL.Path, RequestID: w.Header().Get("x-amz-request-id")})
+}
+
+func writeXML(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(v)
+}
+
+// parseBucket returns the branch a bucket name refers to.
+func parseBucket(name string) (*pfs.Branch, error) {
+	i := strings.IndexByte(name, '.')
+	if i <= 0 {
+		return nil, s3ErrorOf(http.StatusBadRequest, "InvalidBucketName", "bucket names have the form <branch>.<repo>")
+	}
+	repo, err := pfs.ParseRepo(name[i+1:])
+	if err != nil {
+		return nil, s3ErrorOf(http.StatusBadRequest, "InvalidBucketName", err.Error())
+	}
+	return repo.NewBranch(name[:i]), nil
+}
+
+// bucketName returns the name of the bucket for a branch.
+func bucketName(branch *pfs.Branch) string {
+	return branch.Name + "." + branch.Repo.Key()
+}
+
+// lockBranch serializes the commits the gateway makes to a branch. It
+// returns the function that unlocks the branch again.
+func (g *s3Gateway) lockBranch(branch *pfs.Branch) func() {
+	g.locksMu.Lock()
+	l, ok := g.locks[branch.Key()]
+	if !ok {
+		l = &sync.Mutex{}
+		g.locks[branch.Key()] = l
+	}
+	g.locksMu.Unlock()
+	l.Lock()
+	return l.Unlock
+}
+
+// commit makes a commit to branch in which f modifies the files. If f fails,
+// the commit is dropped again before the error is returned.
+func (g *s3Gateway) commit(ctx context.Context, branch *pfs.Branch, f func(commit *pfs.Commit) error) (*pfs.Commit, error) {
+	defer g.lockBranch(branch)()
+	commit, err := g.c.StartCommit(ctx, &pfs.StartCommitRequest{Branch: branch})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	ferr := f(commit)
+	finish := &pfs.FinishCommitRequest{Commit: commit}
+	if ferr != nil {
+		finish.Error = ferr.Error()
+	}
+	if _, err := g.c.FinishCommit(ctx, finish); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if ferr != nil {
+		if err := g.dropCommit(ctx, commit, ferr); err != nil {
+			log.WithError(err).WithField("commit", commit).Error("cannot drop a failed S3 gateway commit")
+			return nil, s3ErrorOf(http.StatusInternalServerError, "InternalError", fmt.Sprintf("%s; the failed commit %s could not be dropped: %s", status.Convert(ferr).Message(), commit.Id, status.Convert(err).Message()))
+		}
+		return nil, ferr
+	}
+	return commit, nil
+}
+
+// dropCommit drops the CommitSet of a commit that failed. Only finished
+// commits can be dropped, so the commits StartCommit propagated to the
+// branches downstream of the commit's branch are finished with the same error
+// first. Cleanup outlives the request, so that a client hanging up does not
+// leave the failed commit as the head of the branch.
+func (g *s3Gateway) dropCommit(ctx context.Context, commit *pfs.Commit, cause error) error {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	ctx = metadata.NewOutgoingContext(context.Background(), md)
+	commitSet := &pfs.CommitSet{Id: commit.Id}
+	ics, err := g.c.InspectCommitSet(ctx, &pfs.InspectCommitSetRequest{CommitSet: commitSet})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	var open []*pfs.Commit
+	for {
+		ci, err := ics.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if ci.Finishing == nil {
+			open = append(open, ci.Commit)
+		}
+	}
+	for _, c := range open {
+		if _, err := g.c.FinishCommit(ctx, &pfs.FinishCommitRequest{Commit: c, Error: cause.Error()}); err != nil && !IsCommitFinishedErr(err) {
+			return errors.EnsureStack(err)
+		}
+	}
+	_, err = g.c.DropCommitSet(ctx, &pfs.DropCommitSetRequest{CommitSet: commitSet})
+	return errors.EnsureStack(err)
+}
+
+// modifyFiles sends reqs to a ModifyFile stream for commit.
+func (g *s3Gateway) modifyFiles(ctx context.Context, commit *pfs.Commit, reqs ...*pfs.ModifyFileRequest) error {
+	mfc, err := g.c.ModifyFile(ctx)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	reqs = append([]*pfs.ModifyFileRequest{{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}}}, reqs...)
+	for _, req := range reqs {
+		if err := mfc.Send(req); err != nil {
+			// the cause is returned by CloseAndRecv
+			break
+		}
+	}
+	_, err = mfc.CloseAndRecv()
+	return errors.EnsureStack(err)
+}
+
+func deleteFileReq(p string) *pfs.ModifyFileRequest {
+	return &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_DeleteFile{DeleteFile: &pfs.DeleteFile{Path: p}}}
+}
+
+// createFileSet uploads the content of r to a new file set, as the file at
+// p, and returns the file set's ID. Nothing is kept if reading r fails.
+func (g *s3Gateway) createFileSet(ctx context.Context, p string, r io.Reader) (string, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cfc, err := g.c.CreateFileSet(ctx)
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	buf := make([]byte, grpcutil.ChunkSize)
+	// send at least one request, so that empty files are created
+	for sent := false; ; sent = true {
+		n, err := io.ReadFull(r, buf)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return "", err
+		}
+		if n == 0 && sent {
+			break
+		}
+		if err := cfc.Send(&pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+			Path:   p,
+			Source: &pfs.AddFile_Raw{Raw: wrapperspb.Bytes(append([]byte(nil), buf[:n]...))},
+		}}}); err != nil {
+			break
+		}
+		if n < len(buf) {
+			break
+		}
+	}
+	resp, err := cfc.CloseAndRecv()
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return resp.FileSetId, nil
+}
+
+// commitFileSet makes a commit to branch that replaces the file at p with
+// the file set id, and returns the file's info in the commit.
+func (g *s3Gateway) commitFileSet(ctx context.Context, branch *pfs.Branch, p, id string) (*pfs.FileInfo, error) {
+	commit, err := g.commit(ctx, branch, func(commit *pfs.Commit) error {
+		if err := g.modifyFiles(ctx, commit, deleteFileReq(p)); err != nil {
+			return err
+		}
+		_, err := g.c.AddFileSet(ctx, &pfs.AddFileSetRequest{Commit: commit, FileSetId: id})
+		return errors.EnsureStack(err)
+	})
+	if err != nil {
+		return nil, err
+	}
+	fi, err := g.c.InspectFile(ctx, &pfs.InspectFileRequest{File: commit.NewFile(p)})
+	return fi, errors.EnsureStack(err)
+}
+
+type s3Bucket struct {
+	Name         string
+	CreationDate string
+}
+
+func (g *s3Gateway) listBuckets(ctx context.Context, w http.ResponseWriter) error {
+	lrc, err := g.c.ListRepo(ctx, &pfs.ListRepoRequest{})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	var buckets []s3Bucket
+	for {
+		ri, err := lrc.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, b := range ri.Branches {
+			buckets = append(buckets, s3Bucket{Name: bucketName(b), CreationDate: s3Time(ri.Created.AsTime())})
+		}
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].Name < buckets[j].Name })
+	writeXML(w, http.StatusOK, struct {
+		XMLName xml.Name   `xml:"ListAllMyBucketsResult"`
+		Xmlns   string     `xml:"xmlns,attr"`
+		Owner   s3Owner    `xml:"Owner"`
+		Buckets []s3Bucket `xml:"Buckets>Bucket"`
+	}{Xmlns: s3Namespace, Owner: s3DefaultOwner, Buckets: buckets})
+	return nil
+}
+
+type s3Owner struct {
+	ID          string
+	DisplayName string
+}
+
+var s3DefaultOwner = s3Owner{ID: "data", DisplayName: "data"}
+
+func (g *s3Gateway) serveBucket(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket string) error {
+	branch, err := parseBucket(bucket)
+	if err != nil {
+		return err
+	}
+	q := r.URL.Query()
+	switch r.Method {
+	case http.MethodHead:
+		_, err := g.c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch})
+		return errors.EnsureStack(err)
+	case http.MethodGet:
+		switch {
+		case q.Has("location"):
+			if _, err := g.c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch}); err != nil {
+				return errors.EnsureStack(err)
+			}
+			writeXML(w, http.StatusOK, struct {
+				XMLName xml.Name `xml:"LocationConstraint"`
+				Xmlns   string   `xml:"xmlns,attr"`
+			}{Xmlns: s3Namespace})
+			return nil
+		case q.Has("versioning"):
+			// every version of an object is kept, as a commit
+			writeXML(w, http.StatusOK, struct {
+				XMLName xml.Name `xml:"VersioningConfiguration"`
+				Xmlns   string   `xml:"xmlns,attr"`
+				Status  string
+			}{Xmlns: s3Namespace, Status: "Enabled"})
+			return nil
+		case q.Has("uploads"):
+			return g.listMultipartUploads(w, r, bucket)
+		}
+		return g.listObjects(ctx, w, r, branch, bucket)
+	case http.MethodPut:
+		return g.createBucket(ctx, w, branch)
+	case http.MethodDelete:
+		if _, err := g.c.DeleteBranch(ctx, &pfs.DeleteBranchRequest{Branch: branch}); err != nil {
+			return errors.EnsureStack(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case http.MethodPost:
+		if q.Has("delete") {
+			return g.deleteObjects(ctx, w, r, branch)
+		}
+	}
+	return s3ErrorOf(http.StatusMethodNotAllowed, "MethodNotAllowed", "the method is not allowed on buckets")
+}
+
+// createBucket creates the branch of a bucket, and its repo if necessary.
+func (g *s3Gateway) createBucket(ctx context.Context, w http.ResponseWriter, branch *pfs.Branch) error {
+	if _, err := g.c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: branch.Repo}); err != nil && !IsRepoExistsErr(err) {
+		return errors.EnsureStack(err)
+	}
+	if _, err := g.c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch}); err == nil {
+		return s3ErrorOf(http.StatusConflict, "BucketAlreadyOwnedByYou", "the bucket already exists")
+	} else if !IsBranchNotFoundErr(err) {
+		return errors.EnsureStack(err)
+	}
+	if _, err := g.c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: branch}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	w.Header().Set("Location", "/"+bucketName(branch))
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+type s3Object struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+	Owner        *s3Owner `xml:",omitempty"`
+}
+
+type s3Prefix struct {
+	Prefix string
+}
+
+// s3Entry is an object or a common prefix in a listing.
+type s3Entry struct {
+	key    string
+	object *pfs.FileInfo
+}
+
+// listObjects implements both versions of ListObjects. Keys are listed in
+// order, and paged through by the last key of the previous page.
+func (g *s3Gateway) listObjects(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch, bucket string) error {
+	q := r.URL.Query()
+	prefix, delimiter := q.Get("prefix"), q.Get("delimiter")
+	maxKeys := s3MaxKeys
+	if s := q.Get("max-keys"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "invalid max-keys "+strconv.Quote(s))
+		}
+		if n < maxKeys {
+			maxKeys = n
+		}
+	}
+	v2 := q.Get("list-type") == "2"
+	after := q.Get("marker")
+	if v2 {
+		after = q.Get("start-after")
+		if token := q.Get("continuation-token"); token != "" {
+			key, err := base64.RawURLEncoding.DecodeString(token)
+			if err != nil {
+				return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "invalid continuation-token")
+			}
+			after = string(key)
+		}
+	}
+
+	entries, err := g.listEntries(ctx, branch, prefix, delimiter)
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(entries), func(i int) bool { return entries[i].key > after })
+	entries = entries[i:]
+	truncated := len(entries) > maxKeys
+	if truncated {
+		entries = entries[:maxKeys]
+	}
+	var objects []s3Object
+	var prefixes []s3Prefix
+	for _, e := range entries {
+		if e.object == nil {
+			prefixes = append(prefixes, s3Prefix{Prefix: e.key})
+			continue
+		}
+		o := s3Object{
+			Key:          e.key,
+			LastModified: s3Time(e.object.Committed.AsTime()),
+			ETag:         `"` + pfs.EncodeHash(e.object.Hash) + `"`,
+			Size:         e.object.SizeBytes,
+			StorageClass: "STANDARD",
+		}
+		if !v2 || q.Get("fetch-owner") == "true" {
+			o.Owner = &s3DefaultOwner
+		}
+		objects = append(objects, o)
+	}
+	var last string
+	if truncated {
+		last = entries[len(entries)-1].key
+	}
+	if v2 {
+		var next string
+		if truncated {
+			next = base64.RawURLEncoding.EncodeToString([]byte(last))
+		}
+		writeXML(w, http.StatusOK, struct {
+			XMLName               xml.Name `xml:"ListBucketResult"`
+			Xmlns                 string   `xml:"xmlns,attr"`
+			Name                  string
+			Prefix                string
+			Delimiter             string `xml:",omitempty"`
+			StartAfter            string `xml:",omitempty"`
+			ContinuationToken     string `xml:",omitempty"`
+			NextContinuationToken string `xml:",omitempty"`
+			KeyCount              int
+			MaxKeys               int
+			IsTruncated           bool
+			Contents              []s3Object
+			CommonPrefixes        []s3Prefix
+		}{xml.Name{}, s3Namespace, bucket, prefix, delimiter, q.Get("start-after"), q.Get("continuation-token"), next, len(entries), maxKeys, truncated, objects, prefixes})
+		return nil
+	}
+	writeXML(w, http.StatusOK, struct {
+		XMLName        xml.Name `xml:"ListBucketResult"`
+		Xmlns          string   `xml:"xmlns,attr"`
+		Name           string
+		Prefix         string
+		Marker         string
+		NextMarker     string `xml:",omitempty"`
+		Delimiter      string `xml:",omitempty"`
+		MaxKeys        int
+		IsTruncated    bool
+		Contents       []s3Object
+		CommonPrefixes []s3Prefix
+	}{xml.Name{}, s3Namespace, bucket, prefix, q.Get("marker"), last, delimiter, maxKeys, truncated, objects, prefixes})
+	return nil
+}
+
+// listEntries returns the objects of the head of branch whose keys start
+// with prefix, in order, rolling keys that contain delimiter after the
+// prefix up into common prefixes.
+func (g *s3Gateway) listEntries(ctx context.Context, branch *pfs.Branch, prefix, delimiter string) ([]s3Entry, error) {
+	bi, err := g.c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch})
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if bi.Head == nil {
+		return nil, nil
+	}
+	// only the deepest directory that can hold matching keys is listed
+	dir := path.Dir("/" + prefix)
+	if strings.HasSuffix(prefix, "/") {
+		dir = path.Clean("/" + prefix)
+	}
+	var infos []*pfs.FileInfo
+	collect := func(fi *pfs.FileInfo) error {
+		infos = append(infos, fi)
+		return nil
+	}
+	file := bi.Head.NewFile(dir)
+	if delimiter == "/" {
+		// keys beneath subdirectories are all rolled up, so the directory's
+		// children are enough
+		err = recvFileInfos(g.c.ListFile(ctx, &pfs.ListFileRequest{File: file}))(collect)
+	} else {
+		err = recvFileInfos(g.c.WalkFile(ctx, &pfs.WalkFileRequest{File: file}))(collect)
+	}
+	if err != nil {
+		if IsFileNotFoundErr(err) || IsNotADirectoryErr(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	seen := make(map[string]bool)
+	var entries []s3Entry
+	for _, fi := range infos {
+		key := strings.TrimPrefix(fi.File.Path, "/")
+		if fi.FileType == pfs.FileType_DIR {
+			if delimiter != "/" {
+				continue
+			}
+			key += "/"
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if delimiter != "" {
+			if i := strings.Index(key[len(prefix):], delimiter); i >= 0 {
+				key = key[:len(prefix)+i+len(delimiter)]
+				if !seen[key] {
+					seen[key] = true
+					entries = append(entries, s3Entry{key: key})
+				}
+				continue
+			}
+		}
+		entries = append(entries, s3Entry{key: key, object: fi})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	return entries, nil
+}
+
+// fileInfoClient is a stream of FileInfos, such as a ListFile or WalkFile
+// stream.
+type fileInfoClient interface {
+	Recv() (*pfs.FileInfo, error)
+}
+
+// recvFileInfos returns a function that calls cb with every FileInfo of the
+// stream that f opened.
+func recvFileInfos(c fileInfoClient, err error) func(cb func(*pfs.FileInfo) error) error {
+	return func(cb func(*pfs.FileInfo) error) error {
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		for {
+			fi, err := c.Recv()
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			if err := cb(fi); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// deleteObjects deletes many objects of a bucket in a single commit.
+func (g *s3Gateway) deleteObjects(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch) error {
+	var req struct {
+		Quiet   bool
+		Objects []struct {
+			Key string
+		} `xml:"Object"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		var serr *s3Error
+		if errors.As(err, &serr) {
+			return serr
+		}
+		return s3ErrorOf(http.StatusBadRequest, "MalformedXML", err.Error())
+	}
+	type deleted struct {
+		Key string
+	}
+	var result []deleted
+	var reqs []*pfs.ModifyFileRequest
+	for _, o := range req.Objects {
+		reqs = append(reqs, deleteFileReq("/"+o.Key))
+		if !req.Quiet {
+			result = append(result, deleted{Key: o.Key})
+		}
+	}
+	if _, err := g.commit(ctx, branch, func(commit *pfs.Commit) error {
+		return g.modifyFiles(ctx, commit, reqs...)
+	}); err != nil {
+		return err
+	}
+	writeXML(w, http.StatusOK, struct {
+		XMLName xml.Name `xml:"DeleteResult"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Deleted []deleted
+	}{Xmlns: s3Namespace, Deleted: result})
+	return nil
+}
+
+func (g *s3Gateway) serveObject(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	branch, err := parseBucket(bucket)
+	if err != nil {
+		return err
+	}
+	q := r.URL.Query()
+	p := "/" + key
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		if q.Has("uploadId") && r.Method == http.MethodGet {
+			return g.listParts(w, r, bucket, key)
+		}
+		return g.getObject(ctx, w, r, branch, p)
+	case http.MethodPut:
+		switch {
+		case q.Has("uploadId"):
+			return g.uploadPart(ctx, w, r, bucket, key)
+		case r.Header.Get("x-amz-copy-source") != "":
+			return g.copyObject(ctx, w, r, branch, p)
+		}
+		return g.putObject(ctx, w, r, branch, p)
+	case http.MethodDelete:
+		if q.Has("uploadId") {
+			return g.abortMultipartUpload(w, r, bucket, key)
+		}
+		if _, err := g.commit(ctx, branch, func(commit *pfs.Commit) error {
+			return g.modifyFiles(ctx, commit, deleteFileReq(p))
+		}); err != nil {
+			return err
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	case http.MethodPost:
+		switch {
+		case q.Has("uploads"):
+			return g.createMultipartUpload(ctx, w, branch, bucket, key)
+		case q.Has("uploadId"):
+			return g.completeMultipartUpload(ctx, w, r, branch, bucket, key)
+		}
+	}
+	return s3ErrorOf(http.StatusMethodNotAllowed, "MethodNotAllowed", "the method is not allowed on objects")
+}
+
+// getObject serves the content of an object, from the commit named by the
+// versionId parameter if it is set. Objects support ranged and conditional
+// requests as in the HTTP gateway.
+func (g *s3Gateway) getObject(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch, p string) error {
+	commit := branch.NewCommit(r.URL.Query().Get("versionId"))
+	fi, err := g.c.InspectFile(ctx, &pfs.InspectFileRequest{File: commit.NewFile(p)})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if fi.FileType != pfs.FileType_FILE {
+		return s3ErrorOf(http.StatusNotFound, "NoSuchKey", "the key is a directory")
+	}
+	w.Header().Set("ETag", `"`+pfs.EncodeHash(fi.Hash)+`"`)
+	w.Header().Set("x-amz-version-id", fi.File.Commit.Id)
+	fr := &fileReader{ctx: ctx, c: g.c, file: fi.File, size: fi.SizeBytes}
+	defer fr.Close()
+	http.ServeContent(w, r, path.Base(p), fi.Committed.AsTime(), fr)
+	return nil
+}
+
+// putObject replaces an object. Keys that end in a slash name directories,
+// which exist implicitly, so writing them empty does nothing.
+func (g *s3Gateway) putObject(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch, p string) error {
+	if strings.HasSuffix(p, "/") {
+		if r.ContentLength > 0 {
+			return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "keys that end in a slash cannot have content")
+		}
+		w.WriteHeader(http.StatusOK)
+		return nil
+	}
+	id, err := g.createFileSet(ctx, p, r.Body)
+	if err != nil {
+		return err
+	}
+	fi, err := g.commitFileSet(ctx, branch, p, id)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("ETag", `"`+pfs.EncodeHash(fi.Hash)+`"`)
+	w.Header().Set("x-amz-version-id", fi.File.Commit.Id)
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// copyObject replaces an object with a copy of the object named by the
+// x-amz-copy-source header, /<bucket>/<key>, optionally followed by
+// ?versionId=<commit>.
+func (g *s3Gateway) copyObject(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch, p string) error {
+	source, version := r.Header.Get("x-amz-copy-source"), ""
+	if i := strings.Index(source, "?versionId="); i >= 0 {
+		source, version = source[:i], source[i+len("?versionId="):]
+	}
+	source, err := url.PathUnescape(strings.TrimPrefix(source, "/"))
+	if err != nil {
+		return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "invalid x-amz-copy-source")
+	}
+	parts := strings.SplitN(source, "/", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "x-amz-copy-source must be <bucket>/<key>")
+	}
+	srcBranch, err := parseBucket(parts[0])
+	if err != nil {
+		return err
+	}
+	// the source is copied from the commit it was found in, even if its
+	// branch moves in the meantime
+	src, err := g.c.InspectFile(ctx, &pfs.InspectFileRequest{File: srcBranch.NewCommit(version).NewFile("/" + parts[1])})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if src.FileType != pfs.FileType_FILE {
+		return s3ErrorOf(http.StatusNotFound, "NoSuchKey", "the copy source is a directory")
+	}
+	commit, err := g.commit(ctx, branch, func(commit *pfs.Commit) error {
+		return g.modifyFiles(ctx, commit, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_CopyFile{CopyFile: &pfs.CopyFile{Dst: p, Src: src.File}}})
+	})
+	if err != nil {
+		return err
+	}
+	fi, err := g.c.InspectFile(ctx, &pfs.InspectFileRequest{File: commit.NewFile(p)})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	w.Header().Set("x-amz-version-id", fi.File.Commit.Id)
+	writeXML(w, http.StatusOK, struct {
+		XMLName      xml.Name `xml:"CopyObjectResult"`
+		Xmlns        string   `xml:"xmlns,attr"`
+		LastModified string
+		ETag         string
+	}{xml.Name{}, s3Namespace, s3Time(fi.Committed.AsTime()), `"` + pfs.EncodeHash(fi.Hash) + `"`})
+	return nil
+}
+
+// s3Time is the time S3 responses report for t.
+func s3Time(t time.Time) string {
+	return t.UTC().Format(s3TimeFormat)
+}
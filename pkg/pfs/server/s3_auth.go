@@ -0,0 +1,344 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The SigV4 constants of the S3 gateway, as defined by AWS.
+const (
+	sigV4Algorithm      = "AWS4-HMAC-SHA256"
+	sigV4ChunkAlgorithm = "AWS4-HMAC-SHA256-PAYLOAD"
+	sigV4TimeFormat     = "20060102T150405Z"
+	sigV4Service        = "s3"
+	sigV4Terminator     = "aws4_request"
+	unsignedPayload     = "UNSIGNED-PAYLOAD"
+	streamingPayload    = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	// sigV4MaxSkew is how far the time a request was signed may be from now.
+	sigV4MaxSkew = 15 * time.Minute
+	// sigV4MaxChunkSize bounds the chunks of a streaming upload that are
+	// buffered to check their signatures.
+	sigV4MaxChunkSize = 16 * 1024 * 1024
+)
+
+// emptySHA256 is the hex SHA-256 of no data.
+var emptySHA256 = hexSHA256(nil)
+
+// sigV4Request is the signature of a request, from either its Authorization
+// header or the query string of a presigned URL.
+type sigV4Request struct {
+	accessKey     string
+	date          time.Time
+	scope         string
+	signedHeaders []string
+	signature     string
+	presigned     bool
+}
+
+// parseSigV4 returns the signature of r, or nil if r is not signed.
+func parseSigV4(r *http.Request) (*sigV4Request, *s3Error) {
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		return parsePresignedSigV4(r)
+	}
+	authz := r.Header.Get("Authorization")
+	if authz == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(authz, sigV4Algorithm+" ") {
+		return nil, s3ErrorOf(http.StatusBadRequest, "InvalidRequest", "only AWS Signature Version 4 is supported")
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(strings.TrimPrefix(authz, sigV4Algorithm+" "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	date, err := time.Parse(sigV4TimeFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, s3ErrorOf(http.StatusForbidden, "AccessDenied", "the X-Amz-Date header is missing or invalid")
+	}
+	if d := time.Since(date); d > sigV4MaxSkew || d < -sigV4MaxSkew {
+		return nil, s3ErrorOf(http.StatusForbidden, "RequestTimeTooSkewed", "the difference between the request time and the server's time is too large")
+	}
+	return newSigV4Request(fields["Credential"], fields["SignedHeaders"], fields["Signature"], date, false)
+}
+
+func parsePresignedSigV4(r *http.Request) (*sigV4Request, *s3Error) {
+	q := r.URL.Query()
+	if q.Get("X-Amz-Algorithm") != sigV4Algorithm {
+		return nil, s3ErrorOf(http.StatusBadRequest, "InvalidRequest", "only AWS Signature Version 4 is supported")
+	}
+	date, err := time.Parse(sigV4TimeFormat, q.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, s3ErrorOf(http.StatusForbidden, "AccessDenied", "X-Amz-Date is missing or invalid")
+	}
+	expires, err := strconv.Atoi(q.Get("X-Amz-Expires"))
+	if err != nil || expires < 0 {
+		return nil, s3ErrorOf(http.StatusForbidden, "AccessDenied", "X-Amz-Expires is missing or invalid")
+	}
+	if time.Now().After(date.Add(time.Duration(expires) * time.Second)) {
+		return nil, s3ErrorOf(http.StatusForbidden, "AccessDenied", "request has expired")
+	}
+	return newSigV4Request(q.Get("X-Amz-Credential"), q.Get("X-Amz-SignedHeaders"), q.Get("X-Amz-Signature"), date, true)
+}
+
+func newSigV4Request(credential, signedHeaders, signature string, date time.Time, presigned bool) (*sigV4Request, *s3Error) {
+	// the credential is <access key>/<date>/<region>/s3/aws4_request
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[1] != date.Format("20060102") || parts[3] != sigV4Service || parts[4] != sigV4Terminator {
+		return nil, s3ErrorOf(http.StatusForbidden, "AuthorizationHeaderMalformed", "invalid credential "+strconv.Quote(credential))
+	}
+	if signedHeaders == "" || signature == "" {
+		return nil, s3ErrorOf(http.StatusForbidden, "AuthorizationHeaderMalformed", "the signature or its signed headers are missing")
+	}
+	return &sigV4Request{
+		accessKey:     parts[0],
+		date:          date,
+		scope:         strings.Join(parts[1:], "/"),
+		signedHeaders: strings.Split(signedHeaders, ";"),
+		signature:     signature,
+		presigned:     presigned,
+	}, nil
+}
+
+// signingKey derives the key requests of s are signed with from secret.
+func (s *sigV4Request) signingKey(secret string) []byte {
+	parts := strings.Split(s.scope, "/")
+	key := []byte("AWS4" + secret)
+	for _, p := range parts {
+		key = hmacSHA256(key, p)
+	}
+	return key
+}
+
+// sign returns the signature of a string to sign with the given final
+// lines, using the key derived from secret.
+func (s *sigV4Request) sign(key []byte, algorithm, rest string) string {
+	stringToSign := algorithm + "\n" + s.date.Format(sigV4TimeFormat) + "\n" + s.scope + "\n" + rest
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// verify checks that r was signed with secret and, unless r is presigned,
+// replaces its body with one that fails at the end of the body if the
+// content does not match what was signed.
+func (s *sigV4Request) verify(r *http.Request, secret string) *s3Error {
+	payload := unsignedPayload
+	if !s.presigned {
+		payload = r.Header.Get("X-Amz-Content-Sha256")
+		if payload == "" {
+			return s3ErrorOf(http.StatusBadRequest, "InvalidRequest", "the X-Amz-Content-Sha256 header is required")
+		}
+	}
+	var headers strings.Builder
+	for _, name := range s.signedHeaders {
+		var value string
+		switch name {
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			values := r.Header.Values(name)
+			for i, v := range values {
+				values[i] = strings.Join(strings.Fields(v), " ")
+			}
+			value = strings.Join(values, ",")
+		}
+		headers.WriteString(name + ":" + value + "\n")
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		s3URIEncode(r.URL.Path, false),
+		canonicalQuery(r.URL.Query()),
+		headers.String(),
+		strings.Join(s.signedHeaders, ";"),
+		payload,
+	}, "\n")
+	key := s.signingKey(secret)
+	if !hmac.Equal([]byte(s.sign(key, sigV4Algorithm, hexSHA256([]byte(canonicalRequest)))), []byte(s.signature)) {
+		return s3ErrorOf(http.StatusForbidden, "SignatureDoesNotMatch", "the request signature does not match the signature calculated with the provided key")
+	}
+	switch payload {
+	case unsignedPayload:
+	case streamingPayload:
+		r.Body = &chunkedReader{body: r.Body, r: bufio.NewReader(r.Body), s: s, key: key, prev: s.signature}
+		if n, err := strconv.ParseInt(r.Header.Get("X-Amz-Decoded-Content-Length"), 10, 64); err == nil {
+			r.ContentLength = n
+		}
+	default:
+		r.Body = &verifyingReader{body: r.Body, h: sha256.New(), want: payload, hexDigest: true, code: "XAmzContentSHA256Mismatch"}
+	}
+	return nil
+}
+
+// checkContentMD5 replaces the body of r with one that fails at the end of
+// the body if its MD5 doesn't match the Content-MD5 header, if there is one.
+func checkContentMD5(r *http.Request) {
+	if want := r.Header.Get("Content-MD5"); want != "" {
+		r.Body = &verifyingReader{body: r.Body, h: md5.New(), want: want, code: "BadDigest"}
+	}
+}
+
+// canonicalQuery returns the canonical form of a query string, without the
+// signature of a presigned URL.
+func canonicalQuery(q url.Values) string {
+	var params []string
+	for k, vs := range q {
+		if k == "X-Amz-Signature" {
+			continue
+		}
+		for _, v := range vs {
+			params = append(params, s3URIEncode(k, true)+"="+s3URIEncode(v, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// s3URIEncode percent-encodes everything but unreserved characters and,
+// unless encodeSlash is set, slashes.
+func s3URIEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9', c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// verifyingReader fails with an S3 error at the end of body if the content
+// read does not have the wanted hash, in hex or base64.
+type verifyingReader struct {
+	body      io.ReadCloser
+	h         hash.Hash
+	want      string
+	hexDigest bool
+	code      string
+}
+
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.h.Write(p[:n])
+	if err == io.EOF {
+		got := base64.StdEncoding.EncodeToString(r.h.Sum(nil))
+		if r.hexDigest {
+			got = hex.EncodeToString(r.h.Sum(nil))
+		}
+		if got != r.want {
+			return n, s3ErrorOf(http.StatusBadRequest, r.code, "the content does not match its checksum")
+		}
+	}
+	return n, err
+}
+
+func (r *verifyingReader) Close() error { return r.body.Close() }
+
+// chunkedReader decodes the aws-chunked content of a streaming upload,
+// checking the signature of each chunk, which chains from the signature of
+// the previous one.
+type chunkedReader struct {
+	body io.Closer
+	r    *bufio.Reader
+	s    *sigV4Request
+	key  []byte
+	prev string
+	buf  []byte
+	done bool
+}
+
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// readChunk reads and checks the next chunk, which has the form
+// <hex size>;chunk-signature=<signature>\r\n<data>\r\n.
+func (r *chunkedReader) readChunk() error {
+	malformed := s3ErrorOf(http.StatusBadRequest, "IncompleteBody", "malformed aws-chunked content")
+	line, err := r.r.ReadString('\n')
+	if err != nil {
+		return malformed
+	}
+	parts := strings.SplitN(strings.TrimRight(line, "\r\n"), ";chunk-signature=", 2)
+	if len(parts) != 2 {
+		return malformed
+	}
+	size, err := strconv.ParseInt(parts[0], 16, 64)
+	if err != nil || size < 0 || size > sigV4MaxChunkSize {
+		return malformed
+	}
+	data := make([]byte, size+2)
+	if _, err := io.ReadFull(r.r, data); err != nil || !bytes.HasSuffix(data, []byte("\r\n")) {
+		return malformed
+	}
+	data = data[:size]
+	want := r.s.sign(r.key, sigV4ChunkAlgorithm, r.prev+"\n"+emptySHA256+"\n"+hexSHA256(data))
+	if !hmac.Equal([]byte(want), []byte(parts[1])) {
+		return s3ErrorOf(http.StatusForbidden, "SignatureDoesNotMatch", "a chunk signature does not match")
+	}
+	r.prev, r.buf, r.done = parts[1], data, size == 0
+	return nil
+}
+
+func (r *chunkedReader) Close() error { return r.body.Close() }
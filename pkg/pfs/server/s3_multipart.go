@@ -0,0 +1,362 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+const (
+	// s3MultipartTTL is how long the parts of a multipart upload are kept
+	// after they are uploaded, and so how long an upload can be in progress:
+	// its first part may have been uploaded as soon as it was initiated.
+	s3MultipartTTL = 24 * time.Hour
+	// s3MaxPartNumber is the highest part number of a multipart upload.
+	s3MaxPartNumber = 10000
+)
+
+// s3Upload is a multipart upload in progress. Each part is uploaded to its
+// own file set, and the file sets are composed into the object once the
+// upload completes. Uploads are kept in memory, so they do not survive a
+// restart of the gateway, and they are dropped once they are older than
+// s3MultipartTTL.
+type s3Upload struct {
+	bucket    string
+	key       string
+	initiated time.Time
+	parts     map[int]*s3Part
+}
+
+func (u *s3Upload) expired(now time.Time) bool {
+	return !now.Before(u.initiated.Add(s3MultipartTTL))
+}
+
+type s3Part struct {
+	fileSetID    string
+	etag         string
+	size         int64
+	lastModified time.Time
+}
+
+// errNoSuchUpload is returned for uploads that do not exist, have been
+// completed or aborted, or have expired.
+var errNoSuchUpload = s3ErrorOf(http.StatusNotFound, "NoSuchUpload", "the upload does not exist")
+
+// lookupUpload returns the upload named by the uploadId parameter of r,
+// which must be an upload of key. The caller must hold uploadsMu.
+func (g *s3Gateway) lookupUpload(r *http.Request, bucket, key string) (string, *s3Upload, error) {
+	g.expireUploads()
+	id := r.URL.Query().Get("uploadId")
+	u, ok := g.uploads[id]
+	if !ok || u.bucket != bucket || u.key != key {
+		return "", nil, errNoSuchUpload
+	}
+	return id, u, nil
+}
+
+// expireUploads drops the uploads whose parts may have expired. The caller
+// must hold uploadsMu.
+func (g *s3Gateway) expireUploads() {
+	now := time.Now()
+	for id, u := range g.uploads {
+		if u.expired(now) {
+			delete(g.uploads, id)
+		}
+	}
+}
+
+func (g *s3Gateway) createMultipartUpload(ctx context.Context, w http.ResponseWriter, branch *pfs.Branch, bucket, key string) error {
+	if _, err := g.c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	id := uuid.NewWithoutDashes()
+	g.uploadsMu.Lock()
+	g.expireUploads()
+	g.uploads[id] = &s3Upload{bucket: bucket, key: key, initiated: time.Now(), parts: make(map[int]*s3Part)}
+	g.uploadsMu.Unlock()
+	writeXML(w, http.StatusOK, struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Xmlns    string   `xml:"xmlns,attr"`
+		Bucket   string
+		Key      string
+		UploadID string `xml:"UploadId"`
+	}{xml.Name{}, s3Namespace, bucket, key, id})
+	return nil
+}
+
+// uploadPart stores a part of an upload, replacing any earlier part with
+// the same number.
+func (g *s3Gateway) uploadPart(ctx context.Context, w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	n, err := strconv.Atoi(r.URL.Query().Get("partNumber"))
+	if err != nil || n < 1 || n > s3MaxPartNumber {
+		return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "partNumber must be an integer between 1 and 10000")
+	}
+	g.uploadsMu.Lock()
+	_, _, err = g.lookupUpload(r, bucket, key)
+	g.uploadsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	h := md5.New()
+	cr := &countingReader{r: io.TeeReader(r.Body, h)}
+	fileSetID, err := g.createFileSet(ctx, "/"+key, cr)
+	if err != nil {
+		return err
+	}
+	if _, err := g.c.RenewFileSet(ctx, &pfs.RenewFileSetRequest{FileSetId: fileSetID, TtlSeconds: int64(s3MultipartTTL / time.Second)}); err != nil {
+		return errors.EnsureStack(err)
+	}
+	part := &s3Part{fileSetID: fileSetID, etag: `"` + hex.EncodeToString(h.Sum(nil)) + `"`, size: cr.n, lastModified: time.Now()}
+	g.uploadsMu.Lock()
+	defer g.uploadsMu.Unlock()
+	// the upload may have been completed or aborted in the meantime
+	_, u, err := g.lookupUpload(r, bucket, key)
+	if err != nil {
+		return err
+	}
+	u.parts[n] = part
+	w.Header().Set("ETag", part.etag)
+	w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+// countingReader counts the bytes read from r.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
+
+// completeMultipartUpload commits the object made of the parts listed in
+// the request, which must be in ascending order.
+func (g *s3Gateway) completeMultipartUpload(ctx context.Context, w http.ResponseWriter, r *http.Request, branch *pfs.Branch, bucket, key string) error {
+	var req struct {
+		Parts []struct {
+			PartNumber int
+			ETag       string
+		} `xml:"Part"`
+	}
+	if err := xml.NewDecoder(r.Body).Decode(&req); err != nil {
+		var serr *s3Error
+		if errors.As(err, &serr) {
+			return serr
+		}
+		return s3ErrorOf(http.StatusBadRequest, "MalformedXML", err.Error())
+	}
+	if len(req.Parts) == 0 {
+		return s3ErrorOf(http.StatusBadRequest, "MalformedXML", "the upload must have at least one part")
+	}
+	// the upload is claimed for the duration of the commit, so that it
+	// cannot be completed twice
+	g.uploadsMu.Lock()
+	id, u, err := g.lookupUpload(r, bucket, key)
+	if err == nil {
+		delete(g.uploads, id)
+	}
+	g.uploadsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	ids, err := func() ([]string, error) {
+		var ids []string
+		for i, p := range req.Parts {
+			if i > 0 && p.PartNumber <= req.Parts[i-1].PartNumber {
+				return nil, s3ErrorOf(http.StatusBadRequest, "InvalidPartOrder", "the parts must be listed in ascending order")
+			}
+			part, ok := u.parts[p.PartNumber]
+			if !ok || strings.Trim(p.ETag, `"`) != strings.Trim(part.etag, `"`) {
+				return nil, s3ErrorOf(http.StatusBadRequest, "InvalidPart", "part "+strconv.Itoa(p.PartNumber)+" was not uploaded")
+			}
+			ids = append(ids, part.fileSetID)
+		}
+		return ids, nil
+	}()
+	if err != nil {
+		g.restoreUpload(id, u)
+		return err
+	}
+	// the parts only expire if the upload has expired as well, in which case
+	// it is not restored
+	resp, err := g.c.ComposeFileSet(ctx, &pfs.ComposeFileSetRequest{FileSetIds: ids})
+	if err != nil {
+		if IsFileSetNotFoundErr(err) {
+			return errNoSuchUpload
+		}
+		g.restoreUpload(id, u)
+		return errors.EnsureStack(err)
+	}
+	fi, err := g.commitFileSet(ctx, branch, "/"+key, resp.FileSetId)
+	if err != nil {
+		if IsFileSetNotFoundErr(err) {
+			return errNoSuchUpload
+		}
+		g.restoreUpload(id, u)
+		return err
+	}
+	w.Header().Set("x-amz-version-id", fi.File.Commit.Id)
+	writeXML(w, http.StatusOK, struct {
+		XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+		Xmlns   string   `xml:"xmlns,attr"`
+		Bucket  string
+		Key     string
+		ETag    string
+	}{xml.Name{}, s3Namespace, bucket, key, `"` + pfs.EncodeHash(fi.Hash) + `"`})
+	return nil
+}
+
+// restoreUpload puts back an upload that failed to complete, so that the
+// client can retry.
+func (g *s3Gateway) restoreUpload(id string, u *s3Upload) {
+	g.uploadsMu.Lock()
+	defer g.uploadsMu.Unlock()
+	g.uploads[id] = u
+}
+
+// abortMultipartUpload forgets an upload. Its parts are garbage collected
+// once their file sets expire.
+func (g *s3Gateway) abortMultipartUpload(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	g.uploadsMu.Lock()
+	defer g.uploadsMu.Unlock()
+	id, _, err := g.lookupUpload(r, bucket, key)
+	if err != nil {
+		return err
+	}
+	delete(g.uploads, id)
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (g *s3Gateway) listParts(w http.ResponseWriter, r *http.Request, bucket, key string) error {
+	type part struct {
+		PartNumber   int
+		LastModified string
+		ETag         string
+		Size         int64
+	}
+	g.uploadsMu.Lock()
+	id, u, err := g.lookupUpload(r, bucket, key)
+	var parts []part
+	if err == nil {
+		for n, p := range u.parts {
+			parts = append(parts, part{n, s3Time(p.lastModified), p.etag, p.size})
+		}
+	}
+	g.uploadsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	marker := 0
+	if s := r.URL.Query().Get("part-number-marker"); s != "" {
+		if marker, err = strconv.Atoi(s); err != nil {
+			return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "invalid part-number-marker "+strconv.Quote(s))
+		}
+	}
+	i := sort.Search(len(parts), func(i int) bool { return parts[i].PartNumber > marker })
+	parts = parts[i:]
+	maxParts := s3MaxKeys
+	if s := r.URL.Query().Get("max-parts"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return s3ErrorOf(http.StatusBadRequest, "InvalidArgument", "invalid max-parts "+strconv.Quote(s))
+		}
+		if n < maxParts {
+			maxParts = n
+		}
+	}
+	truncated := len(parts) > maxParts
+	next := 0
+	if truncated {
+		parts = parts[:maxParts]
+		next = parts[len(parts)-1].PartNumber
+	}
+	writeXML(w, http.StatusOK, struct {
+		XMLName              xml.Name `xml:"ListPartsResult"`
+		Xmlns                string   `xml:"xmlns,attr"`
+		Bucket               string
+		Key                  string
+		UploadID             string `xml:"UploadId"`
+		Initiator            s3Owner
+		Owner                s3Owner
+		StorageClass         string
+		PartNumberMarker     int
+		NextPartNumberMarker int
+		MaxParts             int
+		IsTruncated          bool
+		Parts                []part `xml:"Part"`
+	}{xml.Name{}, s3Namespace, bucket, key, id, s3DefaultOwner, s3DefaultOwner, "STANDARD", marker, next, maxParts, truncated, parts})
+	return nil
+}
+
+func (g *s3Gateway) listMultipartUploads(w http.ResponseWriter, r *http.Request, bucket string) error {
+	type upload struct {
+		Key          string
+		UploadID     string `xml:"UploadId"`
+		Initiator    s3Owner
+		Owner        s3Owner
+		StorageClass string
+		Initiated    string
+	}
+	prefix := r.URL.Query().Get("prefix")
+	var uploads []upload
+	g.uploadsMu.Lock()
+	g.expireUploads()
+	for id, u := range g.uploads {
+		if u.bucket == bucket && strings.HasPrefix(u.key, prefix) {
+			uploads = append(uploads, upload{u.key, id, s3DefaultOwner, s3DefaultOwner, "STANDARD", s3Time(u.initiated)})
+		}
+	}
+	g.uploadsMu.Unlock()
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Key != uploads[j].Key {
+			return uploads[i].Key < uploads[j].Key
+		}
+		return uploads[i].Initiated < uploads[j].Initiated
+	})
+	writeXML(w, http.StatusOK, struct {
+		XMLName     xml.Name `xml:"ListMultipartUploadsResult"`
+		Xmlns       string   `xml:"xmlns,attr"`
+		Bucket      string
+		Prefix      string
+		MaxUploads  int
+		IsTruncated bool
+		Uploads     []upload `xml:"Upload"`
+	}{xml.Name{}, s3Namespace, bucket, prefix, s3MaxKeys, false, uploads})
+	return nil
+}
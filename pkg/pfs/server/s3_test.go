@@ -0,0 +1,346 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/bhojpur/data/pkg/api/v1/auth"
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+// newTestS3 serves an S3 gateway over c and returns a client for it that
+// signs requests with token.
+func newTestS3(t testing.TB, c pfs.APIClient, authC auth.APIClient, token string) (*minio.Client, *httptest.Server) {
+	ts := httptest.NewServer(NewS3Handler(c, authC))
+	t.Cleanup(ts.Close)
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	opts := &minio.Options{BucketLookup: minio.BucketLookupPath}
+	if token != "" {
+		opts.Creds = credentials.NewStaticV4(token, token, "")
+	}
+	mc, err := minio.New(u.Host, opts)
+	require.NoError(t, err)
+	return mc, ts
+}
+
+func getObject(t testing.TB, mc *minio.Client, bucket, key string) string {
+	obj, err := mc.GetObject(context.Background(), bucket, key, minio.GetObjectOptions{})
+	require.NoError(t, err)
+	defer obj.Close()
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestS3Objects(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	mc, _ := newTestS3(t, c, nil, "token")
+
+	require.NoError(t, mc.MakeBucket(ctx, "master.images", minio.MakeBucketOptions{}))
+	require.YesError(t, mc.MakeBucket(ctx, "master.images", minio.MakeBucketOptions{}))
+	require.NoError(t, mc.MakeBucket(ctx, "dev.images", minio.MakeBucketOptions{}))
+	buckets, err := mc.ListBuckets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(buckets))
+	require.Equal(t, "dev.images", buckets[0].Name)
+	require.Equal(t, "master.images", buckets[1].Name)
+	ok, err := mc.BucketExists(ctx, "master.missing")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for key, data := range map[string]string{"a": "foo", "dir/b": "bar", "dir/sub/c": "baz", "e": ""} {
+		_, err := mc.PutObject(ctx, "master.images", key, strings.NewReader(data), int64(len(data)), minio.PutObjectOptions{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, "bar", getObject(t, mc, "master.images", "dir/b"))
+	require.Equal(t, "", getObject(t, mc, "master.images", "e"))
+	// every write is a commit to the branch
+	fi, err := c.InspectFile(ctx, &pfs.InspectFileRequest{File: newRepo("images").NewCommit("master", "").NewFile("/dir/sub/c")})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), fi.SizeBytes)
+	info, err := mc.StatObject(ctx, "master.images", "dir/sub/c", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, pfs.EncodeHash(fi.Hash), info.ETag)
+	require.Equal(t, int64(3), info.Size)
+	_, err = mc.StatObject(ctx, "master.images", "missing", minio.StatObjectOptions{})
+	require.Equal(t, "NoSuchKey", minio.ToErrorResponse(err).Code)
+
+	list := func(opts minio.ListObjectsOptions) []string {
+		var keys []string
+		for o := range mc.ListObjects(ctx, "master.images", opts) {
+			require.NoError(t, o.Err)
+			keys = append(keys, o.Key)
+		}
+		// the client lists common prefixes after the objects of each page
+		sort.Strings(keys)
+		return keys
+	}
+	require.Equal(t, []string{"a", "dir/", "e"}, list(minio.ListObjectsOptions{}))
+	require.Equal(t, []string{"a", "dir/b", "dir/sub/c", "e"}, list(minio.ListObjectsOptions{Recursive: true}))
+	require.Equal(t, []string{"dir/b", "dir/sub/"}, list(minio.ListObjectsOptions{Prefix: "dir/"}))
+	require.Equal(t, []string{"dir/b", "dir/sub/c"}, list(minio.ListObjectsOptions{Prefix: "dir/", Recursive: true}))
+	require.Equal(t, []string{"dir/", "e"}, list(minio.ListObjectsOptions{StartAfter: "a"}))
+	require.Equal(t, []string{"a", "dir/b", "dir/sub/c", "e"}, list(minio.ListObjectsOptions{Recursive: true, MaxKeys: 1}))
+	require.Equal(t, []string{"a", "dir/b", "dir/sub/c", "e"}, list(minio.ListObjectsOptions{Recursive: true, MaxKeys: 3, UseV1: true}))
+	var keys []string
+	for o := range mc.ListObjects(ctx, "dev.images", minio.ListObjectsOptions{}) {
+		require.NoError(t, o.Err)
+		keys = append(keys, o.Key)
+	}
+	require.Equal(t, 0, len(keys))
+
+	_, err = mc.CopyObject(ctx, minio.CopyDestOptions{Bucket: "dev.images", Object: "copy"}, minio.CopySrcOptions{Bucket: "master.images", Object: "dir/b"})
+	require.NoError(t, err)
+	require.Equal(t, "bar", getObject(t, mc, "dev.images", "copy"))
+
+	require.NoError(t, mc.RemoveObject(ctx, "master.images", "a", minio.RemoveObjectOptions{}))
+	objects := make(chan minio.ObjectInfo, 2)
+	objects <- minio.ObjectInfo{Key: "dir/b"}
+	objects <- minio.ObjectInfo{Key: "e"}
+	close(objects)
+	for err := range mc.RemoveObjects(ctx, "master.images", objects, minio.RemoveObjectsOptions{}) {
+		require.NoError(t, err.Err)
+	}
+	require.Equal(t, []string{"dir/sub/c"}, list(minio.ListObjectsOptions{Recursive: true}))
+
+	require.NoError(t, mc.RemoveBucket(ctx, "dev.images"))
+	ok, err = mc.BucketExists(ctx, "dev.images")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestS3FailedWrite(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	mc, _ := newTestS3(t, c, nil, "token")
+	in, out := createRepo(t, c, "in"), createRepo(t, c, "out")
+	require.NoError(t, mc.MakeBucket(ctx, "master.in", minio.MakeBucketOptions{}))
+	_, err := mc.PutObject(ctx, "master.in", "a", strings.NewReader("foo"), 3, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	createBranch(t, c, out.NewBranch("master"), in.NewBranch("master"))
+	inHead := inspectBranch(t, c, in.NewBranch("master")).Head
+	outHead := inspectBranch(t, c, out.NewBranch("master")).Head
+
+	// a is a file, so nothing can be written beneath it; the failed commit
+	// and the downstream commit it started are dropped
+	_, err = mc.PutObject(ctx, "master.in", "a/b", strings.NewReader("bar"), 3, minio.PutObjectOptions{})
+	require.YesError(t, err)
+	require.Equal(t, inHead.Id, inspectBranch(t, c, in.NewBranch("master")).Head.Id)
+	require.Equal(t, outHead.Id, inspectBranch(t, c, out.NewBranch("master")).Head.Id)
+	require.Equal(t, "foo", getObject(t, mc, "master.in", "a"))
+}
+
+func TestS3Versions(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	mc, _ := newTestS3(t, c, nil, "token")
+	require.NoError(t, mc.MakeBucket(ctx, "master.repo", minio.MakeBucketOptions{}))
+	first, err := mc.PutObject(ctx, "master.repo", "a", strings.NewReader("foo"), 3, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	_, err = mc.PutObject(ctx, "master.repo", "a", strings.NewReader("bar"), 3, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "bar", getObject(t, mc, "master.repo", "a"))
+	obj, err := mc.GetObject(ctx, "master.repo", "a", minio.GetObjectOptions{VersionID: first.VersionID})
+	require.NoError(t, err)
+	data, err := io.ReadAll(obj)
+	require.NoError(t, err)
+	require.Equal(t, "foo", string(data))
+}
+
+func TestS3Multipart(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	mc, ts := newTestS3(t, c, nil, "token")
+	core := &minio.Core{Client: mc}
+	require.NoError(t, mc.MakeBucket(ctx, "master.repo", minio.MakeBucketOptions{}))
+
+	id, err := core.NewMultipartUpload(ctx, "master.repo", "big", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	var parts []minio.CompletePart
+	for i, data := range []string{"first ", "second ", "third"} {
+		part, err := core.PutObjectPart(ctx, "master.repo", "big", id, i+1, strings.NewReader(data), int64(len(data)), "", "", nil)
+		require.NoError(t, err)
+		parts = append(parts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	uploads, err := core.ListMultipartUploads(ctx, "master.repo", "", "", "", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(uploads.Uploads))
+	listed, err := core.ListObjectParts(ctx, "master.repo", "big", id, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(listed.ObjectParts))
+	// nothing is committed until the upload completes
+	_, err = mc.StatObject(ctx, "master.repo", "big", minio.StatObjectOptions{})
+	require.YesError(t, err)
+
+	_, err = core.CompleteMultipartUpload(ctx, "master.repo", "big", id, []minio.CompletePart{parts[1], parts[0]}, minio.PutObjectOptions{})
+	require.Equal(t, "InvalidPartOrder", minio.ToErrorResponse(err).Code)
+	_, err = core.CompleteMultipartUpload(ctx, "master.repo", "big", id, parts, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "first second third", getObject(t, mc, "master.repo", "big"))
+	_, err = core.CompleteMultipartUpload(ctx, "master.repo", "big", id, parts, minio.PutObjectOptions{})
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+
+	id, err = core.NewMultipartUpload(ctx, "master.repo", "aborted", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	require.NoError(t, core.AbortMultipartUpload(ctx, "master.repo", "aborted", id))
+	_, err = core.PutObjectPart(ctx, "master.repo", "aborted", id, 1, strings.NewReader("x"), 1, "", "", nil)
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+
+	// uploads are dropped once their parts may have expired
+	id, err = core.NewMultipartUpload(ctx, "master.repo", "stale", minio.PutObjectOptions{})
+	require.NoError(t, err)
+	part, err := core.PutObjectPart(ctx, "master.repo", "stale", id, 1, strings.NewReader("x"), 1, "", "", nil)
+	require.NoError(t, err)
+	g := ts.Config.Handler.(*s3Gateway)
+	g.uploadsMu.Lock()
+	g.uploads[id].initiated = time.Now().Add(-s3MultipartTTL)
+	g.uploadsMu.Unlock()
+	uploads, err = core.ListMultipartUploads(ctx, "master.repo", "", "", "", "", 0)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(uploads.Uploads))
+	_, err = core.CompleteMultipartUpload(ctx, "master.repo", "stale", id, []minio.CompletePart{{PartNumber: 1, ETag: part.ETag}}, minio.PutObjectOptions{})
+	require.Equal(t, "NoSuchUpload", minio.ToErrorResponse(err).Code)
+
+	// large objects are uploaded in parts by the client
+	big := bytes.Repeat([]byte("0123456789abcdef"), 1<<20+1)
+	_, err = mc.PutObject(ctx, "master.repo", "large", bytes.NewReader(big), int64(len(big)), minio.PutObjectOptions{PartSize: 5 << 20})
+	require.NoError(t, err)
+	require.Equal(t, string(big), getObject(t, mc, "master.repo", "large"))
+}
+
+func TestS3Signatures(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	mc, ts := newTestS3(t, c, nil, "token")
+	require.NoError(t, mc.MakeBucket(ctx, "master.repo", minio.MakeBucketOptions{}))
+
+	// unknown payloads use the streaming signature
+	_, err := mc.PutObject(ctx, "master.repo", "streamed", io.LimitReader(strings.NewReader("streamed content"), 16), -1, minio.PutObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "streamed content", getObject(t, mc, "master.repo", "streamed"))
+
+	u, err := mc.PresignedGetObject(ctx, "master.repo", "streamed", time.Minute, nil)
+	require.NoError(t, err)
+	resp, err := http.Get(u.String())
+	require.NoError(t, err)
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "streamed content", string(data))
+
+	// tampering with a presigned URL breaks its signature
+	q := u.Query()
+	q.Set("X-Amz-Expires", "120")
+	u.RawQuery = q.Encode()
+	resp, err = http.Get(u.String())
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// so does signing with the wrong secret
+	u2, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	bad, err := minio.New(u2.Host, &minio.Options{BucketLookup: minio.BucketLookupPath, Creds: credentials.NewStaticV4("token", "other", "")})
+	require.NoError(t, err)
+	_, err = bad.StatObject(ctx, "master.repo", "streamed", minio.StatObjectOptions{})
+	require.YesError(t, err)
+	_, err = bad.ListBuckets(ctx)
+	require.Equal(t, "SignatureDoesNotMatch", minio.ToErrorResponse(err).Code)
+}
+
+// testAuthServer accepts a single token while activated.
+type testAuthServer struct {
+	auth.UnimplementedAPIServer
+	token string
+}
+
+func (a *testAuthServer) WhoAmI(ctx context.Context, _ *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if tokens := md.Get(auth.ContextTokenKey); len(tokens) == 0 || tokens[0] != a.token {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return &auth.WhoAmIResponse{Username: "user"}, nil
+}
+
+func newTestAuthClient(t testing.TB, a auth.APIServer) auth.APIClient {
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	auth.RegisterAPIServer(s, a)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return auth.NewAPIClient(conn)
+}
+
+func TestS3Auth(t *testing.T) {
+	ctx := context.Background()
+	c := newTestClient(t)
+	authC := newTestAuthClient(t, &testAuthServer{token: "secret-token"})
+	mc, ts := newTestS3(t, c, authC, "secret-token")
+	require.NoError(t, mc.MakeBucket(ctx, "master.repo", minio.MakeBucketOptions{}))
+
+	other, _ := newTestS3(t, c, authC, "other-token")
+	_, err := other.ListBuckets(ctx)
+	require.Equal(t, "InvalidAccessKeyId", minio.ToErrorResponse(err).Code)
+	resp, err := http.Get(ts.URL + "/")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+
+	// without auth activated, anonymous requests are allowed
+	anonymous, ts := newTestS3(t, c, newTestAuthClient(t, &auth.UnimplementedAPIServer{}), "")
+	buckets, err := anonymous.ListBuckets(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(buckets))
+	resp, err = http.Get(ts.URL + "/master.repo")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
@@ -0,0 +1,1462 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/backoff"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/grpcutil"
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/sqlutil"
+	"github.com/bhojpur/data/pkg/internal/task"
+)
+
+// newTestClient serves an in-memory PFS over a bufconn and returns a client
+// for it.
+func newTestClient(t testing.TB) pfs.APIClient {
+	apiServer, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	return newTestClientWithServer(t, apiServer)
+}
+
+func newTestClientWithServer(t testing.TB, apiServer pfs.APIServer) pfs.APIClient {
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pfs.RegisterAPIServer(s, apiServer)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pfs.NewAPIClient(conn)
+}
+
+func newRepo(name string) *pfs.Repo {
+	return &pfs.Repo{Name: name, Type: pfs.UserRepoType}
+}
+
+func createRepo(t testing.TB, c pfs.APIClient, name string) *pfs.Repo {
+	repo := newRepo(name)
+	_, err := c.CreateRepo(context.Background(), &pfs.CreateRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	return repo
+}
+
+func startCommit(t testing.TB, c pfs.APIClient, repo *pfs.Repo, branch string) *pfs.Commit {
+	commit, err := c.StartCommit(context.Background(), &pfs.StartCommitRequest{Branch: repo.NewBranch(branch)})
+	require.NoError(t, err)
+	return commit
+}
+
+func finishCommit(t testing.TB, c pfs.APIClient, commit *pfs.Commit) {
+	_, err := c.FinishCommit(context.Background(), &pfs.FinishCommitRequest{Commit: commit})
+	require.NoError(t, err)
+}
+
+func modifyFile(c pfs.APIClient, commit *pfs.Commit, reqs ...*pfs.ModifyFileRequest) error {
+	mfc, err := c.ModifyFile(context.Background())
+	if err != nil {
+		return err
+	}
+	reqs = append([]*pfs.ModifyFileRequest{{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}}}, reqs...)
+	for _, req := range reqs {
+		if err := mfc.Send(req); err != nil {
+			return err
+		}
+	}
+	_, err = mfc.CloseAndRecv()
+	return err
+}
+
+func addFileReq(path, data string) *pfs.ModifyFileRequest {
+	return &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+		Path:   path,
+		Source: &pfs.AddFile_Raw{Raw: wrapperspb.Bytes([]byte(data))},
+	}}}
+}
+
+func putFile(t testing.TB, c pfs.APIClient, commit *pfs.Commit, path, data string) {
+	require.NoError(t, modifyFile(c, commit, deleteFileReq(path), addFileReq(path, data)))
+}
+
+func getFile(t testing.TB, c pfs.APIClient, commit *pfs.Commit, path string) string {
+	gfc, err := c.GetFile(context.Background(), &pfs.GetFileRequest{File: commit.NewFile(path)})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, grpcutil.NewStreamingBytesReader(gfc))
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func listFile(t testing.TB, c pfs.APIClient, commit *pfs.Commit, path string) []string {
+	lfc, err := c.ListFile(context.Background(), &pfs.ListFileRequest{File: commit.NewFile(path)})
+	require.NoError(t, err)
+	var paths []string
+	for {
+		fi, err := lfc.Recv()
+		if err == io.EOF {
+			return paths
+		}
+		require.NoError(t, err)
+		paths = append(paths, fi.File.Path)
+	}
+}
+
+func globFile(t testing.TB, c pfs.APIClient, commit *pfs.Commit, pattern string) []string {
+	gfc, err := c.GlobFile(context.Background(), &pfs.GlobFileRequest{Commit: commit, Pattern: pattern})
+	require.NoError(t, err)
+	var paths []string
+	for {
+		fi, err := gfc.Recv()
+		if err == io.EOF {
+			return paths
+		}
+		require.NoError(t, err)
+		paths = append(paths, fi.File.Path)
+	}
+}
+
+// diffFile returns the diff as "+path", "-path" and "~path" entries for
+// added, removed and modified files respectively.
+func diffFile(t testing.TB, c pfs.APIClient, newFile, oldFile *pfs.File, shallow bool) []string {
+	dfc, err := c.DiffFile(context.Background(), &pfs.DiffFileRequest{NewFile: newFile, OldFile: oldFile, Shallow: shallow})
+	require.NoError(t, err)
+	var diffs []string
+	for {
+		resp, err := dfc.Recv()
+		if err == io.EOF {
+			return diffs
+		}
+		require.NoError(t, err)
+		switch {
+		case resp.OldFile == nil:
+			diffs = append(diffs, "+"+resp.NewFile.File.Path)
+		case resp.NewFile == nil:
+			diffs = append(diffs, "-"+resp.OldFile.File.Path)
+		default:
+			diffs = append(diffs, "~"+resp.NewFile.File.Path)
+		}
+	}
+}
+
+func listCommit(t testing.TB, c pfs.APIClient, req *pfs.ListCommitRequest) []*pfs.CommitInfo {
+	lcc, err := c.ListCommit(context.Background(), req)
+	require.NoError(t, err)
+	var cis []*pfs.CommitInfo
+	for {
+		ci, err := lcc.Recv()
+		if err == io.EOF {
+			return cis
+		}
+		require.NoError(t, err)
+		cis = append(cis, ci)
+	}
+}
+
+func TestRepoLifecycle(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+
+	_, err := c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: repo})
+	require.True(t, IsRepoExistsErr(err))
+	_, err = c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: repo, Description: "updated", Update: true})
+	require.NoError(t, err)
+	ri, err := c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	require.Equal(t, "updated", ri.Description)
+
+	_, err = c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: newRepo("bad name")})
+	require.YesError(t, err)
+
+	_, err = c.DeleteRepo(ctx, &pfs.DeleteRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	_, err = c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.True(t, IsRepoNotFoundErr(err))
+}
+
+func TestCommitParentsAndFiles(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "foo")
+	putFile(t, c, c1, "/dir/b", "bar")
+	finishCommit(t, c, c1)
+
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, addFileReq("/a", "baz"), deleteFileReq("/dir")))
+	require.Equal(t, "foobaz", getFile(t, c, c2, "/a"))
+	finishCommit(t, c, c2)
+
+	ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: c2})
+	require.NoError(t, err)
+	require.Equal(t, c1.Id, ci.ParentCommit.Id)
+	require.Equal(t, pfs.OriginKind_USER, ci.Origin.Kind)
+	require.NotNil(t, ci.Finished)
+
+	// the parent is unaffected by its child
+	require.Equal(t, "foo", getFile(t, c, c1, "/a"))
+	require.Equal(t, []string{"/a", "/dir/"}, listFile(t, c, c1, "/"))
+	require.Equal(t, []string{"/a"}, listFile(t, c, c2, "/"))
+
+	// ancestry syntax resolves through the branch
+	require.Equal(t, "foo", getFile(t, c, repo.NewCommit("master^", ""), "/a"))
+	require.Equal(t, "foobaz", getFile(t, c, repo.NewCommit("master", ""), "/a"))
+
+	_, err = c.InspectFile(ctx, &pfs.InspectFileRequest{File: c2.NewFile("/dir/b")})
+	require.True(t, IsFileNotFoundErr(err))
+
+	err = modifyFile(c, c2, addFileReq("/c", "late"))
+	require.True(t, IsCommitFinishedErr(err))
+}
+
+func TestStartCommitRequiresFinishedParent(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	startCommit(t, c, repo, "master")
+	_, err := c.StartCommit(context.Background(), &pfs.StartCommitRequest{Branch: repo.NewBranch("master")})
+	require.True(t, IsCommitNotFinishedErr(err))
+}
+
+func TestNotADirectory(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", "foo")
+	err := modifyFile(c, commit, addFileReq("/a/b", "bar"))
+	require.True(t, IsNotADirectoryErr(err))
+}
+
+func TestCopyFile(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/src/a", "foo")
+	putFile(t, c, c1, "/src/b/c", "bar")
+	finishCommit(t, c, c1)
+
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_CopyFile{CopyFile: &pfs.CopyFile{
+		Src: c1.NewFile("/src"),
+		Dst: "/dst",
+	}}}))
+	finishCommit(t, c, c2)
+	require.Equal(t, "foo", getFile(t, c, c2, "/dst/a"))
+	require.Equal(t, "bar", getFile(t, c, c2, "/dst/b/c"))
+}
+
+func TestGlobFile(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	for _, p := range []string{"/a.txt", "/b.csv", "/dir/c.txt", "/dir/sub/d.txt", "/other/e.txt"} {
+		putFile(t, c, commit, p, p)
+	}
+	finishCommit(t, c, commit)
+
+	require.Equal(t, []string{"/a.txt", "/b.csv", "/dir/", "/other/"}, globFile(t, c, commit, "/*"))
+	require.Equal(t, []string{"/a.txt"}, globFile(t, c, commit, "*.txt"))
+	require.Equal(t, []string{"/a.txt", "/dir/c.txt", "/dir/sub/d.txt", "/other/e.txt"}, globFile(t, c, commit, "/**/*.txt"))
+	require.Equal(t, []string{"/"}, globFile(t, c, commit, "/"))
+	require.Equal(t, []string{"/dir/c.txt", "/dir/sub/d.txt"}, globFile(t, c, commit, "/dir/**.txt"))
+	require.Equal(t, []string{"/a.txt", "/b.csv"}, globFile(t, c, commit, "/{a,b}.{txt,csv}"))
+	require.Equal(t, []string{"/dir/c.txt", "/other/e.txt"}, globFile(t, c, commit, "/(*)/[c-e].txt"))
+	require.Equal(t, []string{"/dir/sub/"}, globFile(t, c, commit, "/dir/s?b"))
+	require.Equal(t, 0, len(globFile(t, c, commit, "/missing/*")))
+
+	// deleted directories leave the index the walk goes through
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, deleteFileReq("/dir/sub/d.txt"), deleteFileReq("/other")))
+	finishCommit(t, c, c2)
+	require.Equal(t, []string{"/a.txt", "/b.csv", "/dir/"}, globFile(t, c, c2, "/*"))
+	require.Equal(t, []string{"/dir/c.txt"}, globFile(t, c, c2, "/dir/**"))
+
+	gfc, err := c.GlobFile(context.Background(), &pfs.GlobFileRequest{Commit: commit, Pattern: "/[a"})
+	require.NoError(t, err)
+	_, err = gfc.Recv()
+	require.YesError(t, err)
+}
+
+func TestReadTree(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	c := newTestClientWithServer(t, s)
+	commit := startCommit(t, c, createRepo(t, c, "data"), "master")
+	putFile(t, c, commit, "/a", "foo")
+	stored := func() *tree {
+		d.mu.RLock()
+		defer d.mu.RUnlock()
+		return d.files[commit.Key()].tree
+	}
+	// reads of open commits get a copy, and of finished commits the tree
+	// itself
+	_, open, err := d.getTree(commit.NewFile("/a"))
+	require.NoError(t, err)
+	require.True(t, open != stored())
+	finishCommit(t, c, commit)
+	_, finished, err := d.getTree(commit.NewFile("/a"))
+	require.NoError(t, err)
+	require.True(t, finished == stored())
+}
+
+func TestDiffFile(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "foo")
+	putFile(t, c, c1, "/dir/b", "bar")
+	putFile(t, c, c1, "/dir/c", "baz")
+	putFile(t, c, c1, "/other/d", "qux")
+	finishCommit(t, c, c1)
+	require.Equal(t, []string{"+/a", "+/dir/b", "+/dir/c", "+/other/d"}, diffFile(t, c, c1.NewFile("/"), nil, false))
+
+	c2 := startCommit(t, c, repo, "master")
+	putFile(t, c, c2, "/dir/b", "changed")
+	require.NoError(t, modifyFile(c, c2, deleteFileReq("/dir/c"), addFileReq("/dir/e", "new")))
+	finishCommit(t, c, c2)
+
+	require.Equal(t, []string{"~/dir/b", "-/dir/c", "+/dir/e"}, diffFile(t, c, c2.NewFile("/"), nil, false))
+	require.Equal(t, []string{"~/dir/"}, diffFile(t, c, c2.NewFile("/"), nil, true))
+	require.Equal(t, []string{"~/dir/b", "-/dir/c", "+/dir/e"}, diffFile(t, c, c2.NewFile("/dir"), nil, true))
+	require.Equal(t, []string{"~/dir/b"}, diffFile(t, c, c2.NewFile("/dir/b"), nil, true))
+	require.Equal(t, 0, len(diffFile(t, c, c2.NewFile("/a"), nil, false)))
+
+	// the old file may be at a different path in any commit
+	require.Equal(t, []string{"+/dir/b", "+/dir/c", "-/other/d"}, diffFile(t, c, c1.NewFile("/dir"), c1.NewFile("/other"), false))
+
+	// commits may be given by ID in place of a branch
+	newFile, err := pfs.ParseFile("data@" + c2.Id + ":/dir")
+	require.NoError(t, err)
+	oldFile, err := pfs.ParseFile("data@" + c1.Id + ":/dir")
+	require.NoError(t, err)
+	require.Equal(t, []string{"~/dir/b", "-/dir/c", "+/dir/e"}, diffFile(t, c, newFile, oldFile, false))
+	missing, err := pfs.ParseFile("data@missing:/dir")
+	require.NoError(t, err)
+	dfc, err := c.DiffFile(context.Background(), &pfs.DiffFileRequest{NewFile: missing})
+	require.NoError(t, err)
+	_, err = dfc.Recv()
+	require.True(t, IsBranchNotFoundErr(err))
+
+	dfc, err = c.DiffFile(context.Background(), &pfs.DiffFileRequest{NewFile: c2.NewFile("/missing")})
+	require.NoError(t, err)
+	_, err = dfc.Recv()
+	require.True(t, IsFileNotFoundErr(err))
+}
+
+func TestFileTAR(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+
+	big := strings.Repeat("0123456789", grpcutil.ChunkSize/4)
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range []struct{ name, data string }{{"dir/", ""}, {"dir/a", "foo"}, {"dir/sub/b", big}, {"c.txt", "bar"}, {"empty", ""}} {
+		hdr := &tar.Header{Name: f.name, Typeflag: tar.TypeReg, Mode: 0600, Size: int64(len(f.data))}
+		if strings.HasSuffix(f.name, "/") {
+			hdr.Typeflag = tar.TypeDir
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(f.data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/c.txt", "old")
+	mfc, err := c.ModifyFile(ctx)
+	require.NoError(t, err)
+	require.NoError(t, mfc.Send(&pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}}))
+	require.NoError(t, pfs.PutFileTAR(mfc, &buf, true))
+	_, err = mfc.CloseAndRecv()
+	require.NoError(t, err)
+	finishCommit(t, c, commit)
+	require.Equal(t, "foo", getFile(t, c, commit, "/dir/a"))
+	require.Equal(t, big, getFile(t, c, commit, "/dir/sub/b"))
+	require.Equal(t, "bar", getFile(t, c, commit, "/c.txt"))
+	require.Equal(t, []string{"/c.txt", "/dir/", "/empty"}, listFile(t, c, commit, "/"))
+
+	getFileTAR := func(path string) map[string]string {
+		gfc, err := c.GetFileTAR(ctx, &pfs.GetFileRequest{File: commit.NewFile(path)})
+		require.NoError(t, err)
+		ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: commit})
+		require.NoError(t, err)
+		entries := make(map[string]string)
+		tr := tar.NewReader(grpcutil.NewStreamingBytesReader(gfc))
+		for {
+			hdr, err := tr.Next()
+			if err == io.EOF {
+				return entries
+			}
+			require.NoError(t, err)
+			require.True(t, hdr.ModTime.Equal(ci.Finished.AsTime()), hdr.Name)
+			data, err := io.ReadAll(tr)
+			require.NoError(t, err)
+			if hdr.Typeflag == tar.TypeDir {
+				require.Equal(t, int64(0755), hdr.Mode)
+				data = []byte("dir")
+			} else {
+				require.Equal(t, int64(0644), hdr.Mode)
+			}
+			entries[hdr.Name] = string(data)
+		}
+	}
+	require.Equal(t, map[string]string{"dir/": "dir", "dir/a": "foo", "dir/sub/": "dir", "dir/sub/b": big}, getFileTAR("/dir"))
+	require.Equal(t, map[string]string{"c.txt": "bar", "dir/": "dir", "dir/a": "foo", "dir/sub/": "dir", "dir/sub/b": big, "empty": ""}, getFileTAR("/"))
+	require.Equal(t, map[string]string{"c.txt": "bar", "dir/a": "foo"}, getFileTAR("/{*.txt,dir/a}"))
+	require.Equal(t, map[string]string{"dir/sub/": "dir", "dir/sub/b": big}, getFileTAR("/dir/s*"))
+
+	gfc, err := c.GetFileTAR(ctx, &pfs.GetFileRequest{File: commit.NewFile("/missing")})
+	require.NoError(t, err)
+	_, err = gfc.Recv()
+	require.True(t, IsFileNotFoundErr(err))
+}
+
+func TestPutFileSplit(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/rows/stale", "old")
+
+	mfc, err := c.ModifyFile(ctx)
+	require.NoError(t, err)
+	require.NoError(t, mfc.Send(&pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}}))
+	require.NoError(t, pfs.PutFileSplit(mfc, "rows", strings.NewReader("id,name\n1,a\n2,b\n3,c\n"), pfs.Delimiter_CSV, 2, 0))
+	_, err = mfc.CloseAndRecv()
+	require.NoError(t, err)
+	finishCommit(t, c, commit)
+
+	first, second := pfs.SplitFileName("/rows", 0), pfs.SplitFileName("/rows", 1)
+	require.Equal(t, "/rows/0000000000000000", first)
+	require.Equal(t, []string{first, second}, listFile(t, c, commit, "/rows"))
+	require.Equal(t, "id,name\n1,a\n2,b\n", getFile(t, c, commit, first))
+	require.Equal(t, "id,name\n3,c\n", getFile(t, c, commit, second))
+
+	mfc, err = c.ModifyFile(ctx)
+	require.NoError(t, err)
+	require.YesError(t, pfs.PutFileSplit(mfc, "rows", strings.NewReader("x"), pfs.Delimiter_NONE, 0, 0))
+}
+
+func TestAddFileURL(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), FileURLRoot: dir})
+	require.NoError(t, err)
+	s.(*apiServer).driver.downloadBackOff = func() backoff.BackOff { return &backoff.ZeroBackOff{} }
+	c := newTestClientWithServer(t, s)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	addURL := func(path, url string, recursive bool) error {
+		return modifyFile(c, commit, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+			Path:   path,
+			Source: &pfs.AddFile_Url{Url: &pfs.AddFile_URLSource{URL: url, Recursive: recursive}},
+		}}})
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a"), []byte("foo"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "b"), []byte("bar"), 0644))
+	require.NoError(t, addURL("/imported", "file:///", true))
+	require.NoError(t, addURL("/single", "file:///sub/b", false))
+	require.YesError(t, addURL("/empty", "file:///missing", true))
+	require.YesError(t, addURL("/escape", "file:///../a", false))
+
+	failures := 1
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/flaky" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("baz"))
+	}))
+	defer srv.Close()
+	require.NoError(t, addURL("/http", srv.URL+"/flaky", false))
+	require.YesError(t, addURL("/missing", srv.URL+"/missing", false))
+	require.YesError(t, addURL("/unlisted", srv.URL+"/", true))
+	require.YesError(t, addURL("/unknown", "gopher://host/a", false))
+	finishCommit(t, c, commit)
+
+	require.Equal(t, []string{"/http", "/imported/", "/single"}, listFile(t, c, commit, "/"))
+	require.Equal(t, "foo", getFile(t, c, commit, "/imported/a"))
+	require.Equal(t, "bar", getFile(t, c, commit, "/imported/sub/b"))
+	require.Equal(t, "bar", getFile(t, c, commit, "/single"))
+	require.Equal(t, "baz", getFile(t, c, commit, "/http"))
+
+	// servers without a file URL root refuse file URLs
+	c = newTestClient(t)
+	commit = startCommit(t, c, createRepo(t, c, "data"), "master")
+	require.YesError(t, addURL("/a", "file:///a", false))
+}
+
+func TestGetFileRanges(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), FileURLRoot: dir})
+	require.NoError(t, err)
+	c := newTestClientWithServer(t, s)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, commit, addFileReq("/dir/a", "0123456789"), addFileReq("/dir/sub/b", "bar")))
+	finishCommit(t, c, commit)
+	get := func(req *pfs.GetFileRequest) (string, error) {
+		req.File = commit.NewFile("/dir/a")
+		gfc, err := c.GetFile(context.Background(), req)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		_, err = io.Copy(&buf, grpcutil.NewStreamingBytesReader(gfc))
+		return buf.String(), err
+	}
+	for _, tc := range []struct {
+		req      *pfs.GetFileRequest
+		expected string
+	}{
+		{&pfs.GetFileRequest{}, "0123456789"},
+		{&pfs.GetFileRequest{Offset: 7}, "789"},
+		{&pfs.GetFileRequest{Offset: 2, SizeBytes: 3}, "234"},
+		{&pfs.GetFileRequest{Offset: 8, SizeBytes: 5}, "89"},
+		{&pfs.GetFileRequest{Offset: 10}, ""},
+		{&pfs.GetFileRequest{Ranges: []*pfs.ByteRange{{Offset: 8}, {Offset: 0, SizeBytes: 2}, {Offset: 4, SizeBytes: 1}}}, "89014"},
+	} {
+		data, err := get(tc.req)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, data)
+	}
+	for _, req := range []*pfs.GetFileRequest{
+		{Offset: 11},
+		{Offset: -1},
+		{SizeBytes: -1},
+		{Offset: 1, Ranges: []*pfs.ByteRange{{Offset: 2}}},
+	} {
+		_, err := get(req)
+		require.YesError(t, err)
+	}
+
+	getURL := func(path, url string, ranges ...*pfs.ByteRange) error {
+		gfc, err := c.GetFile(context.Background(), &pfs.GetFileRequest{File: commit.NewFile(path), URL: url, Ranges: ranges})
+		require.NoError(t, err)
+		_, err = gfc.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	require.NoError(t, getURL("/dir/a", "file:///range", &pfs.ByteRange{Offset: 3, SizeBytes: 4}))
+	require.NoError(t, getURL("/dir", "file:///export"))
+	require.YesError(t, getURL("/dir", "file:///export", &pfs.ByteRange{Offset: 1}))
+	require.YesError(t, getURL("/missing", "file:///missing"))
+	require.YesError(t, getURL("/dir/a", "file:///bad", &pfs.ByteRange{Offset: 20}))
+	require.YesError(t, getURL("/dir/a", "file:///../escape"))
+	for name, expected := range map[string]string{
+		"range":        "3456",
+		"export/a":     "0123456789",
+		"export/sub/b": "bar",
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		require.NoError(t, err)
+		require.Equal(t, expected, string(data))
+	}
+	_, err = os.Stat(filepath.Join(dir, "missing"))
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "bad"))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestHTTPGateway(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/dir/a.txt", "0123456789")
+	finishCommit(t, c, commit)
+	srv := httptest.NewServer(NewHTTPHandler(c))
+	defer srv.Close()
+	get := func(method, path string, header http.Header) (*http.Response, string) {
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := get("GET", "/pfs/data@master/dir/a.txt", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "0123456789", body)
+	require.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	etag := resp.Header.Get("ETag")
+
+	resp, body = get("GET", "/pfs/data="+commit.Id+"/dir/a.txt", http.Header{"Range": {"bytes=2-4"}})
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, "234", body)
+	require.Equal(t, "bytes 2-4/10", resp.Header.Get("Content-Range"))
+
+	resp, body = get("GET", "/pfs/data@"+commit.Id+"/dir/a.txt", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "0123456789", body)
+
+	resp, body = get("GET", "/pfs/data@master/dir/a.txt", http.Header{"Range": {"bytes=-3"}})
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	require.Equal(t, "789", body)
+
+	resp, body = get("GET", "/pfs/data@master/dir/a.txt", http.Header{"Range": {"bytes=0-1,8-"}})
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/byteranges", mediaType)
+	var parts []string
+	mr := multipart.NewReader(strings.NewReader(body), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		parts = append(parts, part.Header.Get("Content-Range")+" "+string(data))
+	}
+	require.Equal(t, []string{"bytes 0-1/10 01", "bytes 8-9/10 89"}, parts)
+
+	resp, _ = get("GET", "/pfs/data@master/dir/a.txt", http.Header{"Range": {"bytes=20-"}})
+	require.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+	resp, _ = get("GET", "/pfs/data@master/dir/a.txt", http.Header{"If-None-Match": {etag}})
+	require.Equal(t, http.StatusNotModified, resp.StatusCode)
+	resp, body = get("HEAD", "/pfs/data@master/dir/a.txt", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "10", resp.Header.Get("Content-Length"))
+	require.Equal(t, "", body)
+
+	resp, _ = get("GET", "/pfs/data@master/missing", nil)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	resp, _ = get("GET", "/pfs/data@master/dir", nil)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp, _ = get("POST", "/pfs/data@master/dir/a.txt", nil)
+	require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+// failingReaderAt fails every read that reaches past limit.
+type failingReaderAt struct {
+	r     io.ReaderAt
+	limit int64
+}
+
+func (r failingReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off+int64(len(p)) > r.limit {
+		return 0, errors.New("connection lost")
+	}
+	return r.r.ReadAt(p, off)
+}
+
+func TestResumableUpload(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	s.(*apiServer).driver.uploadPartSize = 4
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/big", "old")
+
+	data := "hello, resumable world"
+	upload, err := c.StartUpload(ctx, &pfs.StartUploadRequest{File: commit.NewFile("/big"), SizeBytes: int64(len(data))})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), upload.PartSizeBytes)
+
+	// the first two parts are acknowledged before the connection is lost
+	_, err = pfs.UploadParts(ctx, c, upload.Id, failingReaderAt{r: strings.NewReader(data), limit: 10})
+	require.YesError(t, err)
+	info, err := c.InspectUpload(ctx, &pfs.InspectUploadRequest{UploadId: upload.Id})
+	require.NoError(t, err)
+	require.Equal(t, int64(8), info.CommittedBytes)
+	require.Equal(t, "old", getFile(t, c, commit, "/big"))
+
+	putPart := func(offset int64, part string, hash []byte) error {
+		pc, err := c.PutUploadPart(ctx)
+		require.NoError(t, err)
+		require.NoError(t, pc.Send(&pfs.PutUploadPartRequest{Body: &pfs.PutUploadPartRequest_Header{Header: &pfs.UploadPartHeader{
+			UploadId: upload.Id,
+			Offset:   offset,
+			Hash:     hash,
+		}}}))
+		require.NoError(t, pc.Send(&pfs.PutUploadPartRequest{Body: &pfs.PutUploadPartRequest_Data{Data: wrapperspb.Bytes([]byte(part))}}))
+		_, err = pc.CloseAndRecv()
+		return err
+	}
+	sum := func(part string) []byte {
+		h := pfs.NewHash()
+		h.Write([]byte(part))
+		return h.Sum(nil)
+	}
+	// an acknowledged part may be sent again, but not changed
+	require.NoError(t, putPart(4, data[4:8], sum(data[4:8])))
+	require.YesError(t, putPart(4, "oops", sum("oops")))
+	require.YesError(t, putPart(12, data[12:16], sum(data[12:16])))
+	require.YesError(t, putPart(8, data[8:12], sum("oops")))
+	require.YesError(t, putPart(8, data[8:11], sum(data[8:11])))
+	require.YesError(t, putPart(8, data[8:12]+"more", sum(data[8:12]+"more")))
+	_, err = c.FinishUpload(ctx, &pfs.FinishUploadRequest{UploadId: upload.Id})
+	require.YesError(t, err)
+
+	// an attempt stalled on a dead connection does not hold up the resumed
+	// upload
+	stalledCtx, cancelStalled := context.WithCancel(ctx)
+	defer cancelStalled()
+	stalled, err := c.PutUploadPart(stalledCtx)
+	require.NoError(t, err)
+	require.NoError(t, stalled.Send(&pfs.PutUploadPartRequest{Body: &pfs.PutUploadPartRequest_Header{Header: &pfs.UploadPartHeader{
+		UploadId: upload.Id,
+		Offset:   8,
+		Hash:     sum(data[8:12]),
+	}}}))
+	require.NoError(t, stalled.Send(&pfs.PutUploadPartRequest{Body: &pfs.PutUploadPartRequest_Data{Data: wrapperspb.Bytes([]byte(data[8:10]))}}))
+	resumeCtx, cancelResume := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelResume()
+	info, err = pfs.UploadParts(resumeCtx, c, upload.Id, strings.NewReader(data))
+	require.NoError(t, err)
+	// the stalled attempt is too late to change anything
+	require.NoError(t, stalled.Send(&pfs.PutUploadPartRequest{Body: &pfs.PutUploadPartRequest_Data{Data: wrapperspb.Bytes([]byte(data[10:12]))}}))
+	_, err = stalled.CloseAndRecv()
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), info.CommittedBytes)
+	_, err = c.FinishUpload(ctx, &pfs.FinishUploadRequest{UploadId: upload.Id})
+	require.NoError(t, err)
+	_, err = c.InspectUpload(ctx, &pfs.InspectUploadRequest{UploadId: upload.Id})
+	require.True(t, IsUploadNotFoundErr(err))
+
+	aborted, err := c.StartUpload(ctx, &pfs.StartUploadRequest{File: commit.NewFile("/aborted"), SizeBytes: 1})
+	require.NoError(t, err)
+	_, err = c.AbortUpload(ctx, &pfs.AbortUploadRequest{UploadId: aborted.Id})
+	require.NoError(t, err)
+	_, err = c.FinishUpload(ctx, &pfs.FinishUploadRequest{UploadId: aborted.Id})
+	require.True(t, IsUploadNotFoundErr(err))
+	finishCommit(t, c, commit)
+
+	require.Equal(t, data, getFile(t, c, commit, "/big"))
+	require.Equal(t, []string{"/big"}, listFile(t, c, commit, "/"))
+}
+
+func TestUploadExpiry(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	d.uploadPartSize = 4
+	d.gcGrace = 0
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	commit := startCommit(t, c, createRepo(t, c, "data"), "master")
+
+	data := "abandoned upload"
+	upload, err := c.StartUpload(ctx, &pfs.StartUploadRequest{File: commit.NewFile("/a"), SizeBytes: int64(len(data))})
+	require.NoError(t, err)
+	_, err = pfs.UploadParts(ctx, c, upload.Id, failingReaderAt{r: strings.NewReader(data), limit: 4})
+	require.YesError(t, err)
+	// the parts of a live session are kept
+	n, err := d.collectGarbage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+
+	// each use renews the session
+	d.uploadsMu.Lock()
+	d.uploads[upload.Id].expires = time.Now().Add(time.Minute)
+	d.uploadsMu.Unlock()
+	_, err = c.InspectUpload(ctx, &pfs.InspectUploadRequest{UploadId: upload.Id})
+	require.NoError(t, err)
+	d.uploadsMu.Lock()
+	require.True(t, d.uploads[upload.Id].expires.After(time.Now().Add(time.Hour)))
+	d.uploads[upload.Id].expires = time.Now()
+	d.uploadsMu.Unlock()
+
+	// once it expires, its parts are garbage collected
+	n, err = d.collectGarbage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	_, err = c.InspectUpload(ctx, &pfs.InspectUploadRequest{UploadId: upload.Id})
+	require.True(t, IsUploadNotFoundErr(err))
+	_, err = pfs.UploadParts(ctx, c, upload.Id, strings.NewReader(data))
+	require.True(t, IsUploadNotFoundErr(err))
+
+	// an expired session is not found even before it is collected
+	upload, err = c.StartUpload(ctx, &pfs.StartUploadRequest{File: commit.NewFile("/b"), SizeBytes: 1})
+	require.NoError(t, err)
+	d.uploadsMu.Lock()
+	d.uploads[upload.Id].expires = time.Now()
+	d.uploadsMu.Unlock()
+	_, err = c.FinishUpload(ctx, &pfs.FinishUploadRequest{UploadId: upload.Id})
+	require.True(t, IsUploadNotFoundErr(err))
+}
+
+func createFileSet(t testing.TB, c pfs.APIClient, reqs ...*pfs.ModifyFileRequest) string {
+	cfc, err := c.CreateFileSet(context.Background())
+	require.NoError(t, err)
+	for _, req := range reqs {
+		require.NoError(t, cfc.Send(req))
+	}
+	resp, err := cfc.CloseAndRecv()
+	require.NoError(t, err)
+	return resp.FileSetId
+}
+
+func TestFileSets(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", "old ")
+
+	first := createFileSet(t, c, addFileReq("/a", "a"), addFileReq("/b", "b"), addFileReq("/dir/c", "c"))
+	second := createFileSet(t, c, deleteFileReq("/b"), addFileReq("/b", "new b"), addFileReq("/d", "d"))
+	third := createFileSet(t, c, deleteFileReq("/dir"))
+	composed, err := c.ComposeFileSet(ctx, &pfs.ComposeFileSetRequest{FileSetIds: []string{first, second, third}})
+	require.NoError(t, err)
+	_, err = c.AddFileSet(ctx, &pfs.AddFileSetRequest{Commit: commit, FileSetId: composed.FileSetId})
+	require.NoError(t, err)
+	_, err = c.AddFileSet(ctx, &pfs.AddFileSetRequest{Commit: commit, FileSetId: "missing"})
+	require.True(t, IsFileSetNotFoundErr(err))
+	finishCommit(t, c, commit)
+	require.Equal(t, []string{"/a", "/b", "/d"}, listFile(t, c, commit, "/"))
+	require.Equal(t, "old a", getFile(t, c, commit, "/a"))
+	require.Equal(t, "new b", getFile(t, c, commit, "/b"))
+
+	// a file set of a commit's content can be added to another commit
+	snapshot, err := c.GetFileSet(ctx, &pfs.GetFileSetRequest{Commit: commit})
+	require.NoError(t, err)
+	other := startCommit(t, c, createRepo(t, c, "other"), "master")
+	_, err = c.AddFileSet(ctx, &pfs.AddFileSetRequest{Commit: other, FileSetId: snapshot.FileSetId})
+	require.NoError(t, err)
+	finishCommit(t, c, other)
+	require.Equal(t, []string{"/a", "/b", "/d"}, listFile(t, c, other, "/"))
+	require.Equal(t, "old a", getFile(t, c, other, "/a"))
+
+	_, err = c.ComposeFileSet(ctx, &pfs.ComposeFileSetRequest{FileSetIds: []string{first, createFileSet(t, c, addFileReq("/a/x", "x"))}})
+	require.YesError(t, err)
+	_, err = c.RenewFileSet(ctx, &pfs.RenewFileSetRequest{FileSetId: first, TtlSeconds: 0})
+	require.YesError(t, err)
+	_, err = c.RenewFileSet(ctx, &pfs.RenewFileSetRequest{FileSetId: first, TtlSeconds: 60})
+	require.NoError(t, err)
+
+	// expired file sets are collected along with the chunks only they refer to
+	garbage := createFileSet(t, c, addFileReq("/garbage", "only in an expired file set"))
+	d.fileSetsMu.Lock()
+	for _, fs := range d.fileSets {
+		fs.expires = time.Now()
+	}
+	d.fileSetsMu.Unlock()
+	_, err = c.RenewFileSet(ctx, &pfs.RenewFileSetRequest{FileSetId: garbage, TtlSeconds: 60})
+	require.True(t, IsFileSetNotFoundErr(err))
+	d.gcGrace = 0
+	n, err := d.collectGarbage(ctx)
+	require.NoError(t, err)
+	require.True(t, n > 0)
+	require.Equal(t, 0, len(d.fileSets))
+	n, err = d.collectGarbage(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), n)
+	require.Equal(t, "old a", getFile(t, c, commit, "/a"))
+	require.Equal(t, "new b", getFile(t, c, other, "/b"))
+	_, err = c.CheckStorage(ctx, &pfs.CheckStorageRequest{ReadChunkData: true})
+	require.NoError(t, err)
+}
+
+func cacheValue(t testing.TB, s string) *anypb.Any {
+	value, err := anypb.New(wrapperspb.String(s))
+	require.NoError(t, err)
+	return value
+}
+
+func getCache(t testing.TB, c pfs.APIClient, key string) string {
+	resp, err := c.GetCache(context.Background(), &pfs.GetCacheRequest{Key: key})
+	require.NoError(t, err)
+	if resp.Value == nil {
+		return ""
+	}
+	var value wrapperspb.StringValue
+	require.NoError(t, resp.Value.UnmarshalTo(&value))
+	return value.Value
+}
+
+func TestCache(t *testing.T) {
+	// the budget fits two entries, but not three
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), CacheSizeBytes: 300})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	value := strings.Repeat("v", 60)
+
+	pinned := createFileSet(t, c, addFileReq("/a", "a"))
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "a", Value: cacheValue(t, "a"+value), FileSetIds: []string{pinned}, Tag: "job/1"})
+	require.NoError(t, err)
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "b", Value: cacheValue(t, "b"+value), Tag: "job/2"})
+	require.NoError(t, err)
+	require.Equal(t, "a"+value, getCache(t, c, "a"))
+	require.Equal(t, "", getCache(t, c, "missing"))
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "c", Value: cacheValue(t, "c"+value), FileSetIds: []string{"missing"}})
+	require.True(t, IsFileSetNotFoundErr(err))
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "c", Value: cacheValue(t, strings.Repeat(value, 5))})
+	require.YesError(t, err)
+
+	// b is the least recently used entry, so it is evicted to make room
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "c", Value: cacheValue(t, "c"+value), Tag: "other"})
+	require.NoError(t, err)
+	require.Equal(t, "", getCache(t, c, "b"))
+	require.Equal(t, "a"+value, getCache(t, c, "a"))
+	require.Equal(t, "c"+value, getCache(t, c, "c"))
+
+	// the file sets of cached entries outlive their TTL
+	d.fileSetsMu.Lock()
+	for _, fs := range d.fileSets {
+		fs.expires = time.Now()
+	}
+	d.fileSetsMu.Unlock()
+	d.gcGrace = 0
+	_, err = d.collectGarbage(ctx)
+	require.NoError(t, err)
+	commit := startCommit(t, c, createRepo(t, c, "data"), "master")
+	_, err = c.AddFileSet(ctx, &pfs.AddFileSetRequest{Commit: commit, FileSetId: pinned})
+	require.NoError(t, err)
+	finishCommit(t, c, commit)
+	require.Equal(t, "a", getFile(t, c, commit, "/a"))
+
+	_, err = c.ClearCache(ctx, &pfs.ClearCacheRequest{TagPrefix: "job/"})
+	require.NoError(t, err)
+	require.Equal(t, "", getCache(t, c, "a"))
+	require.Equal(t, "c"+value, getCache(t, c, "c"))
+	_, err = d.collectGarbage(ctx)
+	require.NoError(t, err)
+	_, err = c.RenewFileSet(ctx, &pfs.RenewFileSetRequest{FileSetId: pinned, TtlSeconds: 60})
+	require.True(t, IsFileSetNotFoundErr(err))
+	_, err = c.ClearCache(ctx, &pfs.ClearCacheRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "", getCache(t, c, "c"))
+}
+
+func TestCachePinnedSize(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), CacheSizeBytes: 1000})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+
+	// the entries are small, but the content they pin is not
+	first := createFileSet(t, c, addFileReq("/a", strings.Repeat("a", 600)))
+	second := createFileSet(t, c, addFileReq("/b", strings.Repeat("b", 600)))
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "first", Value: cacheValue(t, "1"), FileSetIds: []string{first}})
+	require.NoError(t, err)
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "second", Value: cacheValue(t, "2"), FileSetIds: []string{second}})
+	require.NoError(t, err)
+	require.Equal(t, "", getCache(t, c, "first"))
+	require.Equal(t, "2", getCache(t, c, "second"))
+	d.fileSetsMu.Lock()
+	require.Equal(t, 0, d.fileSets[first].pins)
+	require.Equal(t, 1, d.fileSets[second].pins)
+	d.fileSetsMu.Unlock()
+
+	// an entry pinning more than the budget is rejected, and pins nothing
+	both, err := c.ComposeFileSet(ctx, &pfs.ComposeFileSetRequest{FileSetIds: []string{first, second}})
+	require.NoError(t, err)
+	_, err = c.PutCache(ctx, &pfs.PutCacheRequest{Key: "both", Value: cacheValue(t, "3"), FileSetIds: []string{both.FileSetId}})
+	require.YesError(t, err)
+	d.fileSetsMu.Lock()
+	require.Equal(t, 0, d.fileSets[both.FileSetId].pins)
+	d.fileSetsMu.Unlock()
+	require.Equal(t, "2", getCache(t, c, "second"))
+}
+
+func TestListTask(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	// the test plays the driver's workers, with leases short enough to lose
+	d.tasks = task.NewService(task.Options{
+		LeaseTTL: 100 * time.Millisecond,
+		BackOff:  func() backoff.BackOff { return &backoff.ZeroBackOff{} },
+	})
+	d.workersOnce.Do(func() {})
+	c := newTestClientWithServer(t, s)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", "hello")
+	finished := make(chan error, 1)
+	go func() {
+		_, err := c.FinishCommit(ctx, &pfs.FinishCommitRequest{Commit: commit})
+		finished <- err
+	}()
+
+	list := func(group *taskapi.Group) []*taskapi.TaskInfo {
+		ltc, err := c.ListTask(ctx, &taskapi.ListTaskRequest{Group: group})
+		require.NoError(t, err)
+		var infos []*taskapi.TaskInfo
+		for {
+			info, err := ltc.Recv()
+			if err == io.EOF {
+				return infos
+			}
+			require.NoError(t, err)
+			infos = append(infos, info)
+		}
+	}
+	// a worker claims the commit's compaction and stalls, leaving the commit
+	// finishing
+	claim, err := d.tasks.Claim(ctx, taskNamespace)
+	require.NoError(t, err)
+	infos := list(&taskapi.Group{Namespace: taskNamespace})
+	require.Equal(t, 1, len(infos))
+	require.Equal(t, claim.ID, infos[0].Id)
+	require.Equal(t, taskapi.State_CLAIMED, infos[0].State)
+	require.Equal(t, compactionTaskGroup, infos[0].Group.Group)
+	require.Equal(t, "type.googleapis.com/v1.pfs.Commit", infos[0].InputType)
+	require.True(t, strings.Contains(infos[0].InputData, commit.Id))
+	ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: commit})
+	require.NoError(t, err)
+	require.NotNil(t, ci.Finishing)
+	require.Nil(t, ci.Finished)
+	require.Equal(t, 0, len(list(&taskapi.Group{Namespace: "other"})))
+
+	// once its lease expires, the task is retried
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		infos := list(nil)
+		if len(infos) != 1 || infos[0].State != taskapi.State_RUNNING {
+			return errors.Errorf("the task has not been released: %v", infos)
+		}
+		require.Equal(t, "retrying: the worker's lease expired", infos[0].Reason)
+		return nil
+	})
+	require.True(t, errors.Is(d.tasks.Complete(claim, nil, nil), task.ErrLeaseLost))
+	// and a live worker finishes the commit
+	go d.tasks.Work(ctx, taskNamespace, d.processFinish)
+	require.NoError(t, <-finished)
+	ci, err = c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: commit})
+	require.NoError(t, err)
+	require.NotNil(t, ci.Finished)
+	require.Equal(t, "hello", getFile(t, c, commit, "/a"))
+	require.Equal(t, 0, len(list(nil)))
+}
+
+func TestFinishCommitTask(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	// the test plays the driver's workers, and failed tasks are not retried
+	d.tasks = task.NewService(task.Options{BackOff: func() backoff.BackOff { return &backoff.StopBackOff{} }})
+	d.workersOnce.Do(func() {})
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+
+	// a caller that gives up does not wait for the task, which still
+	// finishes the commit
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "foo")
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = c.FinishCommit(timeoutCtx, &pfs.FinishCommitRequest{Commit: c1})
+	require.YesError(t, err)
+	claim, err := d.tasks.Claim(ctx, taskNamespace)
+	require.NoError(t, err)
+	output, err := d.processFinish(ctx, claim.Input)
+	require.NoError(t, err)
+	require.NoError(t, d.tasks.Complete(claim, output, nil))
+	ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: c1, Wait: pfs.CommitState_FINISHED})
+	require.NoError(t, err)
+	require.Equal(t, "", ci.Error)
+
+	// a task that fails for good finishes the commit with its failure
+	c2 := startCommit(t, c, repo, "master")
+	finished := make(chan error, 1)
+	go func() {
+		_, err := c.FinishCommit(ctx, &pfs.FinishCommitRequest{Commit: c2})
+		finished <- err
+	}()
+	claim, err = d.tasks.Claim(ctx, taskNamespace)
+	require.NoError(t, err)
+	require.NoError(t, d.tasks.Complete(claim, nil, errors.New("out of space")))
+	require.YesError(t, <-finished)
+	ci, err = c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: c2, Wait: pfs.CommitState_FINISHED})
+	require.NoError(t, err)
+	require.True(t, strings.Contains(ci.Error, "out of space"))
+}
+
+func fsck(t testing.TB, c pfs.APIClient, fix bool) (fixes, errs []string) {
+	fc, err := c.Fsck(context.Background(), &pfs.FsckRequest{Fix: fix})
+	require.NoError(t, err)
+	for {
+		resp, err := fc.Recv()
+		if err == io.EOF {
+			return fixes, errs
+		}
+		require.NoError(t, err)
+		if resp.Fix != "" {
+			fixes = append(fixes, resp.Fix)
+		} else {
+			errs = append(errs, resp.Error)
+		}
+	}
+}
+
+func TestFsck(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	d := s.(*apiServer).driver
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	in := createRepo(t, c, "in")
+	out := createRepo(t, c, "out")
+	createBranch(t, c, out.NewBranch("master"), in.NewBranch("master"))
+	first := startCommit(t, c, in, "master")
+	putFile(t, c, first, "/a", "a")
+	finishCommit(t, c, first)
+	second := startCommit(t, c, in, "master")
+	putFile(t, c, second, "/b", "b")
+	finishCommit(t, c, second)
+	fixes, errs := fsck(t, c, false)
+	require.Equal(t, 0, len(fixes))
+	require.Equal(t, 0, len(errs))
+
+	// break the parent link of the second commit, lose the downstream
+	// commit in its set and the chunk of /b, and let the size of the first
+	// commit drift
+	d.mu.Lock()
+	commits := d.meta[commitsCollection]
+	parent := commits[first.Key()].(*pfs.CommitInfo)
+	parent.ChildCommits = nil
+	delete(commits, out.NewCommit("master", second.Id).Key())
+	ref := d.files[second.Key()].tree.files["/b"].dataRefs()[0]
+	d.files[first.Key()].tree.sizeBytes += 10
+	d.mu.Unlock()
+	require.NoError(t, d.storage.Delete(ctx, ref.ID))
+
+	// without fix, the problems are only reported
+	fixes, errs = fsck(t, c, false)
+	require.Equal(t, 0, len(fixes))
+	require.Equal(t, 6, len(errs))
+	_, errs = fsck(t, c, false)
+	require.Equal(t, 6, len(errs))
+
+	fixes, errs = fsck(t, c, true)
+	require.Equal(t, 5, len(fixes))
+	require.Equal(t, 1, len(errs))
+	require.True(t, strings.Contains(errs[0], "/b"))
+	ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: out.NewCommit("master", "")})
+	require.NoError(t, err)
+	require.Equal(t, second.Id, ci.Commit.Id)
+	require.Equal(t, pfs.OriginKind_FSCK, ci.Origin.Kind)
+	require.Equal(t, 2, len(inspectCommitSet(t, c, second.Id)))
+	ci, err = c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: first})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), ci.SizeBytesUpperBound)
+
+	// only the missing chunk remains
+	fixes, errs = fsck(t, c, true)
+	require.Equal(t, 0, len(fixes))
+	require.Equal(t, 1, len(errs))
+}
+
+func TestEgress(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir(), FileURLRoot: dir})
+	require.NoError(t, err)
+	c := newTestClientWithServer(t, s)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", "foo")
+	putFile(t, c, commit, "/dir/b", "barbaz")
+	egress := func(target pfs.EgressRequest_ObjectStorage) (*pfs.EgressResponse, error) {
+		return c.Egress(ctx, &pfs.EgressRequest{Commit: commit, Target: &target})
+	}
+	target := pfs.EgressRequest_ObjectStorage{ObjectStorage: &pfs.ObjectStorageEgress{Url: "file:///out"}}
+	_, err = egress(target)
+	require.True(t, IsCommitNotFinishedErr(err))
+	finishCommit(t, c, commit)
+
+	resp, err := egress(target)
+	require.NoError(t, err)
+	require.Equal(t, int64(len("foo")+len("barbaz")), resp.GetObjectStorage().BytesWritten)
+	data, err := os.ReadFile(filepath.Join(dir, "out", "dir", "b"))
+	require.NoError(t, err)
+	require.Equal(t, "barbaz", string(data))
+
+	// files must be in a table directory before anything is written
+	_, err = c.Egress(ctx, &pfs.EgressRequest{Commit: commit, Target: &pfs.EgressRequest_SqlDatabase{SqlDatabase: &pfs.SQLDatabaseEgress{
+		Url:        "postgres://localhost:1/db",
+		FileFormat: &pfs.SQLDatabaseEgress_FileFormat{Type: pfs.SQLDatabaseEgress_FileFormat_CSV},
+	}}})
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "/a"))
+}
+
+func TestIngestRows(t *testing.T) {
+	s, err := NewAPIServer(context.Background(), Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	c := newTestClientWithServer(t, s)
+	d := s.(*apiServer).driver
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/users/stale", "x")
+	putFile(t, c, commit, "/other", "y")
+	finishCommit(t, c, commit)
+	branch := repo.NewBranch("master")
+	columns := []string{"id", "name"}
+	ingest := func(delimiter pfs.Delimiter, rows ...[]interface{}) (*pfs.Commit, int64, error) {
+		return d.ingestRows(ctx, branch, &pfs.SQLIngest{Path: "/users", Delimiter: delimiter, TargetFileDatums: 2}, columns, func(w sqlutil.RowWriter) (int64, error) {
+			for _, row := range rows {
+				if err := w.Write(row); err != nil {
+					return 0, err
+				}
+			}
+			return int64(len(rows)), nil
+		})
+	}
+
+	// the snapshot replaces the directory, and every file has the CSV header
+	commit, n, err := ingest(pfs.Delimiter_CSV, []interface{}{int64(1), "alice"}, []interface{}{int64(2), nil}, []interface{}{int64(3), "carol"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.Equal(t, []string{pfs.SplitFileName("/users", 0), pfs.SplitFileName("/users", 1)}, listFile(t, c, commit, "/users"))
+	require.Equal(t, "id,name\n1,alice\n2,\n", getFile(t, c, commit, pfs.SplitFileName("/users", 0)))
+	require.Equal(t, "id,name\n3,carol\n", getFile(t, c, commit, pfs.SplitFileName("/users", 1)))
+	require.Equal(t, "y", getFile(t, c, commit, "/other"))
+
+	commit, _, err = ingest(pfs.Delimiter_JSON, []interface{}{int64(4), "dave"})
+	require.NoError(t, err)
+	require.Equal(t, []string{pfs.SplitFileName("/users", 0)}, listFile(t, c, commit, "/users"))
+	require.Equal(t, `{"id":4,"name":"dave"}`+"\n", getFile(t, c, commit, pfs.SplitFileName("/users", 0)))
+	bi, err := c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch})
+	require.NoError(t, err)
+	require.Equal(t, commit.Id, bi.Head.Id)
+
+	// a failing query or an unsupported delimiter commits nothing
+	_, _, err = d.ingestRows(ctx, branch, &pfs.SQLIngest{Path: "/users", Delimiter: pfs.Delimiter_CSV}, columns, func(w sqlutil.RowWriter) (int64, error) {
+		if err := w.Write([]interface{}{int64(5), "eve"}); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("connection reset")
+	})
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "connection reset"))
+	_, _, err = ingest(pfs.Delimiter_LINE)
+	require.YesError(t, err)
+	bi, err = c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch})
+	require.NoError(t, err)
+	require.Equal(t, commit.Id, bi.Head.Id)
+}
+
+func TestWaitForCommit(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+
+	done := make(chan *pfs.CommitInfo)
+	go func() {
+		ci, err := c.InspectCommit(context.Background(), &pfs.InspectCommitRequest{Commit: commit, Wait: pfs.CommitState_FINISHED})
+		require.NoError(t, err)
+		done <- ci
+	}()
+	finishCommit(t, c, commit)
+	ci := <-done
+	require.NotNil(t, ci.Finished)
+}
+
+func TestListCommit(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	var commits []*pfs.Commit
+	for i := 0; i < 5; i++ {
+		commit := startCommit(t, c, repo, "master")
+		finishCommit(t, c, commit)
+		commits = append(commits, commit)
+	}
+
+	cis := listCommit(t, c, &pfs.ListCommitRequest{Repo: repo})
+	require.Equal(t, 5, len(cis))
+	require.Equal(t, commits[4].Id, cis[0].Commit.Id)
+
+	cis = listCommit(t, c, &pfs.ListCommitRequest{Repo: repo, Number: 2, Reverse: true})
+	require.Equal(t, 2, len(cis))
+	require.Equal(t, commits[3].Id, cis[0].Commit.Id)
+
+	cis = listCommit(t, c, &pfs.ListCommitRequest{Repo: repo, To: commits[3], From: commits[1]})
+	require.Equal(t, 2, len(cis))
+	require.Equal(t, commits[3].Id, cis[0].Commit.Id)
+	require.Equal(t, commits[2].Id, cis[1].Commit.Id)
+}
+
+func TestBranches(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", "foo")
+	finishCommit(t, c, commit)
+
+	_, err := c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: repo.NewBranch("staging"), Head: commit})
+	require.NoError(t, err)
+	require.Equal(t, "foo", getFile(t, c, repo.NewCommit("staging", ""), "/a"))
+
+	// branches created without a head start at an empty commit
+	_, err = c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: repo.NewBranch("empty")})
+	require.NoError(t, err)
+	bi, err := c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: repo.NewBranch("empty")})
+	require.NoError(t, err)
+	require.NotNil(t, bi.Head)
+	require.Equal(t, 0, len(listFile(t, c, bi.Head, "/")))
+
+	ri, err := c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(ri.Branches))
+
+	_, err = c.DeleteBranch(ctx, &pfs.DeleteBranchRequest{Branch: repo.NewBranch("staging")})
+	require.NoError(t, err)
+	_, err = c.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: repo.NewBranch("staging")})
+	require.True(t, IsBranchNotFoundErr(err))
+	// the commit outlives the branch
+	_, err = c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: commit})
+	require.NoError(t, err)
+}
+
+func TestCheckStorageDeduplicates(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	for _, name := range []string{"a", "b"} {
+		repo := createRepo(t, c, name)
+		commit := startCommit(t, c, repo, "master")
+		putFile(t, c, commit, "/file", "same content")
+		finishCommit(t, c, commit)
+	}
+	resp, err := c.CheckStorage(ctx, &pfs.CheckStorageRequest{ReadChunkData: true})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), resp.ChunkObjectCount)
+
+	fi, err := c.InspectFile(ctx, &pfs.InspectFileRequest{File: newRepo("a").NewCommit("master", "").NewFile("/file")})
+	require.NoError(t, err)
+	require.Equal(t, int64(len("same content")), fi.SizeBytes)
+}
+
+func TestSizes(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "hello")
+	putFile(t, c, c1, "/b", "hello")
+	// open commits have sizes, but no finishing times yet
+	ci, err := c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: c1})
+	require.NoError(t, err)
+	require.Equal(t, int64(10), ci.SizeBytesUpperBound)
+	require.Equal(t, int64(5), ci.Details.SizeBytes)
+	require.Nil(t, ci.Details.CompactingTime)
+	finishCommit(t, c, c1)
+	ci, err = c.InspectCommit(ctx, &pfs.InspectCommitRequest{Commit: c1})
+	require.NoError(t, err)
+	require.NotNil(t, ci.Details.CompactingTime)
+	require.NotNil(t, ci.Details.ValidatingTime)
+
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, deleteFileReq("/a"), addFileReq("/c", "world!")))
+	finishCommit(t, c, c2)
+	_, err = c.CreateBranch(ctx, &pfs.CreateBranchRequest{Branch: repo.NewBranch("staging"), Head: c1})
+	require.NoError(t, err)
+	cis := listCommit(t, c, &pfs.ListCommitRequest{Repo: repo})
+	require.Equal(t, 2, len(cis))
+	require.Equal(t, int64(11), cis[0].SizeBytesUpperBound)
+	require.Equal(t, int64(11), cis[0].Details.SizeBytes)
+
+	// the branches' heads hold 11 and 10 bytes, of which "hello" and
+	// "world!" are stored
+	ri, err := c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	require.Equal(t, int64(21), ri.SizeBytesUpperBound)
+	require.Equal(t, int64(11), ri.Details.SizeBytes)
+	client, err := c.ListRepo(ctx, &pfs.ListRepoRequest{})
+	require.NoError(t, err)
+	ri, err = client.Recv()
+	require.NoError(t, err)
+	require.Equal(t, int64(21), ri.SizeBytesUpperBound)
+	require.Equal(t, int64(11), ri.Details.SizeBytes)
+
+	// branches report the sizes of their heads
+	bi := inspectBranch(t, c, repo.NewBranch("staging"))
+	require.Equal(t, int64(10), bi.SizeBytesUpperBound)
+	require.Equal(t, int64(5), bi.Details.SizeBytes)
+	bclient, err := c.ListBranch(ctx, &pfs.ListBranchRequest{Repo: repo})
+	require.NoError(t, err)
+	sizes := make(map[string]int64)
+	for {
+		bi, err := bclient.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		sizes[bi.Branch.Name] = bi.Details.SizeBytes
+	}
+	require.Equal(t, map[string]int64{"master": 11, "staging": 5}, sizes)
+
+	// dropping c2 leaves only "hello" stored
+	_, err = c.DropCommitSet(ctx, &pfs.DropCommitSetRequest{CommitSet: &pfs.CommitSet{Id: c2.Id}})
+	require.NoError(t, err)
+	ri, err = c.InspectRepo(ctx, &pfs.InspectRepoRequest{Repo: repo})
+	require.NoError(t, err)
+	require.Equal(t, int64(20), ri.SizeBytesUpperBound)
+	require.Equal(t, int64(5), ri.Details.SizeBytes)
+}
@@ -0,0 +1,107 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+)
+
+// Sizes are not stored with the metadata, but derived from the file trees,
+// which keep running totals of their content as files are modified, and from
+// the repoSizes, which count the content of each repo's finished commits as
+// they finish and are deleted. They are added to the CommitInfos, BranchInfos
+// and RepoInfos handed out to clients.
+
+// repoSize counts the distinct content referred to by a repo's finished
+// commits.
+type repoSize struct {
+	// refs counts the finished commits referring to each piece of content.
+	refs       map[dataRefKey]int
+	dedupBytes int64
+}
+
+// countFiles adds (delta 1) or removes (delta -1) the distinct content of a
+// commit to or from its repo's size.
+func (d *driver) countFiles(commit *pfs.Commit, cf *commitFiles, delta int) {
+	key := commit.Branch.Repo.Key()
+	rs, ok := d.repoSizes[key]
+	if !ok {
+		rs = &repoSize{refs: make(map[dataRefKey]int)}
+		d.repoSizes[key] = rs
+	}
+	for k := range cf.tree.refs {
+		old := rs.refs[k]
+		n := old + delta
+		if n > 0 {
+			rs.refs[k] = n
+		} else {
+			n = 0
+			delete(rs.refs, k)
+		}
+		if old == 0 && n > 0 {
+			rs.dedupBytes += k.sizeBytes
+		} else if old > 0 && n == 0 {
+			rs.dedupBytes -= k.sizeBytes
+		}
+	}
+	cf.counted = delta > 0
+}
+
+// addCommitSizes sets the sizes of ci's content. SizeBytesUpperBound is its
+// logical size, counting every file in full, and Details.SizeBytes the size
+// of the distinct content it refers to. Once ci has finished, Details also
+// holds how long finishing it took, as measured by finishFiles.
+func (tx *txn) addCommitSizes(ci *pfs.CommitInfo) {
+	cf := tx.commitFiles(ci.Commit)
+	ci.SizeBytesUpperBound = cf.tree.size()
+	ci.Details = &pfs.CommitInfo_Details{SizeBytes: cf.tree.dedupSize()}
+	if ci.Finished != nil {
+		ci.Details.CompactingTime = durationpb.New(cf.compacting)
+		ci.Details.ValidatingTime = durationpb.New(cf.validating)
+	}
+}
+
+// addBranchSizes sets the sizes of bi's head, as addCommitSizes would.
+func (tx *txn) addBranchSizes(bi *pfs.BranchInfo) {
+	cf := tx.commitFiles(bi.Head)
+	bi.SizeBytesUpperBound = cf.tree.size()
+	bi.Details = &pfs.BranchInfo_Details{SizeBytes: cf.tree.dedupSize()}
+}
+
+// addRepoSizes sets the sizes of ri's content. SizeBytesUpperBound is the
+// sum of the logical sizes of the heads of its branches, so content shared by
+// branches or files is counted every time. Details.SizeBytes is the size of
+// the distinct content referred to by any of its finished commits, which is
+// what the repo keeps in storage once its open commits are done.
+func (tx *txn) addRepoSizes(ri *pfs.RepoInfo) {
+	ri.SizeBytesUpperBound = 0
+	for _, bi := range tx.listBranchInfos(ri.Repo) {
+		if bi.Head != nil {
+			ri.SizeBytesUpperBound += tx.commitFiles(bi.Head).tree.size()
+		}
+	}
+	ri.Details = &pfs.RepoInfo_Details{}
+	if rs, ok := tx.d.repoSizes[ri.Repo.Key()]; ok {
+		ri.Details.SizeBytes = rs.dedupBytes
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+)
+
+// metadataStore persists the driver's metadata and commit diffs, so that they
+// survive a restart. File content is already durable in chunk storage.
+type metadataStore interface {
+	// load returns everything that has been persisted.
+	load(ctx context.Context) (*snapshot, error)
+	// write atomically persists the changes staged in tx. It must fail,
+	// without persisting anything, if the store disagrees with the driver
+	// about what already exists.
+	write(ctx context.Context, tx *txn) error
+}
+
+// snapshot is the persisted state of a driver.
+type snapshot struct {
+	meta map[collection]map[string]proto.Message
+	// diffs are the diffs of every commit, keyed like commits.
+	diffs map[string][]fileOp
+}
+
+func newSnapshot() *snapshot {
+	return &snapshot{
+		meta: map[collection]map[string]proto.Message{
+			reposCollection:    make(map[string]proto.Message),
+			branchesCollection: make(map[string]proto.Message),
+			commitsCollection:  make(map[string]proto.Message),
+		},
+		diffs: make(map[string][]fileOp),
+	}
+}
+
+// newMessage returns an empty message of the type stored in c.
+func newMessage(c collection) proto.Message {
+	switch c {
+	case reposCollection:
+		return &pfs.RepoInfo{}
+	case branchesCollection:
+		return &pfs.BranchInfo{}
+	case commitsCollection:
+		return &pfs.CommitInfo{}
+	}
+	panic("unknown collection " + string(c))
+}
+
+// fileOpRecord is the serialized form of a fileOp.
+type fileOpRecord struct {
+	Kind  opKind           `json:"kind"`
+	Path  string           `json:"path"`
+	Datum string           `json:"datum,omitempty"`
+	Refs  []chunk.DataRef  `json:"refs,omitempty"`
+	Parts []filePartRecord `json:"parts,omitempty"`
+}
+
+type filePartRecord struct {
+	Datum string          `json:"datum,omitempty"`
+	Refs  []chunk.DataRef `json:"refs"`
+}
+
+func marshalFileOp(op fileOp) ([]byte, error) {
+	rec := fileOpRecord{Kind: op.kind, Path: op.path, Datum: op.datum, Refs: op.refs}
+	if op.file != nil {
+		for _, p := range op.file.parts {
+			rec.Parts = append(rec.Parts, filePartRecord{Datum: p.datum, Refs: p.refs})
+		}
+	}
+	data, err := json.Marshal(rec)
+	return data, errors.EnsureStack(err)
+}
+
+func unmarshalFileOp(data []byte) (fileOp, error) {
+	var rec fileOpRecord
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return fileOp{}, errors.EnsureStack(err)
+	}
+	op := fileOp{kind: rec.Kind, path: rec.Path, datum: rec.Datum, refs: rec.Refs}
+	if rec.Kind == opPut {
+		op.file = &file{}
+		for _, p := range rec.Parts {
+			op.file.parts = append(op.file.parts, &filePart{datum: p.Datum, refs: p.Refs})
+		}
+	}
+	return op, nil
+}
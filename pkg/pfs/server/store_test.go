@@ -0,0 +1,147 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+// testStore is a metadataStore that keeps what it persists in memory,
+// serializing it the same way a real store would.
+type testStore struct {
+	meta  map[collection]map[string][]byte
+	diffs map[string][][]byte
+}
+
+func newTestStore() *testStore {
+	return &testStore{
+		meta:  make(map[collection]map[string][]byte),
+		diffs: make(map[string][][]byte),
+	}
+}
+
+func (s *testStore) load(ctx context.Context) (*snapshot, error) {
+	snap := newSnapshot()
+	for c, items := range s.meta {
+		for key, data := range items {
+			m := newMessage(c)
+			if err := proto.Unmarshal(data, m); err != nil {
+				return nil, err
+			}
+			snap.meta[c][key] = m
+		}
+	}
+	for key, ops := range s.diffs {
+		for _, data := range ops {
+			op, err := unmarshalFileOp(data)
+			if err != nil {
+				return nil, err
+			}
+			snap.diffs[key] = append(snap.diffs[key], op)
+		}
+	}
+	return snap, nil
+}
+
+func (s *testStore) write(ctx context.Context, tx *txn) error {
+	for c, staged := range tx.staged {
+		if s.meta[c] == nil {
+			s.meta[c] = make(map[string][]byte)
+		}
+		for key, m := range staged {
+			if m == nil {
+				delete(s.meta[c], key)
+				if c == commitsCollection {
+					delete(s.diffs, key)
+				}
+				continue
+			}
+			data, err := proto.Marshal(m)
+			if err != nil {
+				return err
+			}
+			s.meta[c][key] = data
+		}
+	}
+	for key, dc := range tx.diffs {
+		if m, ok := tx.staged[commitsCollection][key]; ok && m == nil {
+			continue
+		}
+		if dc.reset {
+			delete(s.diffs, key)
+		}
+		for _, op := range dc.ops {
+			data, err := marshalFileOp(op)
+			if err != nil {
+				return err
+			}
+			s.diffs[key] = append(s.diffs[key], data)
+		}
+	}
+	return nil
+}
+
+func TestDriverReloadsFromStore(t *testing.T) {
+	objC, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	storage := chunk.NewStorage(objC, chunk.Options{})
+	store := newTestStore()
+	newClient := func() pfs.APIClient {
+		d := newDriver(storage, store)
+		require.NoError(t, d.load(context.Background()))
+		return newTestClientWithServer(t, &apiServer{driver: d})
+	}
+
+	c := newClient()
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	putFile(t, c, c1, "/a", "foo")
+	putFile(t, c, c1, "/b", "bar")
+	finishCommit(t, c, c1)
+	c2 := startCommit(t, c, repo, "master")
+	require.NoError(t, modifyFile(c, c2, deleteFileReq("/b"), addFileReq("/c", "baz")))
+	require.NoError(t, modifyFile(c, c2, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_CopyFile{CopyFile: &pfs.CopyFile{
+		Src: c1.NewFile("/a"),
+		Dst: "/d",
+	}}}))
+	c3 := startCommit(t, c, createRepo(t, c, "cleared"), "master")
+	putFile(t, c, c3, "/a", "foo")
+	_, err = c.ClearCommit(context.Background(), &pfs.ClearCommitRequest{Commit: c3})
+	require.NoError(t, err)
+
+	c = newClient()
+	require.Equal(t, []string{"/a", "/b"}, listFile(t, c, c1, "/"))
+	require.Equal(t, []string{"/a", "/c", "/d"}, listFile(t, c, c2, "/"))
+	require.Equal(t, "foo", getFile(t, c, c2, "/d"))
+	require.Equal(t, 0, len(listFile(t, c, c3, "/")))
+	fi, err := c.InspectFile(context.Background(), &pfs.InspectFileRequest{File: c1.NewFile("/a")})
+	require.NoError(t, err)
+	require.NotNil(t, fi.Committed)
+	finishCommit(t, c, c2)
+}
@@ -0,0 +1,125 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/storage/obj"
+)
+
+// subscribe starts a SubscribeCommit stream and returns a channel of the
+// commits it emits. The stream is closed when the test ends.
+func subscribe(t *testing.T, c pfs.APIClient, req *pfs.SubscribeCommitRequest) <-chan *pfs.CommitInfo {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	scc, err := c.SubscribeCommit(ctx, req)
+	require.NoError(t, err)
+	cis := make(chan *pfs.CommitInfo)
+	go func() {
+		defer close(cis)
+		for {
+			ci, err := scc.Recv()
+			if err != nil {
+				return
+			}
+			cis <- ci
+		}
+	}()
+	return cis
+}
+
+func requireNext(t *testing.T, cis <-chan *pfs.CommitInfo, commit *pfs.Commit) {
+	select {
+	case ci := <-cis:
+		require.Equal(t, commit.Id, ci.Commit.Id)
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for commit %v", commit)
+	}
+}
+
+func requireNothing(t *testing.T, cis <-chan *pfs.CommitInfo) {
+	select {
+	case ci := <-cis:
+		t.Fatalf("unexpected commit %v", ci.Commit)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeCommit(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	c1 := startCommit(t, c, repo, "master")
+	finishCommit(t, c, c1)
+	startCommit(t, c, repo, "other")
+
+	cis := subscribe(t, c, &pfs.SubscribeCommitRequest{Repo: repo, Branch: "master", State: pfs.CommitState_FINISHED})
+	requireNext(t, cis, c1)
+	requireNothing(t, cis)
+
+	// an unfinished commit holds back the commits after it
+	c2 := startCommit(t, c, repo, "master")
+	requireNothing(t, cis)
+	c3, err := c.StartCommit(context.Background(), &pfs.StartCommitRequest{Branch: repo.NewBranch("master"), Parent: c1})
+	require.NoError(t, err)
+	finishCommit(t, c, c3)
+	requireNothing(t, cis)
+	finishCommit(t, c, c2)
+	requireNext(t, cis, c2)
+	requireNext(t, cis, c3)
+}
+
+func TestSubscribeCommitResumes(t *testing.T) {
+	objC, err := obj.NewLocalClient(t.TempDir())
+	require.NoError(t, err)
+	storage := chunk.NewStorage(objC, chunk.Options{})
+	store := newTestStore()
+	newClient := func() pfs.APIClient {
+		d := newDriver(storage, store)
+		require.NoError(t, d.load(context.Background()))
+		return newTestClientWithServer(t, &apiServer{driver: d})
+	}
+
+	c := newClient()
+	repo := createRepo(t, c, "data")
+	var commits []*pfs.Commit
+	for i := 0; i < 3; i++ {
+		commit := startCommit(t, c, repo, "master")
+		finishCommit(t, c, commit)
+		commits = append(commits, commit)
+	}
+	cis := subscribe(t, c, &pfs.SubscribeCommitRequest{Repo: repo, Branch: "master"})
+	requireNext(t, cis, commits[0])
+	requireNext(t, cis, commits[1])
+
+	// after a restart, the subscriber picks up after the last commit it saw
+	c = newClient()
+	cis = subscribe(t, c, &pfs.SubscribeCommitRequest{Repo: repo, Branch: "master", From: &pfs.Commit{Id: commits[1].Id}})
+	requireNext(t, cis, commits[2])
+	requireNothing(t, cis)
+	commit := startCommit(t, c, repo, "master")
+	requireNext(t, cis, commit)
+}
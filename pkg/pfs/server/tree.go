@@ -0,0 +1,455 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/binary"
+	"path"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+)
+
+// file is the content stored at a single path. Content written under
+// different datum tags is kept in separate parts, ordered by datum, and
+// concatenated on read. The content itself lives in chunk storage; files only
+// hold references to it. Files are immutable once they are part of a tree, so
+// trees can share them freely.
+type file struct {
+	parts     []*filePart
+	committed *timestamppb.Timestamp
+}
+
+type filePart struct {
+	datum string
+	refs  []chunk.DataRef
+}
+
+func (f *file) size() int64 {
+	var n int64
+	for _, ref := range f.dataRefs() {
+		n += ref.SizeBytes
+	}
+	return n
+}
+
+// dataRefs returns references to the file's content, in order.
+func (f *file) dataRefs() []chunk.DataRef {
+	var refs []chunk.DataRef
+	for _, p := range f.parts {
+		refs = append(refs, p.refs...)
+	}
+	return refs
+}
+
+// hash hashes the references to the file's content rather than the content
+// itself, so it can be computed without reading any chunks.
+func (f *file) hash() []byte {
+	h := pfs.NewHash()
+	for _, ref := range f.dataRefs() {
+		h.Write(ref.ID)
+		binary.Write(h, binary.BigEndian, ref.OffsetBytes)
+		binary.Write(h, binary.BigEndian, ref.SizeBytes)
+	}
+	return h.Sum(nil)
+}
+
+type opKind int
+
+const (
+	// opAdd appends data to the part of a file written under a datum.
+	opAdd opKind = iota
+	// opDelete deletes a file, a directory, or a single datum's part.
+	opDelete
+	// opPut replaces a file with a copy of another file.
+	opPut
+)
+
+// fileOp is a single modification recorded in a commit's diff.
+type fileOp struct {
+	kind  opKind
+	path  string
+	datum string
+	refs  []chunk.DataRef
+	file  *file
+}
+
+// tree is a snapshot of the files in a commit. Directories are implicit: a
+// directory exists as long as some file exists beneath it.
+type tree struct {
+	files map[string]*file
+	// dirs counts the files beneath every non-root directory.
+	dirs map[string]int
+	// entries holds the paths of the files and directories directly beneath
+	// every directory, including the root, so that a directory can be
+	// walked without looking at the rest of the tree.
+	entries map[string]map[string]bool
+	// sizeBytes is the total size of the files, and dedupBytes the size of
+	// the distinct content they refer to. Both are kept up to date as files
+	// are set and removed, using refs to count the files referring to each
+	// piece of content.
+	sizeBytes  int64
+	dedupBytes int64
+	refs       map[dataRefKey]int
+}
+
+// dataRefKey identifies a piece of stored content. Chunks are addressed by
+// their content, so content written twice has the same key.
+type dataRefKey struct {
+	id          string
+	offsetBytes int64
+	sizeBytes   int64
+}
+
+func newDataRefKey(ref chunk.DataRef) dataRefKey {
+	return dataRefKey{id: string(ref.ID), offsetBytes: ref.OffsetBytes, sizeBytes: ref.SizeBytes}
+}
+
+func newTree() *tree {
+	return &tree{
+		files:   make(map[string]*file),
+		dirs:    make(map[string]int),
+		entries: make(map[string]map[string]bool),
+		refs:    make(map[dataRefKey]int),
+	}
+}
+
+// clone returns a copy of t that can be modified without affecting t.
+func (t *tree) clone() *tree {
+	c := &tree{
+		files:      make(map[string]*file, len(t.files)),
+		dirs:       make(map[string]int, len(t.dirs)),
+		entries:    make(map[string]map[string]bool, len(t.entries)),
+		sizeBytes:  t.sizeBytes,
+		dedupBytes: t.dedupBytes,
+		refs:       make(map[dataRefKey]int, len(t.refs)),
+	}
+	for p, f := range t.files {
+		c.files[p] = f
+	}
+	for d, n := range t.dirs {
+		c.dirs[d] = n
+	}
+	for d, es := range t.entries {
+		ces := make(map[string]bool, len(es))
+		for e := range es {
+			ces[e] = true
+		}
+		c.entries[d] = ces
+	}
+	for k, n := range t.refs {
+		c.refs[k] = n
+	}
+	return c
+}
+
+// size returns the total size of the files in t.
+func (t *tree) size() int64 {
+	return t.sizeBytes
+}
+
+// dedupSize returns the size of the distinct content the files in t refer
+// to, counting content shared between files, or repeated within one, once.
+func (t *tree) dedupSize() int64 {
+	return t.dedupBytes
+}
+
+func (t *tree) isDir(p string) bool {
+	return p == "/" || t.dirs[p] > 0
+}
+
+// checkWrite returns an error if a file cannot be written at p because p is a
+// directory or one of its ancestors is a file.
+func (t *tree) checkWrite(p string) error {
+	if t.isDir(p) {
+		return errors.Errorf("cannot write %s: a directory exists at that path", p)
+	}
+	for _, d := range ancestors(p) {
+		if _, ok := t.files[d]; ok {
+			return ErrNotADirectory{Path: p, Parent: d}
+		}
+	}
+	return nil
+}
+
+func (t *tree) apply(op fileOp) {
+	switch op.kind {
+	case opAdd:
+		nf := &file{}
+		if old, ok := t.files[op.path]; ok {
+			nf.parts = append(nf.parts, old.parts...)
+		}
+		i := sort.Search(len(nf.parts), func(i int) bool { return nf.parts[i].datum >= op.datum })
+		if i < len(nf.parts) && nf.parts[i].datum == op.datum {
+			old := nf.parts[i].refs
+			nf.parts[i] = &filePart{datum: op.datum, refs: append(old[:len(old):len(old)], op.refs...)}
+		} else {
+			nf.parts = append(nf.parts, nil)
+			copy(nf.parts[i+1:], nf.parts[i:])
+			nf.parts[i] = &filePart{datum: op.datum, refs: op.refs}
+		}
+		t.set(op.path, nf)
+	case opPut:
+		t.set(op.path, &file{parts: op.file.parts})
+	case opDelete:
+		if op.datum != "" {
+			old, ok := t.files[op.path]
+			if !ok {
+				return
+			}
+			nf := &file{}
+			for _, p := range old.parts {
+				if p.datum != op.datum {
+					nf.parts = append(nf.parts, p)
+				}
+			}
+			if len(nf.parts) == 0 {
+				t.remove(op.path)
+			} else {
+				t.set(op.path, nf)
+			}
+			return
+		}
+		if _, ok := t.files[op.path]; ok {
+			t.remove(op.path)
+			return
+		}
+		if t.isDir(op.path) {
+			for _, p := range t.under(op.path) {
+				t.remove(p)
+			}
+		}
+	}
+}
+
+func (t *tree) set(p string, f *file) {
+	if old, ok := t.files[p]; ok {
+		t.unref(old)
+	} else {
+		parent := "/"
+		for _, d := range ancestors(p) {
+			t.dirs[d]++
+			t.addEntry(parent, d)
+			parent = d
+		}
+		t.addEntry(parent, p)
+	}
+	t.ref(f)
+	t.files[p] = f
+}
+
+func (t *tree) remove(p string) {
+	f, ok := t.files[p]
+	if !ok {
+		return
+	}
+	t.unref(f)
+	delete(t.files, p)
+	t.removeEntry(p)
+	for _, d := range ancestors(p) {
+		if t.dirs[d]--; t.dirs[d] == 0 {
+			delete(t.dirs, d)
+			t.removeEntry(d)
+		}
+	}
+}
+
+// addEntry records p as directly beneath the directory dir.
+func (t *tree) addEntry(dir, p string) {
+	es, ok := t.entries[dir]
+	if !ok {
+		es = make(map[string]bool)
+		t.entries[dir] = es
+	}
+	es[p] = true
+}
+
+// removeEntry forgets p as an entry of its directory.
+func (t *tree) removeEntry(p string) {
+	dir := path.Dir(p)
+	delete(t.entries[dir], p)
+	if len(t.entries[dir]) == 0 {
+		delete(t.entries, dir)
+	}
+}
+
+// ref adds f's content to t's sizes.
+func (t *tree) ref(f *file) {
+	for _, ref := range f.dataRefs() {
+		t.sizeBytes += ref.SizeBytes
+		k := newDataRefKey(ref)
+		if t.refs[k]++; t.refs[k] == 1 {
+			t.dedupBytes += ref.SizeBytes
+		}
+	}
+}
+
+// unref removes f's content from t's sizes.
+func (t *tree) unref(f *file) {
+	for _, ref := range f.dataRefs() {
+		t.sizeBytes -= ref.SizeBytes
+		k := newDataRefKey(ref)
+		if t.refs[k]--; t.refs[k] == 0 {
+			delete(t.refs, k)
+			t.dedupBytes -= ref.SizeBytes
+		}
+	}
+}
+
+// recount returns a tree holding only the sizes of t's files, counted from
+// scratch.
+func (t *tree) recount() *tree {
+	c := newTree()
+	for _, f := range t.files {
+		c.ref(f)
+	}
+	return c
+}
+
+// checkSizes recounts t's sizes from its files, and returns an error if the
+// running totals have drifted from the recount. The totals are left as they
+// are; fsck corrects them.
+func (t *tree) checkSizes() error {
+	c := t.recount()
+	if c.sizeBytes == t.sizeBytes && c.dedupBytes == t.dedupBytes && len(c.refs) == len(t.refs) {
+		return nil
+	}
+	return errors.Errorf("tree sizes drifted: counted %d bytes (%d deduplicated) but recounted %d (%d deduplicated)", t.sizeBytes, t.dedupBytes, c.sizeBytes, c.dedupBytes)
+}
+
+// recountSizes replaces t's running totals with a recount from its files.
+func (t *tree) recountSizes() {
+	c := t.recount()
+	t.sizeBytes, t.dedupBytes, t.refs = c.sizeBytes, c.dedupBytes, c.refs
+}
+
+// under returns the sorted paths of all files at or beneath p. It only
+// looks at the directories beneath p.
+func (t *tree) under(p string) []string {
+	if _, ok := t.files[p]; ok {
+		return []string{p}
+	}
+	var paths []string
+	t.walk(p, func(fp string) error {
+		if _, ok := t.files[fp]; ok {
+			paths = append(paths, fp)
+		}
+		return nil
+	})
+	return paths
+}
+
+// fileInfo returns the FileInfo for the file or directory at p, or nil if
+// nothing exists there.
+func (t *tree) fileInfo(commit *pfs.Commit, p string) *pfs.FileInfo {
+	if f, ok := t.files[p]; ok {
+		return &pfs.FileInfo{
+			File:      commit.NewFile(p),
+			FileType:  pfs.FileType_FILE,
+			Committed: f.committed,
+			SizeBytes: f.size(),
+			Hash:      f.hash(),
+		}
+	}
+	if !t.isDir(p) {
+		return nil
+	}
+	info := &pfs.FileInfo{
+		File:     commit.NewFile(dirPrefix(p)),
+		FileType: pfs.FileType_DIR,
+	}
+	h := pfs.NewHash()
+	prefix := dirPrefix(p)
+	for _, fp := range t.under(p) {
+		f := t.files[fp]
+		info.SizeBytes += f.size()
+		if f.committed != nil && (info.Committed == nil || f.committed.AsTime().After(info.Committed.AsTime())) {
+			info.Committed = f.committed
+		}
+		h.Write([]byte(strings.TrimPrefix(fp, prefix)))
+		h.Write(f.hash())
+	}
+	info.Hash = h.Sum(nil)
+	return info
+}
+
+// children returns the sorted paths of the files and directories directly
+// beneath the directory p. Directory paths do not carry a trailing slash.
+func (t *tree) children(p string) []string {
+	paths := make([]string, 0, len(t.entries[p]))
+	for e := range t.entries[p] {
+		paths = append(paths, e)
+	}
+	sort.Slice(paths, func(i, j int) bool { return displayPath(t, paths[i]) < displayPath(t, paths[j]) })
+	return paths
+}
+
+// walk calls f with every file and directory at or beneath p, in
+// lexicographic order of their display paths (directories end in "/"). Only
+// the directories beneath p are visited.
+func (t *tree) walk(p string, f func(p string) error) error {
+	if _, ok := t.files[p]; ok {
+		return f(p)
+	}
+	if !t.isDir(p) {
+		return nil
+	}
+	if err := f(p); err != nil {
+		return err
+	}
+	for _, child := range t.children(p) {
+		if err := t.walk(child, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ancestors returns the non-root directories containing p, outermost first.
+func ancestors(p string) []string {
+	var ds []string
+	for i := 1; i < len(p); i++ {
+		if p[i] == '/' {
+			ds = append(ds, p[:i])
+		}
+	}
+	return ds
+}
+
+// dirPrefix returns the prefix shared by every path beneath directory p.
+func dirPrefix(p string) string {
+	if p == "/" {
+		return p
+	}
+	return p + "/"
+}
+
+func displayPath(t *tree, p string) string {
+	if t.isDir(p) {
+		return dirPrefix(p)
+	}
+	return p
+}
@@ -0,0 +1,183 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/robfig/cron/v3"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// A branch with a Trigger follows another branch of its repo, but its head
+// only moves forward to the other branch's head once the trigger's conditions
+// are met. Triggers are evaluated whenever a commit finishes on the branch
+// they watch, so a cron_spec tick takes effect with the first commit after it.
+
+// validateTrigger checks that trigger is well formed for branch.
+func validateTrigger(branch *pfs.Branch, trigger *pfs.Trigger) error {
+	if trigger.Branch == "" {
+		return errors.Errorf("trigger of branch %v must name a branch", branch)
+	}
+	if trigger.Branch == branch.Name {
+		return errors.Errorf("branch %v cannot trigger on itself", branch)
+	}
+	if err := ValidateBranchName(trigger.Branch); err != nil {
+		return err
+	}
+	if trigger.CronSpec == "" && trigger.Size == "" && trigger.Commits == 0 {
+		return errors.Errorf("trigger of branch %v must set at least one of cron_spec, size and commits", branch)
+	}
+	if trigger.Commits < 0 {
+		return errors.Errorf("trigger of branch %v has negative commits %d", branch, trigger.Commits)
+	}
+	if trigger.Size != "" {
+		if _, err := units.FromHumanSize(trigger.Size); err != nil {
+			return errors.Wrapf(err, "trigger of branch %v has invalid size", branch)
+		}
+	}
+	if trigger.CronSpec != "" {
+		if _, err := cron.ParseStandard(trigger.CronSpec); err != nil {
+			return errors.Wrapf(err, "trigger of branch %v has invalid cron_spec", branch)
+		}
+	}
+	return nil
+}
+
+// checkTriggerCycle returns an error if following triggers from the branch
+// named trigger.Branch leads back to branch.
+func (tx *txn) checkTriggerCycle(branch *pfs.Branch, trigger *pfs.Trigger) error {
+	seen := map[string]bool{branch.Name: true}
+	for name := trigger.Branch; name != ""; {
+		if seen[name] {
+			return errors.Errorf("trigger of branch %v would create a trigger cycle through %s", branch, name)
+		}
+		seen[name] = true
+		bi, err := tx.getBranchInfo(branch.Repo.NewBranch(name))
+		if err != nil || bi.Trigger == nil {
+			return nil
+		}
+		name = bi.Trigger.Branch
+	}
+	return nil
+}
+
+// fireTriggers moves the heads of the branches triggered by the head of
+// branch, and so on downstream through any branches triggered by them.
+func (tx *txn) fireTriggers(branch *pfs.Branch) error {
+	bi, err := tx.getBranchInfo(branch)
+	if err != nil {
+		if IsBranchNotFoundErr(err) {
+			return nil
+		}
+		return err
+	}
+	newHead, ok := tx.getCommitInfoByKey(bi.Head.Key())
+	if !ok || newHead.Finished == nil {
+		return nil
+	}
+	for _, tbi := range tx.listBranchInfos(branch.Repo) {
+		if tbi.Trigger == nil || tbi.Trigger.Branch != branch.Name {
+			continue
+		}
+		if err := tx.fireTrigger(tbi, newHead); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fireTrigger moves the head of the triggered branch bi to newHead, if its
+// trigger's conditions are met.
+func (tx *txn) fireTrigger(bi *pfs.BranchInfo, newHead *pfs.CommitInfo) error {
+	var oldHead *pfs.CommitInfo
+	if bi.Head != nil {
+		if bi.Head.Key() == newHead.Commit.Key() {
+			return nil
+		}
+		oldHead, _ = tx.getCommitInfoByKey(bi.Head.Key())
+	}
+	triggered, err := tx.isTriggered(bi.Trigger, newHead, oldHead)
+	if err != nil || !triggered {
+		return err
+	}
+	bi.Head = newHead.Commit
+	tx.putBranchInfo(bi)
+	if err := tx.propagate([]*pfs.Branch{bi.Branch}, newCommitSetID()); err != nil {
+		return err
+	}
+	return tx.fireTriggers(bi.Branch)
+}
+
+// isTriggered returns true if moving a triggered branch from oldHead, which
+// may be nil, to newHead satisfies trigger. The conditions are combined with
+// AND if trigger.All is set, and with OR otherwise.
+func (tx *txn) isTriggered(trigger *pfs.Trigger, newHead, oldHead *pfs.CommitInfo) (bool, error) {
+	result := trigger.All
+	merge := func(cond bool) {
+		if trigger.All {
+			result = result && cond
+		} else {
+			result = result || cond
+		}
+	}
+	if trigger.Size != "" {
+		size, err := units.FromHumanSize(trigger.Size)
+		if err != nil {
+			return false, errors.EnsureStack(err)
+		}
+		var oldSize int64
+		if oldHead != nil {
+			oldSize = tx.commitFiles(oldHead.Commit).tree.size()
+		}
+		merge(tx.commitFiles(newHead.Commit).tree.size()-oldSize >= size)
+	}
+	if trigger.CronSpec != "" {
+		schedule, err := cron.ParseStandard(trigger.CronSpec)
+		if err != nil {
+			return false, errors.EnsureStack(err)
+		}
+		var oldTime time.Time
+		if oldHead != nil && oldHead.Finishing != nil {
+			oldTime = oldHead.Finishing.AsTime()
+		}
+		merge(!schedule.Next(oldTime).After(newHead.Finishing.AsTime()))
+	}
+	if trigger.Commits != 0 {
+		// count the commits between the old head and the new one
+		var commits int64
+		for ci := newHead; ci != nil && commits < trigger.Commits; {
+			if oldHead != nil && ci.Commit.Key() == oldHead.Commit.Key() {
+				break
+			}
+			commits++
+			if ci.ParentCommit == nil {
+				break
+			}
+			ci, _ = tx.getCommitInfoByKey(ci.ParentCommit.Key())
+		}
+		merge(commits >= trigger.Commits)
+	}
+	return result, nil
+}
@@ -0,0 +1,145 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func createTriggeredBranch(c pfs.APIClient, branch *pfs.Branch, trigger *pfs.Trigger) error {
+	_, err := c.CreateBranch(context.Background(), &pfs.CreateBranchRequest{Branch: branch, Trigger: trigger})
+	return err
+}
+
+func TestTriggerCommits(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	require.NoError(t, createTriggeredBranch(c, repo.NewBranch("staging"), &pfs.Trigger{Branch: "master", Commits: 3}))
+	initial := inspectBranch(t, c, repo.NewBranch("staging")).Head
+
+	var last *pfs.Commit
+	for i := 0; i < 3; i++ {
+		require.Equal(t, initial.Id, inspectBranch(t, c, repo.NewBranch("staging")).Head.Id)
+		last = startCommit(t, c, repo, "master")
+		finishCommit(t, c, last)
+	}
+	require.Equal(t, last.Id, inspectBranch(t, c, repo.NewBranch("staging")).Head.Id)
+
+	// the count starts over from the new head
+	for i := 0; i < 2; i++ {
+		finishCommit(t, c, startCommit(t, c, repo, "master"))
+	}
+	require.Equal(t, last.Id, inspectBranch(t, c, repo.NewBranch("staging")).Head.Id)
+}
+
+func TestTriggerSize(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	require.NoError(t, createTriggeredBranch(c, repo.NewBranch("staging"), &pfs.Trigger{Branch: "master", Size: "1KB"}))
+	initial := inspectBranch(t, c, repo.NewBranch("staging")).Head
+
+	commit := startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/a", strings.Repeat("a", 600))
+	finishCommit(t, c, commit)
+	require.Equal(t, initial.Id, inspectBranch(t, c, repo.NewBranch("staging")).Head.Id)
+
+	commit = startCommit(t, c, repo, "master")
+	putFile(t, c, commit, "/b", strings.Repeat("b", 600))
+	finishCommit(t, c, commit)
+	require.Equal(t, commit.Id, inspectBranch(t, c, repo.NewBranch("staging")).Head.Id)
+}
+
+func TestTriggerPropagates(t *testing.T) {
+	c := newTestClient(t)
+	a := createRepo(t, c, "a")
+	b := createRepo(t, c, "b")
+	require.NoError(t, createTriggeredBranch(c, a.NewBranch("staging"), &pfs.Trigger{Branch: "master", Commits: 1}))
+	createBranch(t, c, b.NewBranch("master"), a.NewBranch("staging"))
+	before := inspectBranch(t, c, b.NewBranch("master")).Head
+
+	// starting a commit on master doesn't move staging until it finishes
+	commit := startCommit(t, c, a, "master")
+	require.Equal(t, before.Id, inspectBranch(t, c, b.NewBranch("master")).Head.Id)
+	finishCommit(t, c, commit)
+	after := inspectBranch(t, c, b.NewBranch("master")).Head
+	require.NotEqual(t, before.Id, after.Id)
+	ci, err := c.InspectCommit(context.Background(), &pfs.InspectCommitRequest{Commit: after})
+	require.NoError(t, err)
+	require.Equal(t, pfs.OriginKind_AUTO, ci.Origin.Kind)
+}
+
+func TestTriggerValidation(t *testing.T) {
+	c := newTestClient(t)
+	repo := createRepo(t, c, "data")
+	staging := repo.NewBranch("staging")
+	require.YesError(t, createTriggeredBranch(c, staging, &pfs.Trigger{Branch: "staging", Commits: 1}))
+	require.YesError(t, createTriggeredBranch(c, staging, &pfs.Trigger{Branch: "master"}))
+	require.YesError(t, createTriggeredBranch(c, staging, &pfs.Trigger{Branch: "master", Size: "lots"}))
+	require.YesError(t, createTriggeredBranch(c, staging, &pfs.Trigger{Branch: "master", CronSpec: "every hour"}))
+	_, err := c.CreateBranch(context.Background(), &pfs.CreateBranchRequest{
+		Branch:     staging,
+		Provenance: []*pfs.Branch{createRepo(t, c, "other").NewBranch("master")},
+		Trigger:    &pfs.Trigger{Branch: "master", Commits: 1},
+	})
+	require.YesError(t, err)
+
+	require.NoError(t, createTriggeredBranch(c, staging, &pfs.Trigger{Branch: "master", Commits: 1}))
+	require.YesError(t, createTriggeredBranch(c, repo.NewBranch("master"), &pfs.Trigger{Branch: "staging", Commits: 1}))
+}
+
+func TestIsTriggered(t *testing.T) {
+	tx := newTxn(newDriver(nil, nil))
+	hourAgo := time.Now().Add(-time.Hour)
+	commitAt := func(tm time.Time) *pfs.CommitInfo {
+		return &pfs.CommitInfo{
+			Commit:    newRepo("data").NewCommit("master", newCommitSetID()),
+			Finishing: timestamppb.New(tm),
+		}
+	}
+	oldHead, newHead := commitAt(hourAgo), commitAt(time.Now())
+	for _, c := range []struct {
+		trigger *pfs.Trigger
+		want    bool
+	}{
+		{&pfs.Trigger{CronSpec: "@hourly"}, true},
+		{&pfs.Trigger{CronSpec: "@daily"}, hourAgo.UTC().Day() != time.Now().UTC().Day()},
+		{&pfs.Trigger{CronSpec: "@every 30m"}, true},
+		{&pfs.Trigger{CronSpec: "@every 2h"}, false},
+		// newHead's parent isn't known, so it counts as a single new commit
+		{&pfs.Trigger{Commits: 1}, true},
+		{&pfs.Trigger{Commits: 2}, false},
+		{&pfs.Trigger{Commits: 2, CronSpec: "@every 30m"}, true},
+		{&pfs.Trigger{Commits: 2, CronSpec: "@every 30m", All: true}, false},
+		{&pfs.Trigger{Commits: 1, CronSpec: "@every 30m", All: true}, true},
+	} {
+		got, err := tx.isTriggered(c.trigger, newHead, oldHead)
+		require.NoError(t, err)
+		require.Equal(t, c.want, got, "trigger %v", c.trigger)
+	}
+}
@@ -0,0 +1,287 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/storage/chunk"
+	"github.com/bhojpur/data/pkg/internal/uuid"
+)
+
+// defaultUploadTTL is how long an upload session lives without being used.
+const defaultUploadTTL = 24 * time.Hour
+
+// upload is a resumable upload session. Its parts are stored in chunk storage
+// as they arrive, and the file is only written to the commit by
+// finishUpload. Sessions are kept in memory and do not survive a restart. A
+// session that is not used for the driver's uploadTTL expires, and its parts
+// are garbage collected.
+type upload struct {
+	// mu guards the state of an upload. It is not held while a part is
+	// being received.
+	mu   sync.Mutex
+	info *pfs.UploadInfo
+	// refs and hashes hold the content and checksum of each received part.
+	// refs and expires are also guarded by the driver's uploadsMu, so that
+	// garbage collection can read them without taking the lock of every
+	// upload.
+	refs    [][]chunk.DataRef
+	hashes  [][]byte
+	expires time.Time
+	// closed is set once the upload has been finished or aborted.
+	closed bool
+}
+
+// startUpload starts a session for uploading size bytes to the file at p in
+// an open commit. The commit is resolved now, so the file is written to the
+// same commit even if the branch moves before the upload finishes.
+func (d *driver) startUpload(file *pfs.File, datum string, size int64) (*pfs.UploadInfo, error) {
+	p, err := ValidatePath(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		return nil, errors.Errorf("invalid upload size %d", size)
+	}
+	var commit *pfs.Commit
+	if err := d.read(func(tx *txn) error {
+		ci, cf, err := tx.openCommitFiles(file.Commit)
+		if err != nil {
+			return err
+		}
+		if _, ok := cf.tree.files[p]; !ok {
+			if err := cf.tree.checkWrite(p); err != nil {
+				return err
+			}
+		}
+		commit = ci.Commit
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	u := &upload{info: &pfs.UploadInfo{
+		Id:            uuid.NewWithoutDashes(),
+		File:          &pfs.File{Commit: commit, Path: p},
+		Datum:         datum,
+		SizeBytes:     size,
+		PartSizeBytes: d.uploadPartSize,
+		Started:       timestamppb.Now(),
+	}}
+	d.uploadsMu.Lock()
+	defer d.uploadsMu.Unlock()
+	u.expires = time.Now().Add(d.uploadTTL)
+	d.uploads[u.info.Id] = u
+	return proto.Clone(u.info).(*pfs.UploadInfo), nil
+}
+
+// lockUpload returns the open upload with the given ID, locked, and keeps it
+// alive for another uploadTTL.
+func (d *driver) lockUpload(id string) (*upload, error) {
+	d.uploadsMu.Lock()
+	u, ok := d.uploads[id]
+	if ok && !time.Now().Before(u.expires) {
+		delete(d.uploads, id)
+		ok = false
+	}
+	if ok {
+		u.expires = time.Now().Add(d.uploadTTL)
+	}
+	d.uploadsMu.Unlock()
+	if !ok {
+		return nil, ErrUploadNotFound{ID: id}
+	}
+	u.mu.Lock()
+	if u.closed {
+		u.mu.Unlock()
+		return nil, ErrUploadNotFound{ID: id}
+	}
+	return u, nil
+}
+
+// closeUpload removes a locked upload from the driver.
+func (d *driver) closeUpload(u *upload) {
+	u.closed = true
+	d.uploadsMu.Lock()
+	defer d.uploadsMu.Unlock()
+	delete(d.uploads, u.info.Id)
+}
+
+// inspectUpload returns the state of an upload.
+func (d *driver) inspectUpload(id string) (*pfs.UploadInfo, error) {
+	u, err := d.lockUpload(id)
+	if err != nil {
+		return nil, err
+	}
+	defer u.mu.Unlock()
+	return proto.Clone(u.info).(*pfs.UploadInfo), nil
+}
+
+// putUploadPart receives the part of an upload at header.Offset from r. The
+// next part is stored and acknowledged if its length and hash are right. A
+// part that was already acknowledged may be sent again, in case the
+// acknowledgement was lost, as long as its hash has not changed. The part is
+// received without holding the upload's lock, so that a client resuming over
+// a new connection is not held up by an attempt stuck on a dead one; the
+// first attempt at an offset to be received in full is the one kept.
+func (d *driver) putUploadPart(ctx context.Context, header *pfs.UploadPartHeader, r io.Reader) (*pfs.UploadInfo, error) {
+	u, err := d.lockUpload(header.UploadId)
+	if err != nil {
+		return nil, err
+	}
+	info, err := u.checkPart(header)
+	if err != nil || info != nil {
+		u.mu.Unlock()
+		if err == nil {
+			_, err = io.Copy(io.Discard, r)
+		}
+		return info, errors.EnsureStack(err)
+	}
+	size := u.info.SizeBytes - header.Offset
+	if size > u.info.PartSizeBytes {
+		size = u.info.PartSizeBytes
+	}
+	id := u.info.Id
+	u.mu.Unlock()
+
+	// reading one byte past the part is enough to tell that it is too long
+	h := pfs.NewHash()
+	cr := &countReader{r: io.TeeReader(io.LimitReader(r, size+1), h)}
+	refs, err := d.storage.Upload(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	if cr.n != size {
+		return nil, errors.Errorf("part at offset %d of upload %s has %d bytes or more, expected %d", header.Offset, id, cr.n, size)
+	}
+	if sum := h.Sum(nil); !bytes.Equal(header.Hash, sum) {
+		return nil, errors.Errorf("part at offset %d of upload %s has hash %s, expected %s", header.Offset, id, pfs.EncodeHash(sum), pfs.EncodeHash(header.Hash))
+	}
+
+	if u, err = d.lockUpload(id); err != nil {
+		return nil, err
+	}
+	defer u.mu.Unlock()
+	// another attempt may have stored the part in the meantime
+	if info, err := u.checkPart(header); err != nil || info != nil {
+		return info, err
+	}
+	d.uploadsMu.Lock()
+	u.refs = append(u.refs, refs)
+	d.uploadsMu.Unlock()
+	u.hashes = append(u.hashes, header.Hash)
+	u.info.CommittedBytes += size
+	return proto.Clone(u.info).(*pfs.UploadInfo), nil
+}
+
+// checkPart checks that header is for the next part of a locked upload, or
+// for a part it already received with the same hash, in which case it
+// returns the upload's state.
+func (u *upload) checkPart(header *pfs.UploadPartHeader) (*pfs.UploadInfo, error) {
+	info := u.info
+	if header.Offset < 0 || header.Offset%info.PartSizeBytes != 0 || header.Offset >= info.SizeBytes {
+		return nil, errors.Errorf("invalid offset %d for upload %s: parts start at multiples of %d below %d", header.Offset, info.Id, info.PartSizeBytes, info.SizeBytes)
+	}
+	if header.Offset > info.CommittedBytes {
+		return nil, errors.Errorf("part at offset %d of upload %s is out of order: the next part starts at %d", header.Offset, info.Id, info.CommittedBytes)
+	}
+	if header.Offset < info.CommittedBytes {
+		if !bytes.Equal(header.Hash, u.hashes[header.Offset/info.PartSizeBytes]) {
+			return nil, errors.Errorf("part at offset %d of upload %s was already received with a different hash", header.Offset, info.Id)
+		}
+		return proto.Clone(info).(*pfs.UploadInfo), nil
+	}
+	return nil, nil
+}
+
+// finishUpload writes a complete upload to its commit, replacing what was at
+// the file under the upload's datum, and closes the upload.
+func (d *driver) finishUpload(id string) error {
+	u, err := d.lockUpload(id)
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	info := u.info
+	if info.CommittedBytes != info.SizeBytes {
+		return errors.Errorf("cannot finish upload %s: only %d of %d bytes have been received", id, info.CommittedBytes, info.SizeBytes)
+	}
+	var refs []chunk.DataRef
+	for _, partRefs := range u.refs {
+		refs = append(refs, partRefs...)
+	}
+	p := info.File.Path
+	if err := (commitTarget{info.File.Commit}).modify(d, func(*txn, *tree) ([]fileOp, error) {
+		return []fileOp{
+			{kind: opDelete, path: p, datum: info.Datum},
+			{kind: opAdd, path: p, datum: info.Datum, refs: refs},
+		}, nil
+	}); err != nil {
+		return err
+	}
+	d.closeUpload(u)
+	return nil
+}
+
+// abortUpload discards an upload. Parts it already stored are left in chunk
+// storage.
+func (d *driver) abortUpload(id string) error {
+	u, err := d.lockUpload(id)
+	if err != nil {
+		return err
+	}
+	defer u.mu.Unlock()
+	d.closeUpload(u)
+	return nil
+}
+
+// expireUploads deletes the upload sessions that have expired.
+func (d *driver) expireUploads() {
+	d.uploadsMu.Lock()
+	defer d.uploadsMu.Unlock()
+	now := time.Now()
+	for id, u := range d.uploads {
+		if !now.Before(u.expires) {
+			delete(d.uploads, id)
+		}
+	}
+}
+
+// countReader counts the bytes read through it.
+type countReader struct {
+	r io.Reader
+	n int64
+}
+
+func (r *countReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,127 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"path"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+var validNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ValidateRepoName determines if a repo name is valid.
+func ValidateRepoName(name string) error {
+	if !validNameRe.MatchString(name) {
+		return errors.Errorf("repo name (%v) invalid: only alphanumeric characters, underscores, and dashes are allowed", name)
+	}
+	return nil
+}
+
+// ValidateBranchName determines if a branch name is valid.
+func ValidateBranchName(name string) error {
+	if !validNameRe.MatchString(name) {
+		return errors.Errorf("branch name (%v) invalid: only alphanumeric characters, underscores, and dashes are allowed", name)
+	}
+	return nil
+}
+
+// ValidatePath determines if a file path is valid and returns its canonical
+// form: rooted at "/" with no trailing slash.
+func ValidatePath(p string) (string, error) {
+	for _, r := range p {
+		if r > unicode.MaxASCII || !unicode.IsPrint(r) {
+			return "", errors.Errorf("path (%v) invalid: only printable ASCII characters allowed", p)
+		}
+	}
+	clean := cleanPath(p)
+	if clean == "/" {
+		return "", errors.Errorf("path (%v) invalid: the root directory is not allowed in path", p)
+	}
+	return clean, nil
+}
+
+// cleanPath canonicalizes a path so that "", "/", "a/b/" and "/a//b" map to
+// "/", "/", "/a/b" and "/a/b" respectively.
+func cleanPath(p string) string {
+	return path.Clean("/" + strings.TrimSpace(p))
+}
+
+// normalizeRepo fills in the defaults of a client supplied repo, so that it
+// can be used as a key.
+func normalizeRepo(repo *pfs.Repo) {
+	if repo != nil && repo.Type == "" {
+		repo.Type = pfs.UserRepoType
+	}
+}
+
+func normalizeBranch(branch *pfs.Branch) {
+	if branch != nil {
+		normalizeRepo(branch.Repo)
+	}
+}
+
+func normalizeCommit(commit *pfs.Commit) {
+	if commit != nil {
+		normalizeBranch(commit.Branch)
+	}
+}
+
+func validateRepo(repo *pfs.Repo) error {
+	if repo == nil {
+		return errors.New("repo cannot be nil")
+	}
+	normalizeRepo(repo)
+	return nil
+}
+
+func validateBranch(branch *pfs.Branch) error {
+	if branch == nil {
+		return errors.New("branch cannot be nil")
+	}
+	if branch.Repo == nil {
+		return errors.New("branch repo cannot be nil")
+	}
+	normalizeBranch(branch)
+	return nil
+}
+
+func validateCommit(commit *pfs.Commit) error {
+	if commit == nil {
+		return errors.New("commit cannot be nil")
+	}
+	if commit.Branch == nil || commit.Branch.Repo == nil {
+		return errors.New("commit repo cannot be nil")
+	}
+	normalizeCommit(commit)
+	return nil
+}
+
+func validateFile(file *pfs.File) error {
+	if file == nil {
+		return errors.New("file cannot be nil")
+	}
+	return validateCommit(file.Commit)
+}
@@ -0,0 +1,170 @@
+// Package server implements the PPS API. So far only secrets are
+// implemented; every other RPC returns codes.Unimplemented.
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/task"
+)
+
+// APIServer is a PPS API server.
+type APIServer interface {
+	pps.APIServer
+	// GetSecret returns the value of key in the secret name. It can be used
+	// as the SecretGetter of a PFS API server.
+	GetSecret(ctx context.Context, name, key string) ([]byte, error)
+	// ProcessDatums processes the datums of a job through the server's
+	// in-process task queue, whose tasks ListTask lists.
+	ProcessDatums(ctx context.Context, job *pps.Job, dc *DatumCache, datums [][]*pps.InputFile, f DatumFunc) ([]string, error)
+}
+
+type secret struct {
+	info *pps.SecretInfo
+	data map[string][]byte
+}
+
+type apiServer struct {
+	pps.UnimplementedAPIServer
+	mu      sync.Mutex
+	secrets map[string]*secret
+	tasks   *task.Service
+}
+
+// NewAPIServer creates a PPS APIServer. Secrets are kept in memory and do not
+// survive a restart.
+func NewAPIServer() APIServer {
+	return &apiServer{secrets: make(map[string]*secret), tasks: task.NewService(task.Options{})}
+}
+
+// CreateSecret implements the protobuf pps.CreateSecret RPC. The file is a
+// Kubernetes Secret manifest, in JSON or YAML.
+func (a *apiServer) CreateSecret(ctx context.Context, request *pps.CreateSecretRequest) (*emptypb.Empty, error) {
+	var s corev1.Secret
+	if err := yaml.Unmarshal(request.File, &s); err != nil {
+		return nil, errors.Wrapf(err, "invalid secret manifest")
+	}
+	if s.Kind != "" && s.Kind != "Secret" {
+		return nil, errors.Errorf("invalid secret manifest: kind is %s rather than Secret", s.Kind)
+	}
+	if s.Name == "" {
+		return nil, errors.New("invalid secret manifest: the secret has no name")
+	}
+	data := make(map[string][]byte, len(s.Data)+len(s.StringData))
+	for k, v := range s.Data {
+		data[k] = v
+	}
+	// as in Kubernetes, stringData takes precedence over data
+	for k, v := range s.StringData {
+		data[k] = []byte(v)
+	}
+	secretType := string(s.Type)
+	if secretType == "" {
+		secretType = string(corev1.SecretTypeOpaque)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.secrets[s.Name]; ok {
+		return nil, ErrSecretExists{Name: s.Name}
+	}
+	a.secrets[s.Name] = &secret{
+		info: &pps.SecretInfo{
+			Secret:            &pps.Secret{Name: s.Name},
+			Type:              secretType,
+			CreationTimestamp: timestamppb.Now(),
+		},
+		data: data,
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteSecret implements the protobuf pps.DeleteSecret RPC
+func (a *apiServer) DeleteSecret(ctx context.Context, request *pps.DeleteSecretRequest) (*emptypb.Empty, error) {
+	if request.Secret == nil {
+		return nil, errors.New("secret cannot be nil")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.secrets[request.Secret.Name]; !ok {
+		return nil, ErrSecretNotFound{Name: request.Secret.Name}
+	}
+	delete(a.secrets, request.Secret.Name)
+	return &emptypb.Empty{}, nil
+}
+
+// InspectSecret implements the protobuf pps.InspectSecret RPC
+func (a *apiServer) InspectSecret(ctx context.Context, request *pps.InspectSecretRequest) (*pps.SecretInfo, error) {
+	if request.Secret == nil {
+		return nil, errors.New("secret cannot be nil")
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.secrets[request.Secret.Name]
+	if !ok {
+		return nil, ErrSecretNotFound{Name: request.Secret.Name}
+	}
+	return s.info, nil
+}
+
+// ListSecret implements the protobuf pps.ListSecret RPC
+func (a *apiServer) ListSecret(ctx context.Context, _ *emptypb.Empty) (*pps.SecretInfos, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	infos := &pps.SecretInfos{}
+	for _, s := range a.secrets {
+		infos.SecretInfo = append(infos.SecretInfo, s.info)
+	}
+	sort.Slice(infos.SecretInfo, func(i, j int) bool {
+		return infos.SecretInfo[i].Secret.Name < infos.SecretInfo[j].Secret.Name
+	})
+	return infos, nil
+}
+
+func (a *apiServer) GetSecret(ctx context.Context, name, key string) ([]byte, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s, ok := a.secrets[name]
+	if !ok {
+		return nil, ErrSecretNotFound{Name: name}
+	}
+	v, ok := s.data[key]
+	if !ok {
+		return nil, ErrSecretKeyNotFound{Name: name, Key: key}
+	}
+	return v, nil
+}
+
+// ListTask implements the protobuf pps.ListTask RPC
+func (a *apiServer) ListTask(request *taskapi.ListTaskRequest, srv pps.API_ListTaskServer) error {
+	return a.tasks.List(request.Group, srv.Send)
+}
@@ -0,0 +1,79 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func TestSecrets(t *testing.T) {
+	a := NewAPIServer()
+	ctx := context.Background()
+	create := func(manifest string) error {
+		_, err := a.CreateSecret(ctx, &pps.CreateSecretRequest{File: []byte(manifest)})
+		return err
+	}
+	require.NoError(t, create(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: db
+data:
+  password: aHVudGVyMg==
+  user: YWxpY2U=
+stringData:
+  user: bob
+`))
+	require.NoError(t, create(`{"kind": "Secret", "metadata": {"name": "api"}, "type": "kubernetes.io/basic-auth", "stringData": {"token": "t"}}`))
+	require.YesError(t, create(`{"kind": "Secret", "metadata": {"name": "db"}}`))
+	require.YesError(t, create(`{"kind": "ConfigMap", "metadata": {"name": "cm"}}`))
+	require.YesError(t, create(`{"kind": "Secret"}`))
+
+	v, err := a.GetSecret(ctx, "db", "password")
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", string(v))
+	v, err = a.GetSecret(ctx, "db", "user")
+	require.NoError(t, err)
+	require.Equal(t, "bob", string(v))
+	_, err = a.GetSecret(ctx, "db", "token")
+	require.YesError(t, err)
+
+	info, err := a.InspectSecret(ctx, &pps.InspectSecretRequest{Secret: &pps.Secret{Name: "db"}})
+	require.NoError(t, err)
+	require.Equal(t, "Opaque", info.Type)
+	infos, err := a.ListSecret(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(infos.SecretInfo))
+	require.Equal(t, "api", infos.SecretInfo[0].Secret.Name)
+	require.Equal(t, "kubernetes.io/basic-auth", infos.SecretInfo[0].Type)
+
+	_, err = a.DeleteSecret(ctx, &pps.DeleteSecretRequest{Secret: &pps.Secret{Name: "db"}})
+	require.NoError(t, err)
+	_, err = a.GetSecret(ctx, "db", "password")
+	_, ok := err.(ErrSecretNotFound)
+	require.True(t, ok)
+}
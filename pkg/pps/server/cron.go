@@ -0,0 +1,129 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+// cronBranch is the branch cron inputs commit to.
+const cronBranch = "master"
+
+// RunCron commits to the repo of a cron input on every tick of its schedule,
+// until ctx is done. Each tick either commits an empty file named after the
+// tick time or, if the input has an SQL ingest, a snapshot of its query. If
+// the input overwrites, each tick replaces what the previous one committed.
+// Ticks before the input's start time are skipped, and ticks missed while a
+// previous one was still running are not made up. A failed tick is logged
+// and does not stop later ones.
+func RunCron(ctx context.Context, c pfs.APIClient, input *pps.CronInput) error {
+	schedule, err := cron.ParseStandard(input.Spec)
+	if err != nil {
+		return errors.Wrapf(err, "cron input %s has invalid spec", input.Name)
+	}
+	next := time.Now()
+	if input.Start != nil && input.Start.AsTime().After(next) {
+		next = input.Start.AsTime()
+	}
+	for {
+		next = schedule.Next(next)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return errors.EnsureStack(ctx.Err())
+		case <-timer.C:
+		}
+		if err := cronTick(ctx, c, input, next); err != nil {
+			log.WithError(err).WithField("input", input.Name).Error("cron tick failed")
+		}
+		if now := time.Now(); now.After(next) {
+			next = now
+		}
+	}
+}
+
+// cronTick makes the commit of a cron input for the tick at t.
+func cronTick(ctx context.Context, c pfs.APIClient, input *pps.CronInput, t time.Time) error {
+	branch := (&pfs.Repo{Name: input.Repo, Type: pfs.UserRepoType}).NewBranch(cronBranch)
+	name := t.UTC().Format(time.RFC3339)
+	if input.Sql != nil {
+		ingest := proto.Clone(input.Sql).(*pfs.SQLIngest)
+		if ingest.Path == "" {
+			ingest.Path = "/" + input.Name
+		}
+		if !input.Overwrite {
+			ingest.Path = path.Join(ingest.Path, name)
+		}
+		_, err := c.IngestSQL(ctx, &pfs.IngestSQLRequest{Branch: branch, Sql: ingest})
+		return errors.EnsureStack(err)
+	}
+
+	commit, err := c.StartCommit(ctx, &pfs.StartCommitRequest{Branch: branch})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	finish := &pfs.FinishCommitRequest{Commit: commit}
+	tickErr := addCronFile(ctx, c, commit, "/"+name, input.Overwrite)
+	if tickErr != nil {
+		// don't leave an open commit on the branch
+		finish.Error = tickErr.Error()
+	}
+	if _, err := c.FinishCommit(ctx, finish); err != nil {
+		return errors.EnsureStack(err)
+	}
+	return tickErr
+}
+
+// addCronFile adds an empty file at p to commit, first deleting everything
+// else if overwrite is set.
+func addCronFile(ctx context.Context, c pfs.APIClient, commit *pfs.Commit, p string, overwrite bool) error {
+	mfc, err := c.ModifyFile(ctx)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	reqs := []*pfs.ModifyFileRequest{{Body: &pfs.ModifyFileRequest_SetCommit{SetCommit: commit}}}
+	if overwrite {
+		reqs = append(reqs, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_DeleteFile{DeleteFile: &pfs.DeleteFile{Path: "/"}}})
+	}
+	reqs = append(reqs, &pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+		Path:   p,
+		Source: &pfs.AddFile_Raw{Raw: wrapperspb.Bytes(nil)},
+	}}})
+	for _, req := range reqs {
+		if err := mfc.Send(req); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	_, err = mfc.CloseAndRecv()
+	return errors.EnsureStack(err)
+}
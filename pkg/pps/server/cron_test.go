@@ -0,0 +1,126 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	"github.com/bhojpur/data/pkg/internal/require"
+	pfsserver "github.com/bhojpur/data/pkg/pfs/server"
+)
+
+func newTestPFSClient(t *testing.T) pfs.APIClient {
+	apiServer, err := pfsserver.NewAPIServer(context.Background(), pfsserver.Env{StorageRoot: t.TempDir()})
+	require.NoError(t, err)
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pfs.RegisterAPIServer(s, apiServer)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pfs.NewAPIClient(conn)
+}
+
+func listFiles(t *testing.T, c pfs.APIClient, repo string) []string {
+	commit := (&pfs.Repo{Name: repo, Type: pfs.UserRepoType}).NewCommit(cronBranch, "")
+	lfc, err := c.ListFile(context.Background(), &pfs.ListFileRequest{File: commit.NewFile("/")})
+	require.NoError(t, err)
+	var paths []string
+	for {
+		fi, err := lfc.Recv()
+		if err == io.EOF {
+			return paths
+		}
+		require.NoError(t, err)
+		paths = append(paths, fi.File.Path)
+	}
+}
+
+func TestCronTick(t *testing.T) {
+	c := newTestPFSClient(t)
+	ctx := context.Background()
+	_, err := c.CreateRepo(ctx, &pfs.CreateRepoRequest{Repo: &pfs.Repo{Name: "tick", Type: pfs.UserRepoType}})
+	require.NoError(t, err)
+	t1 := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(24 * time.Hour)
+	t3 := t2.Add(24 * time.Hour)
+
+	input := &pps.CronInput{Name: "tick", Repo: "tick", Spec: "@daily"}
+	require.NoError(t, cronTick(ctx, c, input, t1))
+	require.NoError(t, cronTick(ctx, c, input, t2))
+	require.Equal(t, []string{"/2021-03-04T00:00:00Z", "/2021-03-05T00:00:00Z"}, listFiles(t, c, "tick"))
+	input.Overwrite = true
+	require.NoError(t, cronTick(ctx, c, input, t3))
+	require.Equal(t, []string{"/2021-03-06T00:00:00Z"}, listFiles(t, c, "tick"))
+
+	// an input without a repo fails, rather than committing anywhere else
+	require.YesError(t, cronTick(ctx, c, &pps.CronInput{Name: "missing", Repo: "missing", Spec: "@daily"}, t1))
+}
+
+// ingestClient records the SQL ingests it is asked for.
+type ingestClient struct {
+	pfs.APIClient
+	requests []*pfs.IngestSQLRequest
+}
+
+func (c *ingestClient) IngestSQL(ctx context.Context, req *pfs.IngestSQLRequest, _ ...grpc.CallOption) (*pfs.IngestSQLResponse, error) {
+	c.requests = append(c.requests, req)
+	return &pfs.IngestSQLResponse{}, nil
+}
+
+func TestCronTickSQL(t *testing.T) {
+	c := &ingestClient{}
+	ctx := context.Background()
+	t1 := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	sql := &pfs.SQLIngest{Url: "postgres://db/app", Query: "SELECT * FROM users", Delimiter: pfs.Delimiter_CSV}
+	input := &pps.CronInput{Name: "users", Repo: "snapshots", Spec: "@daily", Sql: sql}
+	require.NoError(t, cronTick(ctx, c, input, t1))
+	input.Overwrite = true
+	require.NoError(t, cronTick(ctx, c, input, t1))
+
+	require.Equal(t, 2, len(c.requests))
+	require.Equal(t, "snapshots@master", c.requests[0].Branch.Key())
+	require.Equal(t, "/users/2021-03-04T00:00:00Z", c.requests[0].Sql.Path)
+	require.Equal(t, "/users", c.requests[1].Sql.Path)
+	require.Equal(t, "SELECT * FROM users", c.requests[1].Sql.Query)
+	// the input itself is left as it is
+	require.Equal(t, "", sql.Path)
+}
+
+func TestRunCronInvalidSpec(t *testing.T) {
+	err := RunCron(context.Background(), &ingestClient{}, &pps.CronInput{Name: "bad", Repo: "bad", Spec: "every day"})
+	require.YesError(t, err)
+}
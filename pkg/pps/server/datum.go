@@ -0,0 +1,227 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/errors"
+)
+
+const (
+	// datumCachePrefix prefixes the cache tags of datum outputs.
+	datumCachePrefix = "datums/"
+	// datumTaskGroup is the group of the tasks that process datums.
+	datumTaskGroup = "datums"
+	// datumWorkers is how many datums of a job are processed at once.
+	datumWorkers = 4
+)
+
+// DatumCache memoises the outputs of a pipeline's datums in the PFS cache,
+// so that a datum whose input files are unchanged since it was last
+// processed can be skipped, and its output reused. Outputs are file sets,
+// which the cache keeps alive for as long as they are cached. Outputs are
+// only reused by pipelines with the same salt, so that changing a pipeline's
+// salt reprocesses every datum.
+type DatumCache struct {
+	c   pfs.APIClient
+	tag string
+}
+
+// NewDatumCache returns the DatumCache of a pipeline with the given salt.
+func NewDatumCache(c pfs.APIClient, pipeline *pps.Pipeline, salt string) *DatumCache {
+	return &DatumCache{c: c, tag: datumCacheTag(pipeline) + salt}
+}
+
+func datumCacheTag(pipeline *pps.Pipeline) string {
+	return datumCachePrefix + pipeline.Name + "/"
+}
+
+// DatumID returns the ID of the datum made of inputs, which only depends on
+// the paths and content hashes of the input files, in order.
+func DatumID(inputs []*pps.InputFile) string {
+	h := sha256.New()
+	for _, input := range inputs {
+		// paths cannot contain NUL, so the fields are unambiguous
+		h.Write([]byte(input.Path))
+		h.Write([]byte{0})
+		h.Write(input.Hash)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (dc *DatumCache) key(inputs []*pps.InputFile) string {
+	return dc.tag + "/" + DatumID(inputs)
+}
+
+// Get returns the ID of the file set with the output of the datum made of
+// inputs, or "" if it is not cached.
+func (dc *DatumCache) Get(ctx context.Context, inputs []*pps.InputFile) (string, error) {
+	resp, err := dc.c.GetCache(ctx, &pfs.GetCacheRequest{Key: dc.key(inputs)})
+	if err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	if resp.Value == nil {
+		return "", nil
+	}
+	var output wrapperspb.StringValue
+	if err := resp.Value.UnmarshalTo(&output); err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return output.Value, nil
+}
+
+// Put caches the file set fileSetID as the output of the datum made of
+// inputs.
+func (dc *DatumCache) Put(ctx context.Context, inputs []*pps.InputFile, fileSetID string) error {
+	value, err := anypb.New(wrapperspb.String(fileSetID))
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = dc.c.PutCache(ctx, &pfs.PutCacheRequest{
+		Key:        dc.key(inputs),
+		Value:      value,
+		FileSetIds: []string{fileSetID},
+		Tag:        dc.tag,
+	})
+	return errors.EnsureStack(err)
+}
+
+// ClearDatumCache forgets the datum outputs of a pipeline, for every salt.
+func ClearDatumCache(ctx context.Context, c pfs.APIClient, pipeline *pps.Pipeline) error {
+	_, err := c.ClearCache(ctx, &pfs.ClearCacheRequest{TagPrefix: datumCacheTag(pipeline)})
+	return errors.EnsureStack(err)
+}
+
+// DatumFunc processes the datum made of inputs, returning the ID of the file
+// set with its output.
+type DatumFunc func(ctx context.Context, inputs []*pps.InputFile) (string, error)
+
+// datumNamespace returns the task namespace of the datums of job.
+func datumNamespace(job *pps.Job) string {
+	return "pps/" + job.Key()
+}
+
+// ProcessDatums processes each of the datums of job with f, as a task of the
+// server's queue, and returns the IDs of the file sets with their outputs,
+// in order. Datums whose output is in dc are skipped, and the outputs of the
+// others are added to it; if dc is nil, every datum is processed. A datum
+// that fails is retried with backoff, and fails the job once its retries
+// give up.
+func (a *apiServer) ProcessDatums(ctx context.Context, job *pps.Job, dc *DatumCache, datums [][]*pps.InputFile, f DatumFunc) ([]string, error) {
+	outputs := make([]string, len(datums))
+	var inputs []*anypb.Any
+	var indexes []int
+	for i, datum := range datums {
+		if dc != nil {
+			id, err := dc.Get(ctx, datum)
+			if err != nil {
+				return nil, err
+			}
+			if id != "" {
+				outputs[i] = id
+				continue
+			}
+		}
+		input, err := anypb.New(datumTask(job, datum))
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		inputs = append(inputs, input)
+		indexes = append(indexes, i)
+	}
+	if len(inputs) == 0 {
+		return outputs, nil
+	}
+
+	process := func(ctx context.Context, input *anypb.Any) (*anypb.Any, error) {
+		var info pps.DatumInfo
+		if err := input.UnmarshalTo(&info); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		datum := make([]*pps.InputFile, len(info.Data))
+		for i, fi := range info.Data {
+			datum[i] = &pps.InputFile{Path: fi.File.Path, Hash: fi.Hash}
+		}
+		id, err := f(ctx, datum)
+		if err != nil {
+			return nil, err
+		}
+		if dc != nil {
+			if err := dc.Put(ctx, datum, id); err != nil {
+				return nil, err
+			}
+		}
+		output, err := anypb.New(wrapperspb.String(id))
+		return output, errors.EnsureStack(err)
+	}
+	namespace := datumNamespace(job)
+	wctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	for i := 0; i < datumWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.tasks.Work(wctx, namespace, process)
+		}()
+	}
+	group := &taskapi.Group{Namespace: namespace, Group: datumTaskGroup}
+	if err := a.tasks.Do(ctx, group, inputs, func(i int, output *anypb.Any, err error) error {
+		if err != nil {
+			return errors.Wrapf(err, "datum %s of job %v failed", DatumID(datums[indexes[i]]), job)
+		}
+		var id wrapperspb.StringValue
+		if err := output.UnmarshalTo(&id); err != nil {
+			return errors.EnsureStack(err)
+		}
+		outputs[indexes[i]] = id.Value
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// datumTask returns the task input of the datum of job made of inputs.
+func datumTask(job *pps.Job, inputs []*pps.InputFile) *pps.DatumInfo {
+	info := &pps.DatumInfo{
+		Datum: &pps.Datum{Job: job, Id: DatumID(inputs)},
+		State: pps.DatumState_STARTING,
+	}
+	for _, input := range inputs {
+		info.Data = append(info.Data, &pfs.FileInfo{File: &pfs.File{Path: input.Path}, Hash: input.Hash})
+	}
+	return info
+}
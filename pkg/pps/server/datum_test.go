@@ -0,0 +1,203 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/bhojpur/data/pkg/api/v1/pfs"
+	"github.com/bhojpur/data/pkg/api/v1/pps"
+	taskapi "github.com/bhojpur/data/pkg/api/v1/task"
+	"github.com/bhojpur/data/pkg/internal/errors"
+	"github.com/bhojpur/data/pkg/internal/require"
+)
+
+func newTestPPSClient(t *testing.T, apiServer APIServer) pps.APIClient {
+	l := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	pps.RegisterAPIServer(s, apiServer)
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return l.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return pps.NewAPIClient(conn)
+}
+
+// outputFileSet creates a file set holding data at /out.
+func outputFileSet(ctx context.Context, c pfs.APIClient, data string) (string, error) {
+	cfc, err := c.CreateFileSet(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := cfc.Send(&pfs.ModifyFileRequest{Body: &pfs.ModifyFileRequest_AddFile{AddFile: &pfs.AddFile{
+		Path:   "/out",
+		Source: &pfs.AddFile_Raw{Raw: wrapperspb.Bytes([]byte(data))},
+	}}}); err != nil {
+		return "", err
+	}
+	resp, err := cfc.CloseAndRecv()
+	if err != nil {
+		return "", err
+	}
+	return resp.FileSetId, nil
+}
+
+func TestDatumCache(t *testing.T) {
+	c := newTestPFSClient(t)
+	ctx := context.Background()
+	pipeline := &pps.Pipeline{Name: "edges"}
+	inputs := []*pps.InputFile{{Path: "/a", Hash: []byte{1}}, {Path: "/b", Hash: []byte{2}}}
+	changed := []*pps.InputFile{{Path: "/a", Hash: []byte{1}}, {Path: "/b", Hash: []byte{3}}}
+	require.NotEqual(t, DatumID(inputs), DatumID(changed))
+
+	output, err := outputFileSet(ctx, c, "output")
+	require.NoError(t, err)
+
+	dc := NewDatumCache(c, pipeline, "salt")
+	require.NoError(t, dc.Put(ctx, inputs, output))
+	id, err := dc.Get(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, output, id)
+	id, err = dc.Get(ctx, changed)
+	require.NoError(t, err)
+	require.Equal(t, "", id)
+	// outputs are not shared across salts
+	id, err = NewDatumCache(c, pipeline, "other").Get(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, "", id)
+
+	require.NoError(t, ClearDatumCache(ctx, c, &pps.Pipeline{Name: "edge"}))
+	id, err = dc.Get(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, output, id)
+	require.NoError(t, ClearDatumCache(ctx, c, pipeline))
+	id, err = dc.Get(ctx, inputs)
+	require.NoError(t, err)
+	require.Equal(t, "", id)
+}
+
+func TestProcessDatums(t *testing.T) {
+	c := newTestPFSClient(t)
+	a := NewAPIServer()
+	pc := newTestPPSClient(t, a)
+	ctx := context.Background()
+	pipeline := &pps.Pipeline{Name: "edges"}
+	job := &pps.Job{Pipeline: pipeline, Id: "1"}
+	dc := NewDatumCache(c, pipeline, "")
+	cached := []*pps.InputFile{{Path: "/cached", Hash: []byte{1}}}
+	flaky := []*pps.InputFile{{Path: "/flaky", Hash: []byte{2}}}
+	stuck := []*pps.InputFile{{Path: "/stuck", Hash: []byte{3}}}
+	cachedOutput, err := outputFileSet(ctx, c, "cached")
+	require.NoError(t, err)
+	require.NoError(t, dc.Put(ctx, cached, cachedOutput))
+
+	// the flaky datum fails once, then both it and the stuck datum block
+	// until they are released
+	release := make(chan struct{})
+	var mu sync.Mutex
+	attempts := make(map[string]int)
+	var outputs []string
+	done := make(chan error, 1)
+	go func() {
+		outs, err := a.ProcessDatums(ctx, job, dc, [][]*pps.InputFile{cached, flaky, stuck}, func(ctx context.Context, inputs []*pps.InputFile) (string, error) {
+			p := inputs[0].Path
+			mu.Lock()
+			attempts[p]++
+			n := attempts[p]
+			mu.Unlock()
+			if p == "/flaky" && n == 1 {
+				return "", errors.New("transient failure")
+			}
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+			return outputFileSet(ctx, c, p)
+		})
+		outputs = outs
+		done <- err
+	}()
+
+	list := func() []*taskapi.TaskInfo {
+		ltc, err := pc.ListTask(ctx, &taskapi.ListTaskRequest{Group: &taskapi.Group{Namespace: datumNamespace(job)}})
+		require.NoError(t, err)
+		var infos []*taskapi.TaskInfo
+		for {
+			info, err := ltc.Recv()
+			if err == io.EOF {
+				return infos
+			}
+			require.NoError(t, err)
+			infos = append(infos, info)
+		}
+	}
+	// the cached datum is skipped, and the others are claimed, the flaky one
+	// after being retried
+	require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+		infos := list()
+		if len(infos) != 2 {
+			return errors.Errorf("listed %d tasks rather than 2", len(infos))
+		}
+		for _, info := range infos {
+			if info.State != taskapi.State_CLAIMED {
+				return errors.Errorf("task %s is %v", info.Id, info.State)
+			}
+		}
+		if infos[0].Reason == "" {
+			return errors.New("the flaky datum has not been retried")
+		}
+		return nil
+	})
+	infos := list()
+	require.Equal(t, datumTaskGroup, infos[0].Group.Group)
+	require.Equal(t, "type.googleapis.com/v1.pps.DatumInfo", infos[0].InputType)
+	require.True(t, strings.Contains(infos[0].InputData, "/flaky"))
+	require.Equal(t, "retrying: transient failure", infos[0].Reason)
+	require.True(t, strings.Contains(infos[1].InputData, "/stuck"))
+	require.Equal(t, "", infos[1].Reason)
+
+	close(release)
+	require.NoError(t, <-done)
+	require.Equal(t, 3, len(outputs))
+	require.Equal(t, cachedOutput, outputs[0])
+	require.Equal(t, map[string]int{"/flaky": 2, "/stuck": 1}, attempts)
+	require.Equal(t, 0, len(list()))
+	// processed outputs are cached
+	id, err := dc.Get(ctx, stuck)
+	require.NoError(t, err)
+	require.Equal(t, outputs[2], id)
+}
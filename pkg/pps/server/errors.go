@@ -0,0 +1,68 @@
+package server
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrSecretNotFound is returned when a secret does not exist.
+type ErrSecretNotFound struct {
+	Name string
+}
+
+func (e ErrSecretNotFound) Error() string {
+	return fmt.Sprintf("secret %s not found", e.Name)
+}
+
+func (e ErrSecretNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
+
+// ErrSecretExists is returned when creating a secret that already exists.
+type ErrSecretExists struct {
+	Name string
+}
+
+func (e ErrSecretExists) Error() string {
+	return fmt.Sprintf("secret %s already exists", e.Name)
+}
+
+func (e ErrSecretExists) GRPCStatus() *status.Status {
+	return status.New(codes.AlreadyExists, e.Error())
+}
+
+// ErrSecretKeyNotFound is returned when a secret has no value for a key.
+type ErrSecretKeyNotFound struct {
+	Name string
+	Key  string
+}
+
+func (e ErrSecretKeyNotFound) Error() string {
+	return fmt.Sprintf("secret %s has no key %s", e.Name, e.Key)
+}
+
+func (e ErrSecretKeyNotFound) GRPCStatus() *status.Status {
+	return status.New(codes.NotFound, e.Error())
+}
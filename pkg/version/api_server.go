@@ -21,26 +21,27 @@ package version
 // THE SOFTWARE.
 
 import (
+	"context"
 	"fmt"
 
 	pb "github.com/bhojpur/data/pkg/api/v1/version"
 	"github.com/bhojpur/data/pkg/internal/errors"
 
-	"github.com/gogo/protobuf/types"
-	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type apiServer struct {
+	pb.UnimplementedAPIServer
 	version *pb.Version
 	options APIServerOptions
 }
 
 func newAPIServer(version *pb.Version, options APIServerOptions) *apiServer {
-	return &apiServer{version, options}
+	return &apiServer{version: version, options: options}
 }
 
-func (a *apiServer) GetVersion(ctx context.Context, request *types.Empty) (response *pb.Version, err error) {
+func (a *apiServer) GetVersion(ctx context.Context, request *emptypb.Empty) (response *pb.Version, err error) {
 	return a.version, nil
 }
 
@@ -58,7 +59,7 @@ func NewAPIServer(version *pb.Version, options APIServerOptions) pb.APIServer {
 func GetServerVersion(clientConn *grpc.ClientConn) (*pb.Version, error) {
 	res, err := pb.NewAPIClient(clientConn).GetVersion(
 		context.Background(),
-		&types.Empty{},
+		&emptypb.Empty{},
 	)
 	return res, errors.EnsureStack(err)
 }